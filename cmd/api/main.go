@@ -6,6 +6,7 @@ import (
 	"final-project-backend/pkg/logger"
 	"final-project-backend/pkg/postgres"
 	"log"
+	"os"
 )
 
 func main() {
@@ -31,6 +32,13 @@ func main() {
 	}
 	appLogger.Infof("Postgres connected")
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(gormDB, os.Args[2:]); err != nil {
+			appLogger.Fatalf("Migrate: %s", err)
+		}
+		return
+	}
+
 	s := server.NewServer(cfg, gormDB, appLogger)
 	if err = s.Run(); err != nil {
 		log.Fatal(err)
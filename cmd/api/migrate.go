@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"final-project-backend/pkg/migrate"
+	"final-project-backend/sql/migrations"
+	"fmt"
+	"gorm.io/gorm"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | to <version> | baseline <version>"
+
+// runMigrate handles the migrate subcommand. Down reverts one migration
+// unless given a number of steps.
+func runMigrate(gormDB *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := gormDB.DB()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("applied", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.New(migrateUsage)
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations("reverted", reverted)
+		return err
+	case "to", "to-version":
+		version, err := parseVersion(args)
+		if err != nil {
+			return err
+		}
+		ran, err := migrator.To(ctx, version)
+		printMigrations("ran", ran)
+		return err
+	case "baseline":
+		version, err := parseVersion(args)
+		if err != nil {
+			return err
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		printMigrations("recorded", recorded)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			name := status.Migration.Name
+			if name == "" {
+				name = "(unknown to this binary)"
+			}
+			fmt.Printf("%06d  %-40s %s\n", status.Migration.Version, name, appliedAt)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+func parseVersion(args []string) (int64, error) {
+	if len(args) < 2 {
+		return 0, errors.New(migrateUsage)
+	}
+
+	version, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || version < 0 {
+		return 0, errors.New(migrateUsage)
+	}
+
+	return version, nil
+}
+
+func printMigrations(verb string, ran []*migrate.Migration) {
+	for _, migration := range ran {
+		fmt.Printf("%s %06d_%s\n", verb, migration.Version, migration.Name)
+	}
+	if len(ran) == 0 {
+		fmt.Println("nothing to do")
+	}
+}
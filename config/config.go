@@ -11,6 +11,12 @@ type Config struct {
 	Server   ServerConfig
 	Postgres PostgresConfig
 	Logger   Logger
+	Gateway  GatewayConfig
+	Scoring  ScoringConfig
+	Storage  StorageConfig
+	Overdue  OverdueConfig
+	Voucher  VoucherConfig
+	Metrics  MetricsConfig
 }
 
 type ServerConfig struct {
@@ -23,6 +29,7 @@ type ServerConfig struct {
 	ReadTimeout       time.Duration
 	WriteTimeout      time.Duration
 	CtxDefaultTimeout time.Duration
+	ShutdownDelay     time.Duration
 	Debug             bool
 }
 
@@ -34,6 +41,54 @@ type Logger struct {
 	Level             string
 }
 
+type GatewayConfig struct {
+	Provider             string
+	CallbackSecret       string
+	CallbackTolerance    time.Duration
+	IntentExpMin         int
+	VirtualAccountPrefix string
+}
+
+type ScoringConfig struct {
+	Version           string
+	BaseScore         int
+	MaxScore          int
+	OnTimePoints      int
+	LatePoints        int
+	DelayPoints       int
+	UtilizationPoints int
+	TenurePoints      int
+	MaxTenureMonths   int
+	MinLimitScore     int
+	LimitPerPoint     float64
+	LimitStep         float64
+	ProposalJob       bool
+	ProposalInterval  time.Duration
+}
+
+type StorageConfig struct {
+	Driver    string
+	LocalPath string
+}
+
+type OverdueConfig struct {
+	NotifyJob      bool
+	NotifyInterval time.Duration
+}
+
+type VoucherConfig struct {
+	LifecycleJob      bool
+	LifecycleInterval time.Duration
+}
+
+// MetricsConfig serves Prometheus metrics on Path to scrapers that send
+// Token as a bearer token.
+type MetricsConfig struct {
+	Enabled bool
+	Path    string
+	Token   string
+}
+
 type PostgresConfig struct {
 	PostgresqlHost     string
 	PostgresqlPort     string
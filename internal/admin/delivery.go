@@ -13,10 +13,43 @@ type Handlers interface {
 	GetInstallmentByID(c *gin.Context)
 	UpdateInstallmentByID(c *gin.Context)
 	UpdateDebtorByID(c *gin.Context)
+	GetDebtorScore(c *gin.Context)
+	GetKycSubmissions(c *gin.Context)
+	GetKycDocument(c *gin.Context)
+	ReviewKyc(c *gin.Context)
+	GetCreditLimitProposals(c *gin.Context)
+	RespondCreditLimitProposal(c *gin.Context)
 	CreateVoucher(c *gin.Context)
 	GetVouchers(c *gin.Context)
 	GetVoucherByID(c *gin.Context)
 	DeleteVoucher(c *gin.Context)
 	UpdateVoucher(c *gin.Context)
+	GetVoucherCodes(c *gin.Context)
+	CreateVoucherCodes(c *gin.Context)
+	GetVoucherStats(c *gin.Context)
+	GetLoanProducts(c *gin.Context)
+	GetLoanProductByID(c *gin.Context)
+	CreateLoanProduct(c *gin.Context)
+	UpdateLoanProduct(c *gin.Context)
+	DeleteLoanProduct(c *gin.Context)
 	GetSummary(c *gin.Context)
+	ProposeRestructuring(c *gin.Context)
+	GetRestructurings(c *gin.Context)
+	WriteOffLoan(c *gin.Context)
+	ReversePayment(c *gin.Context)
+	ImportStatement(c *gin.Context)
+	GetReconciliationItems(c *gin.Context)
+	ResolveReconciliationItem(c *gin.Context)
+	GetRejectionReasons(c *gin.Context)
+	CreateRejectionReason(c *gin.Context)
+	UpdateRejectionReason(c *gin.Context)
+	GetRejectionReport(c *gin.Context)
+	GetAnalytics(c *gin.Context)
+	GetAgingReport(c *gin.Context)
+	GetParReport(c *gin.Context)
+	GetRollRateReport(c *gin.Context)
+	GetVintageReport(c *gin.Context)
+	BulkLoanAction(c *gin.Context)
+	BulkAdvanceContract(c *gin.Context)
+	BulkSetCreditLimit(c *gin.Context)
 }
@@ -1,12 +1,30 @@
 package body
 
 const (
-	InvalidContractStatusFormatMessage = "Invalid contract status format."
-	InvalidCreditHealthFormatMessage   = "Invalid credit health format."
-	InvalidCreditLimitFormatMessage    = "Invalid credit limit format."
-	InvalidDateFormatMessage           = "Invalid due date format."
-	InvalidNameFormatMessage           = "Invalid name format."
-	InvalidDiscountFormatMessage       = "Invalid discount format."
+	InvalidContractStatusFormatMessage  = "Invalid contract status format."
+	InvalidCreditHealthFormatMessage    = "Invalid credit health format."
+	InvalidCreditLimitFormatMessage     = "Invalid credit limit format."
+	InvalidDateFormatMessage            = "Invalid due date format."
+	InvalidNameFormatMessage            = "Invalid name format."
+	InvalidDiscountFormatMessage        = "Invalid discount format."
+	InvalidDiscountTypeFormatMessage    = "Invalid discount type format."
+	InvalidFileFormatMessage            = "Invalid file format."
+	InvalidStatementFormatMessage       = "Invalid statement format."
+	InvalidActionFormatMessage          = "Invalid action format."
+	InvalidNoteFormatMessage            = "Invalid note format."
+	InvalidPaymentIntentIDFormatMessage = "Invalid payment intent id format."
+	InvalidInstallmentIDFormatMessage   = "Invalid installment id format."
+	InvalidReasonFormatMessage          = "Invalid reason format."
+	InvalidTenorFormatMessage           = "Invalid tenor format."
+	InvalidInstallmentAmountMessage     = "Invalid installment amount format."
+	InvalidAmountFormatMessage          = "Invalid amount format."
+	InvalidPercentageFormatMessage      = "Invalid percentage format."
+	InvalidFineFormatMessage            = "Invalid fine format."
+	InvalidCodeFormatMessage            = "Invalid code format."
+	InvalidBulkItemsFormatMessage       = "Invalid bulk items format."
+	InvalidLoanProductIDFormatMessage   = "Invalid loan product id format."
+	InvalidVoucherCodeFormatMessage     = "Invalid voucher code format."
+	InvalidCountFormatMessage           = "Invalid count format."
 )
 
 type UnprocessableEntity struct {
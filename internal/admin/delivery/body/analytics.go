@@ -0,0 +1,42 @@
+package body
+
+import "time"
+
+type AnalyticsPoint struct {
+	Period               time.Time `json:"period"`
+	DisbursedAmount      float64   `json:"disbursed_amount"`
+	RepaidAmount         float64   `json:"repaid_amount"`
+	FinesCollected       float64   `json:"fines_collected"`
+	VoucherDiscounts     float64   `json:"voucher_discounts"`
+	NewDebtors           int64     `json:"new_debtors"`
+	ApprovedLoans        int64     `json:"approved_loans"`
+	RejectedLoans        int64     `json:"rejected_loans"`
+	ApprovalRate         float64   `json:"approval_rate"`
+	AverageApprovalHours float64   `json:"average_approval_hours"`
+	approvalSeconds      float64
+	timedApprovals       int64
+}
+
+// AddApprovals adds decided loans and derives the approval rate and average
+// approval time from everything added so far.
+func (p *AnalyticsPoint) AddApprovals(approved, rejected int64, approvalSeconds float64, timedApprovals int64) {
+	p.ApprovedLoans += approved
+	p.RejectedLoans += rejected
+	p.approvalSeconds += approvalSeconds
+	p.timedApprovals += timedApprovals
+
+	if decided := p.ApprovedLoans + p.RejectedLoans; decided > 0 {
+		p.ApprovalRate = float64(p.ApprovedLoans) / float64(decided)
+	}
+	if p.timedApprovals > 0 {
+		p.AverageApprovalHours = p.approvalSeconds / float64(p.timedApprovals) / 3600
+	}
+}
+
+type AnalyticsResponse struct {
+	Granularity string            `json:"granularity"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Totals      *AnalyticsPoint   `json:"totals"`
+	Series      []*AnalyticsPoint `json:"series"`
+}
@@ -0,0 +1,43 @@
+package body
+
+import (
+	"errors"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+)
+
+const maxBulkItems = 100
+
+type BulkItemResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResponse reports every item of a bulk request. Items are independent,
+// so some may succeed while others fail.
+type BulkResponse struct {
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Items     []*BulkItemResult `json:"items"`
+}
+
+// Add records the outcome of one item. Only client errors are reported
+// verbatim; anything else is reported as an internal error.
+func (r *BulkResponse) Add(id string, err error) {
+	item := &BulkItemResult{ID: id, Success: err == nil}
+	if err != nil {
+		item.Error = response.InternalServerErrorMessage
+		var e *httperror.Error
+		if errors.As(err, &e) {
+			item.Error = e.Err.Error()
+		}
+		r.Failed++
+	} else {
+		r.Succeeded++
+	}
+
+	r.Total++
+	r.Items = append(r.Items, item)
+}
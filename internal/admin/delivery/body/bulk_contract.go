@@ -0,0 +1,34 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+)
+
+type BulkContractRequest struct {
+	DebtorIDs []string `json:"debtor_ids"`
+}
+
+func (r *BulkContractRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"debtor_ids": "",
+		},
+	}
+
+	if len(r.DebtorIDs) == 0 || len(r.DebtorIDs) > maxBulkItems {
+		unprocessableEntity = true
+		entity.Fields["debtor_ids"] = InvalidBulkItemsFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,129 @@
+package body
+
+import (
+	"encoding/csv"
+	"errors"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const maxCreditLimitFileSize = 1 << 20
+
+type BulkCreditLimitRequest struct {
+	File *multipart.FileHeader `form:"file"`
+	Rows []*CreditLimitRow     `form:"-"`
+}
+
+// CreditLimitRow is one line of the uploaded file. Err is set when the line
+// could not be read, so it is reported without failing the other lines.
+type CreditLimitRow struct {
+	DebtorID    string
+	CreditLimit float64
+	Err         error
+}
+
+func (r *BulkCreditLimitRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"file": "",
+		},
+	}
+
+	if r.File == nil || r.File.Size == 0 || r.File.Size > maxCreditLimitFileSize ||
+		strings.ToLower(filepath.Ext(r.File.Filename)) != ".csv" {
+		unprocessableEntity = true
+		entity.Fields["file"] = InvalidFileFormatMessage
+	}
+
+	if !unprocessableEntity {
+		rows, err := readCreditLimitRows(r.File)
+		if err != nil || len(rows) == 0 || len(rows) > maxBulkItems {
+			unprocessableEntity = true
+			entity.Fields["file"] = InvalidFileFormatMessage
+		}
+		r.Rows = rows
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
+
+// readCreditLimitRows reads a CSV with a header row containing the debtor_id
+// and credit_limit columns.
+func readCreditLimitRows(file *multipart.FileHeader) ([]*CreditLimitRow, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	debtorColumn, ok := columns["debtor_id"]
+	if !ok {
+		return nil, errors.New("file is missing debtor_id column")
+	}
+	limitColumn, ok := columns["credit_limit"]
+	if !ok {
+		return nil, errors.New("file is missing credit_limit column")
+	}
+
+	var rows []*CreditLimitRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := &CreditLimitRow{}
+		if debtorColumn < len(record) {
+			row.DebtorID = strings.TrimSpace(record[debtorColumn])
+		}
+
+		if limitColumn >= len(record) {
+			row.Err = httperror.New(http.StatusBadRequest, response.InvalidBulkRow)
+		} else {
+			limit, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(record[limitColumn]), ",", ""), 64)
+			if err != nil || limit <= 0 {
+				row.Err = httperror.New(http.StatusBadRequest, response.InvalidBulkRow)
+			}
+			row.CreditLimit = limit
+		}
+
+		if row.DebtorID == "" {
+			row.Err = httperror.New(http.StatusBadRequest, response.InvalidBulkRow)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
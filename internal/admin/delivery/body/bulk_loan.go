@@ -0,0 +1,65 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+const (
+	BulkLoanActionApprove = "approve"
+	BulkLoanActionReject  = "reject"
+)
+
+type BulkLoanRequest struct {
+	Action     string   `json:"action"`
+	LendingIDs []string `json:"lending_ids"`
+	ReasonID   int      `json:"reason_id"`
+	Note       string   `json:"note"`
+}
+
+func (r *BulkLoanRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"action":      "",
+			"lending_ids": "",
+			"reason_id":   "",
+			"note":        "",
+		},
+	}
+
+	if len(r.LendingIDs) == 0 || len(r.LendingIDs) > maxBulkItems {
+		unprocessableEntity = true
+		entity.Fields["lending_ids"] = InvalidBulkItemsFormatMessage
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	switch r.Action {
+	case BulkLoanActionApprove:
+	case BulkLoanActionReject:
+		if r.ReasonID < 1 {
+			unprocessableEntity = true
+			entity.Fields["reason_id"] = InvalidReasonFormatMessage
+		}
+
+		if r.Note == "" {
+			unprocessableEntity = true
+			entity.Fields["note"] = InvalidNoteFormatMessage
+		}
+	default:
+		unprocessableEntity = true
+		entity.Fields["action"] = InvalidActionFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,47 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var rejectionReasonCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type CreateRejectionReasonRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+func (r *CreateRejectionReasonRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"code": "",
+			"name": "",
+		},
+	}
+
+	r.Code = strings.ToLower(strings.TrimSpace(r.Code))
+	if !rejectionReasonCodePattern.MatchString(r.Code) {
+		unprocessableEntity = true
+		entity.Fields["code"] = InvalidCodeFormatMessage
+	}
+
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		unprocessableEntity = true
+		entity.Fields["name"] = InvalidNameFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -3,30 +3,46 @@ package body
 import (
 	"final-project-backend/pkg/httperror"
 	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
 	"net/http"
 	"strings"
 	"time"
 )
 
 type CreateVoucherRequest struct {
-	Name            string    `json:"name"`
-	DiscountPayment int       `json:"discount_payment"`
-	DiscountQuota   int       `json:"discount_quota"`
-	ActiveDate      string    `json:"active_date"`
-	ExpireDate      string    `json:"expire_date"`
-	ActiveDateTime  time.Time `json:"-"`
-	ExpireDateTime  time.Time `json:"-"`
+	Name                  string    `json:"name"`
+	VoucherDiscountTypeID int       `json:"voucher_discount_type_id"`
+	DiscountPayment       int       `json:"discount_payment"`
+	DiscountAmount        float64   `json:"discount_amount"`
+	MaxDiscount           float64   `json:"max_discount"`
+	DiscountQuota         int       `json:"discount_quota"`
+	PerUserLimit          int       `json:"per_user_limit"`
+	MinInstallmentAmount  float64   `json:"min_installment_amount"`
+	CodeRequired          bool      `json:"code_required"`
+	CreditHealthIDs       []int     `json:"credit_health_ids"`
+	LoanProductIDs        []string  `json:"loan_product_ids"`
+	ActiveDate            string    `json:"active_date"`
+	ExpireDate            string    `json:"expire_date"`
+	ActiveDateTime        time.Time `json:"-"`
+	ExpireDateTime        time.Time `json:"-"`
 }
 
 func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 	unprocessableEntity := false
 	entity := UnprocessableEntity{
 		Fields: map[string]string{
-			"name":             "",
-			"discount_payment": "",
-			"discount_quota":   "",
-			"active_date":      "",
-			"expire_date":      "",
+			"name":                     "",
+			"voucher_discount_type_id": "",
+			"discount_payment":         "",
+			"discount_amount":          "",
+			"max_discount":             "",
+			"discount_quota":           "",
+			"per_user_limit":           "",
+			"min_installment_amount":   "",
+			"credit_health_ids":        "",
+			"loan_product_ids":         "",
+			"active_date":              "",
+			"expire_date":              "",
 		},
 	}
 
@@ -36,9 +52,8 @@ func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 		entity.Fields["name"] = InvalidNameFormatMessage
 	}
 
-	if r.DiscountPayment < 1 || r.DiscountPayment > 100 {
+	if !validateVoucherDiscount(&r.VoucherDiscountTypeID, &r.DiscountPayment, &r.DiscountAmount, &r.MaxDiscount, entity.Fields) {
 		unprocessableEntity = true
-		entity.Fields["discount_payment"] = InvalidDiscountFormatMessage
 	}
 
 	if r.DiscountQuota == 0 {
@@ -46,6 +61,31 @@ func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 		entity.Fields["discount_quota"] = InvalidDiscountFormatMessage
 	}
 
+	if r.PerUserLimit < 0 {
+		unprocessableEntity = true
+		entity.Fields["per_user_limit"] = InvalidDiscountFormatMessage
+	}
+
+	if r.MinInstallmentAmount < 0 {
+		unprocessableEntity = true
+		entity.Fields["min_installment_amount"] = InvalidAmountFormatMessage
+	}
+
+	for _, creditHealthID := range r.CreditHealthIDs {
+		if creditHealthID < 1 || creditHealthID > 3 {
+			unprocessableEntity = true
+			entity.Fields["credit_health_ids"] = InvalidCreditHealthFormatMessage
+		}
+	}
+
+	for i, loanProductID := range r.LoanProductIDs {
+		r.LoanProductIDs[i] = strings.TrimSpace(loanProductID)
+		if _, err := uuid.Parse(r.LoanProductIDs[i]); err != nil {
+			unprocessableEntity = true
+			entity.Fields["loan_product_ids"] = InvalidLoanProductIDFormatMessage
+		}
+	}
+
 	loc, _ := time.LoadLocation("Asia/Jakarta")
 
 	r.ActiveDate = strings.TrimSpace(r.ActiveDate)
@@ -79,3 +119,41 @@ func (r *CreateVoucherRequest) Validate() (UnprocessableEntity, error) {
 
 	return entity, nil
 }
+
+// validateVoucherDiscount checks the discount settings for the voucher type.
+// A missing type is a percentage discount, as vouchers were before types.
+// Settings the type does not use are cleared.
+func validateVoucherDiscount(typeID, percentage *int, amount, maxDiscount *float64, fields map[string]string) bool {
+	valid := true
+	if *typeID == 0 {
+		*typeID = 1
+	}
+
+	switch *typeID {
+	case 1, 3:
+		if *percentage < 1 || *percentage > 100 {
+			valid = false
+			fields["discount_payment"] = InvalidDiscountFormatMessage
+		}
+
+		if *maxDiscount < 0 {
+			valid = false
+			fields["max_discount"] = InvalidDiscountFormatMessage
+		}
+
+		*amount = 0
+	case 2:
+		if *amount <= 0 {
+			valid = false
+			fields["discount_amount"] = InvalidDiscountFormatMessage
+		}
+
+		*percentage = 0
+		*maxDiscount = 0
+	default:
+		valid = false
+		fields["voucher_discount_type_id"] = InvalidDiscountTypeFormatMessage
+	}
+
+	return valid
+}
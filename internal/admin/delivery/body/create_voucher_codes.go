@@ -0,0 +1,71 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// maxVoucherCodes bounds how many single use codes one request generates.
+const maxVoucherCodes = 1000
+
+var (
+	voucherCodeFormat   = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{3,31}$`)
+	voucherPrefixFormat = regexp.MustCompile(`^[A-Z0-9]{1,10}$`)
+)
+
+// CreateVoucherCodesRequest either names one shared code, which can be used
+// until the voucher runs out, or asks for a batch of generated single use
+// codes.
+type CreateVoucherCodesRequest struct {
+	Code   string `json:"code"`
+	Count  int    `json:"count"`
+	Prefix string `json:"prefix"`
+}
+
+func (r *CreateVoucherCodesRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"code":   "",
+			"count":  "",
+			"prefix": "",
+		},
+	}
+
+	r.Code = strings.ToUpper(strings.TrimSpace(r.Code))
+	r.Prefix = strings.ToUpper(strings.TrimSpace(r.Prefix))
+
+	if r.Code != "" {
+		if !voucherCodeFormat.MatchString(r.Code) {
+			unprocessableEntity = true
+			entity.Fields["code"] = InvalidVoucherCodeFormatMessage
+		}
+
+		if r.Count != 0 || r.Prefix != "" {
+			unprocessableEntity = true
+			entity.Fields["count"] = InvalidCountFormatMessage
+		}
+	} else {
+		if r.Count < 1 || r.Count > maxVoucherCodes {
+			unprocessableEntity = true
+			entity.Fields["count"] = InvalidCountFormatMessage
+		}
+
+		if r.Prefix != "" && !voucherPrefixFormat.MatchString(r.Prefix) {
+			unprocessableEntity = true
+			entity.Fields["prefix"] = InvalidVoucherCodeFormatMessage
+		}
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
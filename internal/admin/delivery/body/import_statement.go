@@ -0,0 +1,57 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/statement"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const maxStatementSize = 10 << 20
+
+type ImportStatementRequest struct {
+	Format string                `form:"format"`
+	File   *multipart.FileHeader `form:"file"`
+}
+
+func (r *ImportStatementRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"format": "",
+			"file":   "",
+		},
+	}
+
+	if r.File == nil || r.File.Size == 0 || r.File.Size > maxStatementSize {
+		unprocessableEntity = true
+		entity.Fields["file"] = InvalidFileFormatMessage
+	}
+
+	r.Format = strings.ToLower(strings.TrimSpace(r.Format))
+	if r.Format == "" && r.File != nil {
+		switch strings.ToLower(filepath.Ext(r.File.Filename)) {
+		case ".csv":
+			r.Format = statement.FormatCSV
+		case ".sta", ".mt940", ".940":
+			r.Format = statement.FormatMT940
+		}
+	}
+
+	if r.Format != statement.FormatCSV && r.Format != statement.FormatMT940 {
+		unprocessableEntity = true
+		entity.Fields["format"] = InvalidStatementFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
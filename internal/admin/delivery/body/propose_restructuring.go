@@ -0,0 +1,46 @@
+package body
+
+import (
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+)
+
+type ProposeRestructuringRequest struct {
+	Tenor             int     `json:"tenor"`
+	InstallmentAmount float64 `json:"installment_amount"`
+	CapitalizeFines   bool    `json:"capitalize_fines"`
+}
+
+func (r *ProposeRestructuringRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"tenor":              "",
+			"installment_amount": "",
+		},
+	}
+
+	switch {
+	case r.Tenor == 0 && r.InstallmentAmount == 0, r.Tenor != 0 && r.InstallmentAmount != 0:
+		unprocessableEntity = true
+		entity.Fields["tenor"] = InvalidTenorFormatMessage
+		entity.Fields["installment_amount"] = InvalidInstallmentAmountMessage
+	case r.Tenor < 0 || r.Tenor > models.MaxRestructuringTenor:
+		unprocessableEntity = true
+		entity.Fields["tenor"] = InvalidTenorFormatMessage
+	case r.InstallmentAmount < 0:
+		unprocessableEntity = true
+		entity.Fields["installment_amount"] = InvalidInstallmentAmountMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
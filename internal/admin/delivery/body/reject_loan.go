@@ -0,0 +1,43 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type RejectLoanRequest struct {
+	ReasonID int    `json:"reason_id"`
+	Note     string `json:"note"`
+}
+
+func (r *RejectLoanRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"reason_id": "",
+			"note":      "",
+		},
+	}
+
+	if r.ReasonID < 1 {
+		unprocessableEntity = true
+		entity.Fields["reason_id"] = InvalidReasonFormatMessage
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Note == "" {
+		unprocessableEntity = true
+		entity.Fields["note"] = InvalidNoteFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,13 @@
+package body
+
+import (
+	"final-project-backend/internal/models"
+	"time"
+)
+
+type RejectionReportResponse struct {
+	Period string                         `json:"period"`
+	From   time.Time                      `json:"from"`
+	To     time.Time                      `json:"to"`
+	Rows   []*models.RejectionReasonCount `json:"rows"`
+}
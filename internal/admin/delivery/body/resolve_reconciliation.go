@@ -0,0 +1,73 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+const (
+	ReconciliationActionSettle = "settle"
+	ReconciliationActionIgnore = "ignore"
+)
+
+type ResolveReconciliationRequest struct {
+	Action          string `json:"action"`
+	PaymentIntentID string `json:"payment_intent_id"`
+	InstallmentID   string `json:"installment_id"`
+	Note            string `json:"note"`
+}
+
+func (r *ResolveReconciliationRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"action":            "",
+			"payment_intent_id": "",
+			"installment_id":    "",
+			"note":              "",
+		},
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	r.PaymentIntentID = strings.TrimSpace(r.PaymentIntentID)
+	r.InstallmentID = strings.TrimSpace(r.InstallmentID)
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	switch r.Action {
+	case ReconciliationActionSettle:
+		if (r.PaymentIntentID == "") == (r.InstallmentID == "") {
+			unprocessableEntity = true
+			entity.Fields["payment_intent_id"] = InvalidPaymentIntentIDFormatMessage
+			entity.Fields["installment_id"] = InvalidInstallmentIDFormatMessage
+		}
+
+		if _, err := uuid.Parse(r.PaymentIntentID); r.PaymentIntentID != "" && err != nil {
+			unprocessableEntity = true
+			entity.Fields["payment_intent_id"] = InvalidPaymentIntentIDFormatMessage
+		}
+
+		if _, err := uuid.Parse(r.InstallmentID); r.InstallmentID != "" && err != nil {
+			unprocessableEntity = true
+			entity.Fields["installment_id"] = InvalidInstallmentIDFormatMessage
+		}
+	case ReconciliationActionIgnore:
+		if r.Note == "" {
+			unprocessableEntity = true
+			entity.Fields["note"] = InvalidNoteFormatMessage
+		}
+	default:
+		unprocessableEntity = true
+		entity.Fields["action"] = InvalidActionFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
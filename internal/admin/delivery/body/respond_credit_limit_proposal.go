@@ -0,0 +1,41 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+const (
+	CreditLimitProposalActionApprove = "approve"
+	CreditLimitProposalActionReject  = "reject"
+)
+
+type RespondCreditLimitProposalRequest struct {
+	Action string `json:"action"`
+}
+
+func (r *RespondCreditLimitProposalRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"action": "",
+		},
+	}
+
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	if r.Action != CreditLimitProposalActionApprove && r.Action != CreditLimitProposalActionReject {
+		unprocessableEntity = true
+		entity.Fields["action"] = InvalidActionFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,51 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+const (
+	KycActionApprove = "approve"
+	KycActionReject  = "reject"
+)
+
+type ReviewKycRequest struct {
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+func (r *ReviewKycRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"action": "",
+			"reason": "",
+		},
+	}
+
+	r.Reason = strings.TrimSpace(r.Reason)
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	switch r.Action {
+	case KycActionApprove:
+	case KycActionReject:
+		if r.Reason == "" {
+			unprocessableEntity = true
+			entity.Fields["reason"] = InvalidReasonFormatMessage
+		}
+	default:
+		unprocessableEntity = true
+		entity.Fields["action"] = InvalidActionFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
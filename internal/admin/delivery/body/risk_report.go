@@ -0,0 +1,143 @@
+package body
+
+import (
+	"final-project-backend/pkg/export"
+	"time"
+)
+
+type AgingRow struct {
+	Bucket      string  `json:"bucket"`
+	Loans       int     `json:"loans"`
+	Outstanding float64 `json:"outstanding"`
+	Share       float64 `json:"share"`
+}
+
+type AgingReport struct {
+	AsOf             time.Time   `json:"as_of"`
+	TotalLoans       int         `json:"total_loans"`
+	TotalOutstanding float64     `json:"total_outstanding"`
+	Rows             []*AgingRow `json:"rows"`
+}
+
+func (r *AgingReport) ExportColumns() []export.Column {
+	return []export.Column{
+		{Name: "As Of", Kind: export.Date},
+		{Name: "Bucket", Kind: export.Text},
+		{Name: "Loans", Kind: export.Integer},
+		{Name: "Outstanding", Kind: export.Money},
+		{Name: "Share", Kind: export.Ratio},
+	}
+}
+
+func (r *AgingReport) ExportRows() [][]interface{} {
+	var rows [][]interface{}
+	for _, row := range r.Rows {
+		rows = append(rows, []interface{}{r.AsOf, row.Bucket, row.Loans, row.Outstanding, row.Share})
+	}
+
+	return rows
+}
+
+type ParRow struct {
+	Name        string  `json:"name"`
+	MinDays     int     `json:"min_days"`
+	Loans       int     `json:"loans"`
+	Outstanding float64 `json:"outstanding"`
+	Ratio       float64 `json:"ratio"`
+}
+
+type ParReport struct {
+	AsOf             time.Time `json:"as_of"`
+	TotalOutstanding float64   `json:"total_outstanding"`
+	Rows             []*ParRow `json:"rows"`
+}
+
+func (r *ParReport) ExportColumns() []export.Column {
+	return []export.Column{
+		{Name: "As Of", Kind: export.Date},
+		{Name: "Name", Kind: export.Text},
+		{Name: "Min Days", Kind: export.Integer},
+		{Name: "Loans", Kind: export.Integer},
+		{Name: "Outstanding", Kind: export.Money},
+		{Name: "Ratio", Kind: export.Ratio},
+	}
+}
+
+func (r *ParReport) ExportRows() [][]interface{} {
+	var rows [][]interface{}
+	for _, row := range r.Rows {
+		rows = append(rows, []interface{}{r.AsOf, row.Name, row.MinDays, row.Loans, row.Outstanding, row.Ratio})
+	}
+
+	return rows
+}
+
+type RollRateRow struct {
+	Month       time.Time `json:"month"`
+	FromBucket  string    `json:"from_bucket"`
+	ToBucket    string    `json:"to_bucket"`
+	Loans       int       `json:"loans"`
+	Outstanding float64   `json:"outstanding"`
+	Rate        float64   `json:"rate"`
+}
+
+type RollRateReport struct {
+	AsOf   time.Time      `json:"as_of"`
+	Months int            `json:"months"`
+	Rows   []*RollRateRow `json:"rows"`
+}
+
+func (r *RollRateReport) ExportColumns() []export.Column {
+	return []export.Column{
+		{Name: "Month", Kind: export.Date},
+		{Name: "From Bucket", Kind: export.Text},
+		{Name: "To Bucket", Kind: export.Text},
+		{Name: "Loans", Kind: export.Integer},
+		{Name: "Outstanding", Kind: export.Money},
+		{Name: "Rate", Kind: export.Ratio},
+	}
+}
+
+func (r *RollRateReport) ExportRows() [][]interface{} {
+	var rows [][]interface{}
+	for _, row := range r.Rows {
+		rows = append(rows, []interface{}{row.Month, row.FromBucket, row.ToBucket, row.Loans, row.Outstanding, row.Rate})
+	}
+
+	return rows
+}
+
+type VintageRow struct {
+	Cohort         time.Time `json:"cohort"`
+	Loans          int       `json:"loans"`
+	Amount         float64   `json:"amount"`
+	MonthsOnBook   int       `json:"months_on_book"`
+	BadOutstanding float64   `json:"bad_outstanding"`
+	BadRate        float64   `json:"bad_rate"`
+}
+
+type VintageReport struct {
+	AsOf   time.Time     `json:"as_of"`
+	Months int           `json:"months"`
+	Rows   []*VintageRow `json:"rows"`
+}
+
+func (r *VintageReport) ExportColumns() []export.Column {
+	return []export.Column{
+		{Name: "Cohort", Kind: export.Date},
+		{Name: "Loans", Kind: export.Integer},
+		{Name: "Amount", Kind: export.Money},
+		{Name: "Months On Book", Kind: export.Integer},
+		{Name: "Bad Outstanding", Kind: export.Money},
+		{Name: "Bad Rate", Kind: export.Ratio},
+	}
+}
+
+func (r *VintageReport) ExportRows() [][]interface{} {
+	var rows [][]interface{}
+	for _, row := range r.Rows {
+		rows = append(rows, []interface{}{row.Cohort, row.Loans, row.Amount, row.MonthsOnBook, row.BadOutstanding, row.BadRate})
+	}
+
+	return rows
+}
@@ -0,0 +1,116 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type UpdateLoanProductRequest struct {
+	Name            string    `json:"name"`
+	Duration        int       `json:"duration"`
+	Percentage      int       `json:"percentage"`
+	FinePerDay      float64   `json:"fine_per_day"`
+	MinAmount       float64   `json:"min_amount"`
+	MaxAmount       float64   `json:"max_amount"`
+	CreditHealthIDs []int     `json:"credit_health_ids"`
+	ActiveDate      string    `json:"active_date"`
+	ExpireDate      string    `json:"expire_date"`
+	ActiveDateTime  time.Time `json:"-"`
+	ExpireDateTime  time.Time `json:"-"`
+}
+
+func (r *UpdateLoanProductRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"name":              "",
+			"duration":          "",
+			"percentage":        "",
+			"fine_per_day":      "",
+			"min_amount":        "",
+			"max_amount":        "",
+			"credit_health_ids": "",
+			"active_date":       "",
+			"expire_date":       "",
+		},
+	}
+
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		unprocessableEntity = true
+		entity.Fields["name"] = InvalidNameFormatMessage
+	}
+
+	if r.Duration < 1 {
+		unprocessableEntity = true
+		entity.Fields["duration"] = InvalidTenorFormatMessage
+	}
+
+	if r.Percentage < 100 {
+		unprocessableEntity = true
+		entity.Fields["percentage"] = InvalidPercentageFormatMessage
+	}
+
+	if r.FinePerDay < 0 {
+		unprocessableEntity = true
+		entity.Fields["fine_per_day"] = InvalidFineFormatMessage
+	}
+
+	if r.MinAmount <= 0 {
+		unprocessableEntity = true
+		entity.Fields["min_amount"] = InvalidAmountFormatMessage
+	}
+
+	if r.MaxAmount < r.MinAmount {
+		unprocessableEntity = true
+		entity.Fields["max_amount"] = InvalidAmountFormatMessage
+	}
+
+	if len(r.CreditHealthIDs) == 0 {
+		unprocessableEntity = true
+		entity.Fields["credit_health_ids"] = InvalidCreditHealthFormatMessage
+	}
+
+	for _, creditHealthID := range r.CreditHealthIDs {
+		if creditHealthID != 1 && creditHealthID != 2 {
+			unprocessableEntity = true
+			entity.Fields["credit_health_ids"] = InvalidCreditHealthFormatMessage
+		}
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+
+	r.ActiveDate = strings.TrimSpace(r.ActiveDate)
+	activeTime, err := time.ParseInLocation("02-01-2006 15:04:05", r.ActiveDate, loc)
+	if err != nil {
+		unprocessableEntity = true
+		entity.Fields["active_date"] = InvalidDateFormatMessage
+	}
+
+	r.ExpireDate = strings.TrimSpace(r.ExpireDate)
+	expireTime, err := time.ParseInLocation("02-01-2006 15:04:05", r.ExpireDate, loc)
+	if err != nil {
+		unprocessableEntity = true
+		entity.Fields["expire_date"] = InvalidDateFormatMessage
+	}
+
+	if expireTime.Sub(activeTime) < 0 {
+		unprocessableEntity = true
+		entity.Fields["active_date"] = InvalidDateFormatMessage
+		entity.Fields["expire_date"] = InvalidDateFormatMessage
+	}
+
+	r.ActiveDateTime = activeTime
+	r.ExpireDateTime = expireTime
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,37 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type UpdateRejectionReasonRequest struct {
+	Name     string `json:"name"`
+	IsActive bool   `json:"is_active"`
+}
+
+func (r *UpdateRejectionReasonRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"name": "",
+		},
+	}
+
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		unprocessableEntity = true
+		entity.Fields["name"] = InvalidNameFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,11 @@
+package body
+
+import "final-project-backend/internal/models"
+
+// VoucherResponse is a voucher in the admin listing together with the
+// redemptions that still stand against it.
+type VoucherResponse struct {
+	*models.Voucher
+	Redemptions   int64   `json:"redemptions"`
+	DiscountGiven float64 `json:"discount_given"`
+}
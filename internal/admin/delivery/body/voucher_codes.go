@@ -0,0 +1,34 @@
+package body
+
+import (
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/export"
+)
+
+type VoucherCodesResponse struct {
+	Voucher *models.Voucher       `json:"voucher"`
+	Codes   []*models.VoucherCode `json:"codes"`
+}
+
+func (r *VoucherCodesResponse) ExportColumns() []export.Column {
+	return []export.Column{
+		{Name: "Code", Kind: export.Text},
+		{Name: "Voucher", Kind: export.Text},
+		{Name: "Single Use", Kind: export.Text},
+		{Name: "Redeemed At", Kind: export.Date},
+		{Name: "Created At", Kind: export.Date},
+	}
+}
+
+func (r *VoucherCodesResponse) ExportRows() [][]interface{} {
+	var rows [][]interface{}
+	for _, code := range r.Codes {
+		singleUse := "no"
+		if code.SingleUse {
+			singleUse = "yes"
+		}
+		rows = append(rows, []interface{}{code.Code, r.Voucher.Name, singleUse, code.RedeemedAt, code.CreatedAt})
+	}
+
+	return rows
+}
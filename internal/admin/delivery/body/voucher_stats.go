@@ -0,0 +1,36 @@
+package body
+
+import (
+	"final-project-backend/internal/models"
+	"time"
+)
+
+type VoucherStatsPoint struct {
+	Period          time.Time `json:"period"`
+	Redemptions     int64     `json:"redemptions"`
+	LateRedemptions int64     `json:"late_redemptions"`
+	DiscountCost    float64   `json:"discount_cost"`
+}
+
+type VoucherRepaymentRate struct {
+	Installments int64   `json:"installments"`
+	OnTime       int64   `json:"on_time"`
+	OnTimeRate   float64 `json:"on_time_rate"`
+}
+
+// VoucherStatsResponse covers the campaign from the voucher's active date to
+// its expiry, or to now while it is still running. Repayment rates compare
+// installments that fell due in that window.
+type VoucherStatsResponse struct {
+	Voucher             *models.Voucher       `json:"voucher"`
+	Granularity         string                `json:"granularity"`
+	From                time.Time             `json:"from"`
+	To                  time.Time             `json:"to"`
+	Redemptions         int64                 `json:"redemptions"`
+	DiscountCost        float64               `json:"discount_cost"`
+	LateRedemptions     int64                 `json:"late_redemptions"`
+	LateRedemptionShare float64               `json:"late_redemption_share"`
+	VoucherUsers        *VoucherRepaymentRate `json:"voucher_users"`
+	NonUsers            *VoucherRepaymentRate `json:"non_users"`
+	Series              []*VoucherStatsPoint  `json:"series"`
+}
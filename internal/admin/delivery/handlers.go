@@ -5,15 +5,20 @@ import (
 	"final-project-backend/config"
 	"final-project-backend/internal/admin"
 	"final-project-backend/internal/admin/delivery/body"
+	"final-project-backend/pkg/export"
 	"final-project-backend/pkg/httperror"
 	"final-project-backend/pkg/logger"
 	"final-project-backend/pkg/response"
 	"final-project-backend/pkg/utils"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type adminHandlers struct {
@@ -64,7 +69,13 @@ func (h *adminHandlers) GetVoucherByID(c *gin.Context) {
 
 func (h *adminHandlers) ApproveLoan(c *gin.Context) {
 	id := c.Param("id")
-	lending, err := h.adminUC.ApproveLoan(c, id)
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	lending, err := h.adminUC.ApproveLoan(c, userID.(string), id)
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -82,7 +93,25 @@ func (h *adminHandlers) ApproveLoan(c *gin.Context) {
 
 func (h *adminHandlers) RejectLoan(c *gin.Context) {
 	id := c.Param("id")
-	lending, err := h.adminUC.RejectLoan(c, id)
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.RejectLoanRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	lending, err := h.adminUC.RejectLoan(c, userID.(string), id, requestBody)
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -100,6 +129,12 @@ func (h *adminHandlers) RejectLoan(c *gin.Context) {
 
 func (h *adminHandlers) UpdateDebtorByID(c *gin.Context) {
 	id := c.Param("id")
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
 	var requestBody body.UpdateContractRequest
 	if err := c.ShouldBind(&requestBody); err != nil {
 		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
@@ -112,7 +147,7 @@ func (h *adminHandlers) UpdateDebtorByID(c *gin.Context) {
 		return
 	}
 
-	debtor, err := h.adminUC.UpdateDebtorByID(c, id, requestBody)
+	debtor, err := h.adminUC.UpdateDebtorByID(c, userID.(string), id, requestBody)
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -187,6 +222,83 @@ func (h *adminHandlers) CreateVoucher(c *gin.Context) {
 	response.SuccessResponse(c.Writer, voucher, http.StatusOK)
 }
 
+func (h *adminHandlers) GetVoucherCodes(c *gin.Context) {
+	id := c.Param("id")
+	codes, err := h.adminUC.GetVoucherCodes(c, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	h.writeReport(c, "voucher-codes", codes)
+}
+
+func (h *adminHandlers) GetVoucherStats(c *gin.Context) {
+	id := c.Param("id")
+
+	var granularity string
+	switch strings.TrimSpace(c.Query("granularity")) {
+	case "week":
+		granularity = "week"
+	case "month":
+		granularity = "month"
+	default:
+		granularity = "day"
+	}
+
+	stats, err := h.adminUC.GetVoucherStats(c, id, granularity)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, stats, http.StatusOK)
+}
+
+func (h *adminHandlers) CreateVoucherCodes(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.CreateVoucherCodesRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	codes, err := h.adminUC.CreateVoucherCodes(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, codes, http.StatusOK)
+}
+
 func (h *adminHandlers) DeleteVoucher(c *gin.Context) {
 	id := c.Param("id")
 	voucher, err := h.adminUC.DeleteVoucherByID(c, id)
@@ -275,6 +387,13 @@ func (h *adminHandlers) GetLoans(c *gin.Context) {
 	pagination := &utils.Pagination{}
 	name, status := h.ValidateQueryLoans(c, pagination)
 
+	if format := export.FormatFor(c.Query("format"), c.GetHeader("Accept")); format != "" {
+		h.writeExport(c, "loans", format, func(w io.Writer) error {
+			return h.adminUC.ExportLoans(c, name, status, pagination.Sort, format, w)
+		})
+		return
+	}
+
 	loans, err := h.adminUC.GetLoans(c, name, status, pagination)
 	if err != nil {
 		var e *httperror.Error
@@ -324,7 +443,7 @@ func (h *adminHandlers) ValidateQueryLoans(c *gin.Context, pagination *utils.Pag
 
 	switch status {
 	case "history":
-		statusFilter = append(statusFilter, 4, 5)
+		statusFilter = append(statusFilter, 4, 5, 6, 7)
 	default:
 		statusFilter = append(statusFilter, 1, 2, 3)
 	}
@@ -364,6 +483,13 @@ func (h *adminHandlers) GetPayments(c *gin.Context) {
 	pagination := &utils.Pagination{}
 	name := h.ValidateQueryPayments(c, pagination)
 
+	if format := export.FormatFor(c.Query("format"), c.GetHeader("Accept")); format != "" {
+		h.writeExport(c, "payments", format, func(w io.Writer) error {
+			return h.adminUC.ExportPayments(c, name, pagination.Sort, format, w)
+		})
+		return
+	}
+
 	payments, err := h.adminUC.GetPayments(c, name, pagination)
 	if err != nil {
 		var e *httperror.Error
@@ -425,9 +551,9 @@ func (h *adminHandlers) ValidateQueryPayments(c *gin.Context, pagination *utils.
 
 func (h *adminHandlers) GetVouchers(c *gin.Context) {
 	pagination := &utils.Pagination{}
-	name := h.ValidateQueryVouchers(c, pagination)
+	name, status := h.ValidateQueryVouchers(c, pagination)
 
-	vouchers, err := h.adminUC.GetVouchers(c, name, pagination)
+	vouchers, err := h.adminUC.GetVouchers(c, name, status, pagination)
 	if err != nil {
 		var e *httperror.Error
 		if !errors.As(err, &e) {
@@ -443,18 +569,35 @@ func (h *adminHandlers) GetVouchers(c *gin.Context) {
 	response.SuccessResponse(c.Writer, vouchers, http.StatusOK)
 }
 
-func (h *adminHandlers) ValidateQueryVouchers(c *gin.Context, pagination *utils.Pagination) string {
+func (h *adminHandlers) ValidateQueryVouchers(c *gin.Context, pagination *utils.Pagination) (string, []int) {
 	name := strings.TrimSpace(c.Query("name"))
+	status := strings.TrimSpace(c.Query("status"))
 	sort := strings.TrimSpace(c.Query("sort"))
 	sortBy := strings.TrimSpace(c.Query("sortBy"))
 	limit := strings.TrimSpace(c.Query("limit"))
 	page := strings.TrimSpace(c.Query("page"))
 
+	var statusFilter []int
 	var sortFilter string
 	var sortByFilter string
 	var limitFilter int
 	var pageFilter int
 
+	switch status {
+	case "scheduled":
+		statusFilter = append(statusFilter, 1)
+	case "active":
+		statusFilter = append(statusFilter, 2)
+	case "exhausted":
+		statusFilter = append(statusFilter, 3)
+	case "expired":
+		statusFilter = append(statusFilter, 4)
+	case "archived":
+		statusFilter = append(statusFilter, 5)
+	default:
+		statusFilter = append(statusFilter, 1, 2, 3, 4)
+	}
+
 	switch sort {
 	case "asc":
 		sortFilter = sort
@@ -487,13 +630,20 @@ func (h *adminHandlers) ValidateQueryVouchers(c *gin.Context, pagination *utils.
 	pagination.Page = pageFilter
 	pagination.Sort = fmt.Sprintf("%s %s", sortByFilter, sortFilter)
 
-	return name
+	return name, statusFilter
 }
 
 func (h *adminHandlers) GetDebtors(c *gin.Context) {
 	pagination := &utils.Pagination{}
 	name := h.ValidateQueryDebtors(c, pagination)
 
+	if format := export.FormatFor(c.Query("format"), c.GetHeader("Accept")); format != "" {
+		h.writeExport(c, "debtors", format, func(w io.Writer) error {
+			return h.adminUC.ExportDebtors(c, name, pagination.Sort, format, w)
+		})
+		return
+	}
+
 	debtors, err := h.adminUC.GetDebtors(c, name, pagination)
 	if err != nil {
 		var e *httperror.Error
@@ -556,3 +706,1090 @@ func (h *adminHandlers) ValidateQueryDebtors(c *gin.Context, pagination *utils.P
 
 	return name
 }
+
+func (h *adminHandlers) ProposeRestructuring(c *gin.Context) {
+	id := c.Param("id")
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.ProposeRestructuringRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	restructuring, err := h.adminUC.ProposeRestructuring(c, userID.(string), id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, restructuring, http.StatusOK)
+}
+
+func (h *adminHandlers) GetRestructurings(c *gin.Context) {
+	id := c.Param("id")
+	restructurings, err := h.adminUC.GetRestructurings(c, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, restructurings, http.StatusOK)
+}
+
+func (h *adminHandlers) WriteOffLoan(c *gin.Context) {
+	id := c.Param("id")
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.WriteOffRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	writeOff, err := h.adminUC.WriteOffLoan(c, userID.(string), id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, writeOff, http.StatusOK)
+}
+
+func (h *adminHandlers) ReversePayment(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.ReversePaymentRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	payment, err := h.adminUC.ReversePayment(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, payment, http.StatusOK)
+}
+
+func (h *adminHandlers) ImportStatement(c *gin.Context) {
+	var requestBody body.ImportStatementRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	statementImport, err := h.adminUC.ImportStatement(c, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, statementImport, http.StatusOK)
+}
+
+func (h *adminHandlers) GetReconciliationItems(c *gin.Context) {
+	pagination := &utils.Pagination{}
+	status := h.ValidateQueryReconciliationItems(c, pagination)
+
+	items, err := h.adminUC.GetReconciliationItems(c, status, pagination)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, items, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryReconciliationItems(c *gin.Context, pagination *utils.Pagination) []int {
+	status := strings.TrimSpace(c.Query("status"))
+	sort := strings.TrimSpace(c.Query("sort"))
+	sortBy := strings.TrimSpace(c.Query("sortBy"))
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var statusFilter []int
+	var sortFilter string
+	var sortByFilter string
+	var limitFilter int
+	var pageFilter int
+
+	switch status {
+	case "matched":
+		statusFilter = append(statusFilter, 3)
+	case "history":
+		statusFilter = append(statusFilter, 4, 5)
+	default:
+		statusFilter = append(statusFilter, 1, 2)
+	}
+
+	switch sort {
+	case "asc":
+		sortFilter = sort
+	default:
+		sortFilter = "desc"
+	}
+
+	switch sortBy {
+	case "amount", "transaction_date":
+		sortByFilter = sortBy
+	default:
+		sortByFilter = "created_at"
+	}
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pagination.Limit = limitFilter
+	pagination.Page = pageFilter
+	pagination.Sort = fmt.Sprintf("%s %s", sortByFilter, sortFilter)
+
+	return statusFilter
+}
+
+func (h *adminHandlers) ResolveReconciliationItem(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.ResolveReconciliationRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	item, err := h.adminUC.ResolveReconciliationItem(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, item, http.StatusOK)
+}
+
+func (h *adminHandlers) GetLoanProducts(c *gin.Context) {
+	pagination := &utils.Pagination{}
+	name := h.ValidateQueryLoanProducts(c, pagination)
+
+	loanProducts, err := h.adminUC.GetLoanProducts(c, name, pagination)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loanProducts, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryLoanProducts(c *gin.Context, pagination *utils.Pagination) string {
+	name := strings.TrimSpace(c.Query("name"))
+	sort := strings.TrimSpace(c.Query("sort"))
+	sortBy := strings.TrimSpace(c.Query("sortBy"))
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var sortFilter string
+	var sortByFilter string
+	var limitFilter int
+	var pageFilter int
+
+	switch sort {
+	case "asc":
+		sortFilter = sort
+	default:
+		sortFilter = "desc"
+	}
+
+	switch sortBy {
+	case "duration":
+		sortByFilter = sortBy
+	case "percentage":
+		sortByFilter = sortBy
+	case "active_date":
+		sortByFilter = sortBy
+	case "expire_date":
+		sortByFilter = sortBy
+	default:
+		sortByFilter = "created_at"
+	}
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pagination.Limit = limitFilter
+	pagination.Page = pageFilter
+	pagination.Sort = fmt.Sprintf("%s %s", sortByFilter, sortFilter)
+
+	return name
+}
+
+func (h *adminHandlers) GetLoanProductByID(c *gin.Context) {
+	id := c.Param("id")
+	loanProduct, err := h.adminUC.GetLoanProductByID(c, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loanProduct, http.StatusOK)
+}
+
+func (h *adminHandlers) CreateLoanProduct(c *gin.Context) {
+	var requestBody body.CreateLoanProductRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	loanProduct, err := h.adminUC.CreateLoanProduct(c, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loanProduct, http.StatusOK)
+}
+
+func (h *adminHandlers) UpdateLoanProduct(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.UpdateLoanProductRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	loanProduct, err := h.adminUC.UpdateLoanProductByID(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loanProduct, http.StatusOK)
+}
+
+func (h *adminHandlers) DeleteLoanProduct(c *gin.Context) {
+	id := c.Param("id")
+	loanProduct, err := h.adminUC.DeleteLoanProductByID(c, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loanProduct, http.StatusOK)
+}
+
+func (h *adminHandlers) GetDebtorScore(c *gin.Context) {
+	id := c.Param("id")
+	score, err := h.adminUC.GetDebtorScore(c, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, score, http.StatusOK)
+}
+
+func (h *adminHandlers) GetCreditLimitProposals(c *gin.Context) {
+	pagination := &utils.Pagination{}
+	status := h.ValidateQueryCreditLimitProposals(c, pagination)
+
+	proposals, err := h.adminUC.GetCreditLimitProposals(c, status, pagination)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, proposals, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryCreditLimitProposals(c *gin.Context, pagination *utils.Pagination) []int {
+	status := strings.TrimSpace(c.Query("status"))
+	sort := strings.TrimSpace(c.Query("sort"))
+	sortBy := strings.TrimSpace(c.Query("sortBy"))
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var statusFilter []int
+	var sortFilter string
+	var sortByFilter string
+	var limitFilter int
+	var pageFilter int
+
+	switch status {
+	case "history":
+		statusFilter = append(statusFilter, 2, 3)
+	default:
+		statusFilter = append(statusFilter, 1)
+	}
+
+	switch sort {
+	case "asc":
+		sortFilter = sort
+	default:
+		sortFilter = "desc"
+	}
+
+	switch sortBy {
+	case "score", "proposed_limit":
+		sortByFilter = sortBy
+	default:
+		sortByFilter = "created_at"
+	}
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pagination.Limit = limitFilter
+	pagination.Page = pageFilter
+	pagination.Sort = fmt.Sprintf("%s %s", sortByFilter, sortFilter)
+
+	return statusFilter
+}
+
+func (h *adminHandlers) RespondCreditLimitProposal(c *gin.Context) {
+	id := c.Param("id")
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.RespondCreditLimitProposalRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	proposal, err := h.adminUC.RespondCreditLimitProposal(c, userID.(string), id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, proposal, http.StatusOK)
+}
+
+func (h *adminHandlers) GetKycSubmissions(c *gin.Context) {
+	pagination := &utils.Pagination{}
+	status := h.ValidateQueryKycSubmissions(c, pagination)
+
+	submissions, err := h.adminUC.GetKycSubmissions(c, status, pagination)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, submissions, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryKycSubmissions(c *gin.Context, pagination *utils.Pagination) []int {
+	status := strings.TrimSpace(c.Query("status"))
+	sort := strings.TrimSpace(c.Query("sort"))
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var statusFilter []int
+	var sortFilter string
+	var limitFilter int
+	var pageFilter int
+
+	switch status {
+	case "history":
+		statusFilter = append(statusFilter, 3, 4)
+	default:
+		statusFilter = append(statusFilter, 2)
+	}
+
+	switch sort {
+	case "desc":
+		sortFilter = sort
+	default:
+		sortFilter = "asc"
+	}
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pagination.Limit = limitFilter
+	pagination.Page = pageFilter
+	pagination.Sort = fmt.Sprintf("created_at %s", sortFilter)
+
+	return statusFilter
+}
+
+func (h *adminHandlers) GetKycDocument(c *gin.Context) {
+	id := c.Param("id")
+	document := c.Param("document")
+	file, key, err := h.adminUC.GetKycDocument(c, id, document)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, -1, mime.TypeByExtension(filepath.Ext(key)), file, nil)
+}
+
+func (h *adminHandlers) ReviewKyc(c *gin.Context) {
+	id := c.Param("id")
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.ReviewKycRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	submission, err := h.adminUC.ReviewKyc(c, userID.(string), id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, submission, http.StatusOK)
+}
+
+func (h *adminHandlers) GetRejectionReasons(c *gin.Context) {
+	reasons, err := h.adminUC.GetRejectionReasons(c)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, reasons, http.StatusOK)
+}
+
+func (h *adminHandlers) CreateRejectionReason(c *gin.Context) {
+	var requestBody body.CreateRejectionReasonRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	reason, err := h.adminUC.CreateRejectionReason(c, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, reason, http.StatusOK)
+}
+
+func (h *adminHandlers) UpdateRejectionReason(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.UpdateRejectionReasonRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	reason, err := h.adminUC.UpdateRejectionReasonByID(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, reason, http.StatusOK)
+}
+
+func (h *adminHandlers) GetRejectionReport(c *gin.Context) {
+	period, from, to := h.ValidateQueryRejectionReport(c)
+
+	report, err := h.adminUC.GetRejectionReport(c, period, from, to)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryRejectionReport(c *gin.Context) (string, time.Time, time.Time) {
+	period := strings.TrimSpace(c.Query("period"))
+	from := strings.TrimSpace(c.Query("from"))
+	to := strings.TrimSpace(c.Query("to"))
+
+	var periodFilter string
+	switch period {
+	case "day", "week":
+		periodFilter = period
+	default:
+		periodFilter = "month"
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	toFilter, err := time.ParseInLocation("02-01-2006", to, loc)
+	if err != nil {
+		toFilter = today
+	}
+	// The end date is inclusive, so the range runs to the start of the next day.
+	toFilter = toFilter.AddDate(0, 0, 1)
+
+	fromFilter, err := time.ParseInLocation("02-01-2006", from, loc)
+	if err != nil || !fromFilter.Before(toFilter) {
+		fromFilter = toFilter.AddDate(-1, 0, 0)
+	}
+
+	return periodFilter, fromFilter, toFilter
+}
+
+func (h *adminHandlers) BulkLoanAction(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.BulkLoanRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	result, err := h.adminUC.BulkLoanAction(c, userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, result, http.StatusOK)
+}
+
+func (h *adminHandlers) BulkAdvanceContract(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.BulkContractRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	result, err := h.adminUC.BulkAdvanceContract(c, userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, result, http.StatusOK)
+}
+
+func (h *adminHandlers) BulkSetCreditLimit(c *gin.Context) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.BulkCreditLimitRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	result, err := h.adminUC.BulkSetCreditLimit(c, userID.(string), requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, result, http.StatusOK)
+}
+
+// writeExport streams an export as a file download. Pagination does not
+// apply, so every row matching the filters is written.
+func (h *adminHandlers) writeExport(c *gin.Context, name, format string, run func(w io.Writer) error) {
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName(name, format)))
+	c.Status(http.StatusOK)
+
+	if err := run(c.Writer); err != nil {
+		h.logger.Errorf("HandlerRegister, Error: %s", err)
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+		}
+	}
+}
+
+func (h *adminHandlers) GetAnalytics(c *gin.Context) {
+	granularity, from, to := h.ValidateQueryAnalytics(c)
+
+	analytics, err := h.adminUC.GetAnalytics(c, granularity, from, to)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, analytics, http.StatusOK)
+}
+
+func (h *adminHandlers) ValidateQueryAnalytics(c *gin.Context) (string, time.Time, time.Time) {
+	granularity := strings.TrimSpace(c.Query("granularity"))
+	from := strings.TrimSpace(c.Query("from"))
+	to := strings.TrimSpace(c.Query("to"))
+
+	var granularityFilter string
+	var maxRange time.Duration
+	switch granularity {
+	case "week":
+		granularityFilter = granularity
+		maxRange = 2 * 366 * 24 * time.Hour
+	case "month":
+		granularityFilter = granularity
+		maxRange = 5 * 366 * 24 * time.Hour
+	default:
+		granularityFilter = "day"
+		maxRange = 366 * 24 * time.Hour
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	toFilter, err := time.ParseInLocation("02-01-2006", to, loc)
+	if err != nil {
+		toFilter = today
+	}
+	// The end date is inclusive, so the range runs to the start of the next day.
+	toFilter = toFilter.AddDate(0, 0, 1)
+
+	fromFilter, err := time.ParseInLocation("02-01-2006", from, loc)
+	if err != nil || !fromFilter.Before(toFilter) {
+		fromFilter = toFilter.AddDate(0, 0, -30)
+	}
+
+	// Long ranges are cut so a fine granularity cannot produce an unbounded
+	// series.
+	if toFilter.Sub(fromFilter) > maxRange {
+		fromFilter = toFilter.Add(-maxRange)
+	}
+
+	return granularityFilter, fromFilter, toFilter
+}
+
+func (h *adminHandlers) GetAgingReport(c *gin.Context) {
+	asOf, _ := h.ValidateQueryRiskReport(c, 0)
+
+	report, err := h.adminUC.GetAgingReport(c, asOf)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	h.writeReport(c, "aging-report", report)
+}
+
+func (h *adminHandlers) GetParReport(c *gin.Context) {
+	asOf, _ := h.ValidateQueryRiskReport(c, 0)
+
+	report, err := h.adminUC.GetParReport(c, asOf)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	h.writeReport(c, "par-report", report)
+}
+
+func (h *adminHandlers) GetRollRateReport(c *gin.Context) {
+	asOf, months := h.ValidateQueryRiskReport(c, 6)
+
+	report, err := h.adminUC.GetRollRateReport(c, asOf, months)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	h.writeReport(c, "roll-rate-report", report)
+}
+
+func (h *adminHandlers) GetVintageReport(c *gin.Context) {
+	asOf, months := h.ValidateQueryRiskReport(c, 12)
+
+	report, err := h.adminUC.GetVintageReport(c, asOf, months)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	h.writeReport(c, "vintage-report", report)
+}
+
+// writeReport sends a report as JSON, or as a file when an export format is
+// asked for.
+func (h *adminHandlers) writeReport(c *gin.Context, name string, report export.Table) {
+	if format := export.FormatFor(c.Query("format"), c.GetHeader("Accept")); format != "" {
+		h.writeExport(c, name, format, func(w io.Writer) error {
+			return export.WriteTable(format, w, report)
+		})
+		return
+	}
+
+	response.SuccessResponse(c.Writer, report, http.StatusOK)
+}
+
+// ValidateQueryRiskReport reads the day a risk report is taken at, which
+// defaults to today and cannot be in the future, and how many months it looks
+// back over.
+func (h *adminHandlers) ValidateQueryRiskReport(c *gin.Context, defaultMonths int) (time.Time, int) {
+	asOf := strings.TrimSpace(c.Query("as_of"))
+	months := strings.TrimSpace(c.Query("months"))
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	asOfFilter, err := time.ParseInLocation("02-01-2006", asOf, loc)
+	if err != nil || asOfFilter.After(today) {
+		asOfFilter = today
+	}
+
+	monthsFilter, err := strconv.Atoi(months)
+	if err != nil || monthsFilter < 1 {
+		monthsFilter = defaultMonths
+	}
+	if monthsFilter > 24 {
+		monthsFilter = 24
+	}
+
+	return asOfFilter, monthsFilter
+}
@@ -10,19 +10,52 @@ func MapAdminRoutes(adminGroup *gin.RouterGroup, h admin.Handlers, mw *middlewar
 	adminGroup.Use(mw.AuthJWTMiddleware())
 	adminGroup.Use(mw.AdminMiddleware())
 	adminGroup.GET("/", h.GetSummary)
+	adminGroup.GET("/analytics", h.GetAnalytics)
 	adminGroup.GET("/debtors", h.GetDebtors)
+	adminGroup.POST("/debtors/bulk/contract", h.BulkAdvanceContract)
+	adminGroup.POST("/debtors/bulk/credit-limits", h.BulkSetCreditLimit)
 	adminGroup.GET("/debtors/:id", h.GetDebtorByID)
 	adminGroup.PUT("/debtors/:id", h.UpdateDebtorByID)
+	adminGroup.GET("/debtors/:id/score", h.GetDebtorScore)
+	adminGroup.GET("/kyc", h.GetKycSubmissions)
+	adminGroup.GET("/kyc/:id/:document", h.GetKycDocument)
+	adminGroup.PUT("/kyc/:id", h.ReviewKyc)
+	adminGroup.GET("/credit-limit-proposals", h.GetCreditLimitProposals)
+	adminGroup.PUT("/credit-limit-proposals/:id", h.RespondCreditLimitProposal)
 	adminGroup.GET("/loans", h.GetLoans)
+	adminGroup.POST("/loans/bulk", h.BulkLoanAction)
 	adminGroup.GET("/loans/:id", h.GetLoanByID)
 	adminGroup.PUT("/loans/:id", h.ApproveLoan)
 	adminGroup.DELETE("/loans/:id", h.RejectLoan)
+	adminGroup.POST("/loans/:id/write-off", h.WriteOffLoan)
+	adminGroup.GET("/loans/:id/restructurings", h.GetRestructurings)
+	adminGroup.POST("/loans/:id/restructurings", h.ProposeRestructuring)
 	adminGroup.GET("/loans/installments/:id", h.GetInstallmentByID)
 	adminGroup.PUT("/loans/installments/:id", h.UpdateInstallmentByID)
 	adminGroup.GET("/payments", h.GetPayments)
+	adminGroup.POST("/payments/:id/reverse", h.ReversePayment)
 	adminGroup.GET("/vouchers", h.GetVouchers)
 	adminGroup.POST("/vouchers", h.CreateVoucher)
 	adminGroup.GET("/vouchers/:id", h.GetVoucherByID)
 	adminGroup.PUT("/vouchers/:id", h.UpdateVoucher)
 	adminGroup.DELETE("/vouchers/:id", h.DeleteVoucher)
+	adminGroup.GET("/vouchers/:id/codes", h.GetVoucherCodes)
+	adminGroup.POST("/vouchers/:id/codes", h.CreateVoucherCodes)
+	adminGroup.GET("/vouchers/:id/stats", h.GetVoucherStats)
+	adminGroup.GET("/loan-products", h.GetLoanProducts)
+	adminGroup.POST("/loan-products", h.CreateLoanProduct)
+	adminGroup.GET("/loan-products/:id", h.GetLoanProductByID)
+	adminGroup.PUT("/loan-products/:id", h.UpdateLoanProduct)
+	adminGroup.DELETE("/loan-products/:id", h.DeleteLoanProduct)
+	adminGroup.GET("/rejection-reasons", h.GetRejectionReasons)
+	adminGroup.POST("/rejection-reasons", h.CreateRejectionReason)
+	adminGroup.PUT("/rejection-reasons/:id", h.UpdateRejectionReason)
+	adminGroup.GET("/reports/rejection-reasons", h.GetRejectionReport)
+	adminGroup.GET("/reports/risk/aging", h.GetAgingReport)
+	adminGroup.GET("/reports/risk/par", h.GetParReport)
+	adminGroup.GET("/reports/risk/roll-rates", h.GetRollRateReport)
+	adminGroup.GET("/reports/risk/vintage", h.GetVintageReport)
+	adminGroup.GET("/reconciliations", h.GetReconciliationItems)
+	adminGroup.POST("/reconciliations", h.ImportStatement)
+	adminGroup.PUT("/reconciliations/:id", h.ResolveReconciliationItem)
 }
@@ -8,26 +8,723 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	io "io"
+
 	models "final-project-backend/internal/models"
 
-	utils "final-project-backend/pkg/utils"
-)
+	scoring "final-project-backend/pkg/scoring"
+
+	time "time"
+
+	utils "final-project-backend/pkg/utils"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// ApproveLoan provides a mock function with given fields: ctx, userID, lendingID
+func (_m *UseCase) ApproveLoan(ctx context.Context, userID string, lendingID string) (*models.Lending, error) {
+	ret := _m.Called(ctx, userID, lendingID)
+
+	var r0 *models.Lending
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Lending); ok {
+		r0 = rf(ctx, userID, lendingID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Lending)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, lendingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkAdvanceContract provides a mock function with given fields: ctx, userID, _a2
+func (_m *UseCase) BulkAdvanceContract(ctx context.Context, userID string, _a2 body.BulkContractRequest) (*body.BulkResponse, error) {
+	ret := _m.Called(ctx, userID, _a2)
+
+	var r0 *body.BulkResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.BulkContractRequest) *body.BulkResponse); ok {
+		r0 = rf(ctx, userID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.BulkResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.BulkContractRequest) error); ok {
+		r1 = rf(ctx, userID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkLoanAction provides a mock function with given fields: ctx, userID, _a2
+func (_m *UseCase) BulkLoanAction(ctx context.Context, userID string, _a2 body.BulkLoanRequest) (*body.BulkResponse, error) {
+	ret := _m.Called(ctx, userID, _a2)
+
+	var r0 *body.BulkResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.BulkLoanRequest) *body.BulkResponse); ok {
+		r0 = rf(ctx, userID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.BulkResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.BulkLoanRequest) error); ok {
+		r1 = rf(ctx, userID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkSetCreditLimit provides a mock function with given fields: ctx, userID, _a2
+func (_m *UseCase) BulkSetCreditLimit(ctx context.Context, userID string, _a2 body.BulkCreditLimitRequest) (*body.BulkResponse, error) {
+	ret := _m.Called(ctx, userID, _a2)
+
+	var r0 *body.BulkResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.BulkCreditLimitRequest) *body.BulkResponse); ok {
+		r0 = rf(ctx, userID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.BulkResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.BulkCreditLimitRequest) error); ok {
+		r1 = rf(ctx, userID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoanProduct provides a mock function with given fields: ctx, _a1
+func (_m *UseCase) CreateLoanProduct(ctx context.Context, _a1 body.CreateLoanProductRequest) (*models.LoanProduct, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 *models.LoanProduct
+	if rf, ok := ret.Get(0).(func(context.Context, body.CreateLoanProductRequest) *models.LoanProduct); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, body.CreateLoanProductRequest) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRejectionReason provides a mock function with given fields: ctx, _a1
+func (_m *UseCase) CreateRejectionReason(ctx context.Context, _a1 body.CreateRejectionReasonRequest) (*models.RejectionReason, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 *models.RejectionReason
+	if rf, ok := ret.Get(0).(func(context.Context, body.CreateRejectionReasonRequest) *models.RejectionReason); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RejectionReason)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, body.CreateRejectionReasonRequest) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVoucher provides a mock function with given fields: ctx, _a1
+func (_m *UseCase) CreateVoucher(ctx context.Context, _a1 body.CreateVoucherRequest) (*models.Voucher, error) {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 *models.Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, body.CreateVoucherRequest) *models.Voucher); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Voucher)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, body.CreateVoucherRequest) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVoucherCodes provides a mock function with given fields: ctx, voucherID, _a2
+func (_m *UseCase) CreateVoucherCodes(ctx context.Context, voucherID string, _a2 body.CreateVoucherCodesRequest) (*body.VoucherCodesResponse, error) {
+	ret := _m.Called(ctx, voucherID, _a2)
+
+	var r0 *body.VoucherCodesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.CreateVoucherCodesRequest) *body.VoucherCodesResponse); ok {
+		r0 = rf(ctx, voucherID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.VoucherCodesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.CreateVoucherCodesRequest) error); ok {
+		r1 = rf(ctx, voucherID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLoanProductByID provides a mock function with given fields: ctx, loanProductID
+func (_m *UseCase) DeleteLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	ret := _m.Called(ctx, loanProductID)
+
+	var r0 *models.LoanProduct
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.LoanProduct); ok {
+		r0 = rf(ctx, loanProductID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanProductID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteVoucherByID provides a mock function with given fields: ctx, voucherID
+func (_m *UseCase) DeleteVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
+	ret := _m.Called(ctx, voucherID)
+
+	var r0 *models.Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Voucher); ok {
+		r0 = rf(ctx, voucherID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Voucher)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportDebtors provides a mock function with given fields: ctx, name, sort, format, w
+func (_m *UseCase) ExportDebtors(ctx context.Context, name string, sort string, format string, w io.Writer) error {
+	ret := _m.Called(ctx, name, sort, format, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, io.Writer) error); ok {
+		r0 = rf(ctx, name, sort, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportLoans provides a mock function with given fields: ctx, name, status, sort, format, w
+func (_m *UseCase) ExportLoans(ctx context.Context, name string, status []int, sort string, format string, w io.Writer) error {
+	ret := _m.Called(ctx, name, status, sort, format, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, string, string, io.Writer) error); ok {
+		r0 = rf(ctx, name, status, sort, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportPayments provides a mock function with given fields: ctx, name, sort, format, w
+func (_m *UseCase) ExportPayments(ctx context.Context, name string, sort string, format string, w io.Writer) error {
+	ret := _m.Called(ctx, name, sort, format, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, io.Writer) error); ok {
+		r0 = rf(ctx, name, sort, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAgingReport provides a mock function with given fields: ctx, asOf
+func (_m *UseCase) GetAgingReport(ctx context.Context, asOf time.Time) (*body.AgingReport, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 *body.AgingReport
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *body.AgingReport); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.AgingReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnalytics provides a mock function with given fields: ctx, granularity, from, to
+func (_m *UseCase) GetAnalytics(ctx context.Context, granularity string, from time.Time, to time.Time) (*body.AnalyticsResponse, error) {
+	ret := _m.Called(ctx, granularity, from, to)
+
+	var r0 *body.AnalyticsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *body.AnalyticsResponse); ok {
+		r0 = rf(ctx, granularity, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.AnalyticsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, granularity, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCreditLimitProposals provides a mock function with given fields: ctx, status, pagination
+func (_m *UseCase) GetCreditLimitProposals(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, status, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, []int, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, *utils.Pagination) error); ok {
+		r1 = rf(ctx, status, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDebtorByID provides a mock function with given fields: ctx, id
+func (_m *UseCase) GetDebtorByID(ctx context.Context, id string) (*models.Debtor, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Debtor
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Debtor); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Debtor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDebtorScore provides a mock function with given fields: ctx, debtorID
+func (_m *UseCase) GetDebtorScore(ctx context.Context, debtorID string) (*scoring.Result, error) {
+	ret := _m.Called(ctx, debtorID)
+
+	var r0 *scoring.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) *scoring.Result); ok {
+		r0 = rf(ctx, debtorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scoring.Result)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, debtorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDebtors provides a mock function with given fields: ctx, name, pagination
+func (_m *UseCase) GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, name, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, name, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
+		r1 = rf(ctx, name, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstallmentByID provides a mock function with given fields: ctx, installmentID
+func (_m *UseCase) GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error) {
+	ret := _m.Called(ctx, installmentID)
+
+	var r0 *models.Installment
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Installment); ok {
+		r0 = rf(ctx, installmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Installment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, installmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKycDocument provides a mock function with given fields: ctx, submissionID, document
+func (_m *UseCase) GetKycDocument(ctx context.Context, submissionID string, document string) (io.ReadCloser, string, error) {
+	ret := _m.Called(ctx, submissionID, document)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) io.ReadCloser); ok {
+		r0 = rf(ctx, submissionID, document)
+	} else {
+		r0 = ret.Get(0).(io.ReadCloser)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = rf(ctx, submissionID, document)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, submissionID, document)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetKycSubmissions provides a mock function with given fields: ctx, status, pagination
+func (_m *UseCase) GetKycSubmissions(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, status, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, []int, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, *utils.Pagination) error); ok {
+		r1 = rf(ctx, status, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanByID provides a mock function with given fields: ctx, lendingID
+func (_m *UseCase) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
+	ret := _m.Called(ctx, lendingID)
+
+	var r0 *models.Lending
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Lending); ok {
+		r0 = rf(ctx, lendingID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Lending)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, lendingID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanProductByID provides a mock function with given fields: ctx, loanProductID
+func (_m *UseCase) GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	ret := _m.Called(ctx, loanProductID)
+
+	var r0 *models.LoanProduct
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.LoanProduct); ok {
+		r0 = rf(ctx, loanProductID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, loanProductID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoanProducts provides a mock function with given fields: ctx, name, pagination
+func (_m *UseCase) GetLoanProducts(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, name, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, name, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
+		r1 = rf(ctx, name, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoans provides a mock function with given fields: ctx, name, status, pagination
+func (_m *UseCase) GetLoans(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, name, status, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, name, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, *utils.Pagination) error); ok {
+		r1 = rf(ctx, name, status, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetParReport provides a mock function with given fields: ctx, asOf
+func (_m *UseCase) GetParReport(ctx context.Context, asOf time.Time) (*body.ParReport, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 *body.ParReport
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *body.ParReport); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.ParReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayments provides a mock function with given fields: ctx, name, pagination
+func (_m *UseCase) GetPayments(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, name, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, name, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
+		r1 = rf(ctx, name, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReconciliationItems provides a mock function with given fields: ctx, status, pagination
+func (_m *UseCase) GetReconciliationItems(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	ret := _m.Called(ctx, status, pagination)
+
+	var r0 *utils.Pagination
+	if rf, ok := ret.Get(0).(func(context.Context, []int, *utils.Pagination) *utils.Pagination); ok {
+		r0 = rf(ctx, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Pagination)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, *utils.Pagination) error); ok {
+		r1 = rf(ctx, status, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRejectionReasons provides a mock function with given fields: ctx
+func (_m *UseCase) GetRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.RejectionReason
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.RejectionReason); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RejectionReason)
+		}
+	}
 
-// UseCase is an autogenerated mock type for the UseCase type
-type UseCase struct {
-	mock.Mock
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRejectionReport provides a mock function with given fields: ctx, period, from, to
+func (_m *UseCase) GetRejectionReport(ctx context.Context, period string, from time.Time, to time.Time) (*body.RejectionReportResponse, error) {
+	ret := _m.Called(ctx, period, from, to)
+
+	var r0 *body.RejectionReportResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *body.RejectionReportResponse); ok {
+		r0 = rf(ctx, period, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.RejectionReportResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, period, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// ApproveLoan provides a mock function with given fields: ctx, lendingID
-func (_m *UseCase) ApproveLoan(ctx context.Context, lendingID string) (*models.Lending, error) {
+// GetRestructurings provides a mock function with given fields: ctx, lendingID
+func (_m *UseCase) GetRestructurings(ctx context.Context, lendingID string) ([]*models.Restructuring, error) {
 	ret := _m.Called(ctx, lendingID)
 
-	var r0 *models.Lending
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Lending); ok {
+	var r0 []*models.Restructuring
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Restructuring); ok {
 		r0 = rf(ctx, lendingID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Lending)
+			r0 = ret.Get(0).([]*models.Restructuring)
 		}
 	}
 
@@ -41,22 +738,22 @@ func (_m *UseCase) ApproveLoan(ctx context.Context, lendingID string) (*models.L
 	return r0, r1
 }
 
-// CreateVoucher provides a mock function with given fields: ctx, _a1
-func (_m *UseCase) CreateVoucher(ctx context.Context, _a1 body.CreateVoucherRequest) (*models.Voucher, error) {
-	ret := _m.Called(ctx, _a1)
+// GetRollRateReport provides a mock function with given fields: ctx, asOf, months
+func (_m *UseCase) GetRollRateReport(ctx context.Context, asOf time.Time, months int) (*body.RollRateReport, error) {
+	ret := _m.Called(ctx, asOf, months)
 
-	var r0 *models.Voucher
-	if rf, ok := ret.Get(0).(func(context.Context, body.CreateVoucherRequest) *models.Voucher); ok {
-		r0 = rf(ctx, _a1)
+	var r0 *body.RollRateReport
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) *body.RollRateReport); ok {
+		r0 = rf(ctx, asOf, months)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Voucher)
+			r0 = ret.Get(0).(*body.RollRateReport)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, body.CreateVoucherRequest) error); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, asOf, months)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -64,22 +761,22 @@ func (_m *UseCase) CreateVoucher(ctx context.Context, _a1 body.CreateVoucherRequ
 	return r0, r1
 }
 
-// DeleteVoucherByID provides a mock function with given fields: ctx, voucherID
-func (_m *UseCase) DeleteVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
-	ret := _m.Called(ctx, voucherID)
+// GetSummary provides a mock function with given fields: ctx
+func (_m *UseCase) GetSummary(ctx context.Context) (*body.SummaryResponse, error) {
+	ret := _m.Called(ctx)
 
-	var r0 *models.Voucher
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Voucher); ok {
-		r0 = rf(ctx, voucherID)
+	var r0 *body.SummaryResponse
+	if rf, ok := ret.Get(0).(func(context.Context) *body.SummaryResponse); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Voucher)
+			r0 = ret.Get(0).(*body.SummaryResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, voucherID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -87,22 +784,22 @@ func (_m *UseCase) DeleteVoucherByID(ctx context.Context, voucherID string) (*mo
 	return r0, r1
 }
 
-// GetDebtorByID provides a mock function with given fields: ctx, id
-func (_m *UseCase) GetDebtorByID(ctx context.Context, id string) (*models.Debtor, error) {
-	ret := _m.Called(ctx, id)
+// GetVintageReport provides a mock function with given fields: ctx, asOf, months
+func (_m *UseCase) GetVintageReport(ctx context.Context, asOf time.Time, months int) (*body.VintageReport, error) {
+	ret := _m.Called(ctx, asOf, months)
 
-	var r0 *models.Debtor
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Debtor); ok {
-		r0 = rf(ctx, id)
+	var r0 *body.VintageReport
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) *body.VintageReport); ok {
+		r0 = rf(ctx, asOf, months)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Debtor)
+			r0 = ret.Get(0).(*body.VintageReport)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, asOf, months)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,22 +807,22 @@ func (_m *UseCase) GetDebtorByID(ctx context.Context, id string) (*models.Debtor
 	return r0, r1
 }
 
-// GetDebtors provides a mock function with given fields: ctx, name, pagination
-func (_m *UseCase) GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	ret := _m.Called(ctx, name, pagination)
+// GetVoucherByID provides a mock function with given fields: ctx, voucherID
+func (_m *UseCase) GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
+	ret := _m.Called(ctx, voucherID)
 
-	var r0 *utils.Pagination
-	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
-		r0 = rf(ctx, name, pagination)
+	var r0 *models.Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Voucher); ok {
+		r0 = rf(ctx, voucherID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*utils.Pagination)
+			r0 = ret.Get(0).(*models.Voucher)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
-		r1 = rf(ctx, name, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, voucherID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -133,22 +830,22 @@ func (_m *UseCase) GetDebtors(ctx context.Context, name string, pagination *util
 	return r0, r1
 }
 
-// GetInstallmentByID provides a mock function with given fields: ctx, installmentID
-func (_m *UseCase) GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error) {
-	ret := _m.Called(ctx, installmentID)
+// GetVoucherCodes provides a mock function with given fields: ctx, voucherID
+func (_m *UseCase) GetVoucherCodes(ctx context.Context, voucherID string) (*body.VoucherCodesResponse, error) {
+	ret := _m.Called(ctx, voucherID)
 
-	var r0 *models.Installment
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Installment); ok {
-		r0 = rf(ctx, installmentID)
+	var r0 *body.VoucherCodesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *body.VoucherCodesResponse); ok {
+		r0 = rf(ctx, voucherID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Installment)
+			r0 = ret.Get(0).(*body.VoucherCodesResponse)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, installmentID)
+		r1 = rf(ctx, voucherID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -156,22 +853,22 @@ func (_m *UseCase) GetInstallmentByID(ctx context.Context, installmentID string)
 	return r0, r1
 }
 
-// GetLoanByID provides a mock function with given fields: ctx, lendingID
-func (_m *UseCase) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
-	ret := _m.Called(ctx, lendingID)
+// GetVoucherStats provides a mock function with given fields: ctx, voucherID, granularity
+func (_m *UseCase) GetVoucherStats(ctx context.Context, voucherID string, granularity string) (*body.VoucherStatsResponse, error) {
+	ret := _m.Called(ctx, voucherID, granularity)
 
-	var r0 *models.Lending
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Lending); ok {
-		r0 = rf(ctx, lendingID)
+	var r0 *body.VoucherStatsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *body.VoucherStatsResponse); ok {
+		r0 = rf(ctx, voucherID, granularity)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Lending)
+			r0 = ret.Get(0).(*body.VoucherStatsResponse)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, lendingID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, voucherID, granularity)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -179,8 +876,8 @@ func (_m *UseCase) GetLoanByID(ctx context.Context, lendingID string) (*models.L
 	return r0, r1
 }
 
-// GetLoans provides a mock function with given fields: ctx, name, status, pagination
-func (_m *UseCase) GetLoans(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+// GetVouchers provides a mock function with given fields: ctx, name, status, pagination
+func (_m *UseCase) GetVouchers(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	ret := _m.Called(ctx, name, status, pagination)
 
 	var r0 *utils.Pagination
@@ -202,22 +899,22 @@ func (_m *UseCase) GetLoans(ctx context.Context, name string, status []int, pagi
 	return r0, r1
 }
 
-// GetPayments provides a mock function with given fields: ctx, name, pagination
-func (_m *UseCase) GetPayments(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	ret := _m.Called(ctx, name, pagination)
+// ImportStatement provides a mock function with given fields: ctx, _a1
+func (_m *UseCase) ImportStatement(ctx context.Context, _a1 body.ImportStatementRequest) (*models.StatementImport, error) {
+	ret := _m.Called(ctx, _a1)
 
-	var r0 *utils.Pagination
-	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
-		r0 = rf(ctx, name, pagination)
+	var r0 *models.StatementImport
+	if rf, ok := ret.Get(0).(func(context.Context, body.ImportStatementRequest) *models.StatementImport); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*utils.Pagination)
+			r0 = ret.Get(0).(*models.StatementImport)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
-		r1 = rf(ctx, name, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, body.ImportStatementRequest) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -225,17 +922,15 @@ func (_m *UseCase) GetPayments(ctx context.Context, name string, pagination *uti
 	return r0, r1
 }
 
-// GetSummary provides a mock function with given fields: ctx
-func (_m *UseCase) GetSummary(ctx context.Context) (*body.SummaryResponse, error) {
+// ProposeCreditLimits provides a mock function with given fields: ctx
+func (_m *UseCase) ProposeCreditLimits(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
 
-	var r0 *body.SummaryResponse
-	if rf, ok := ret.Get(0).(func(context.Context) *body.SummaryResponse); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*body.SummaryResponse)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
@@ -248,22 +943,22 @@ func (_m *UseCase) GetSummary(ctx context.Context) (*body.SummaryResponse, error
 	return r0, r1
 }
 
-// GetVoucherByID provides a mock function with given fields: ctx, voucherID
-func (_m *UseCase) GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
-	ret := _m.Called(ctx, voucherID)
+// ProposeRestructuring provides a mock function with given fields: ctx, userID, lendingID, _a3
+func (_m *UseCase) ProposeRestructuring(ctx context.Context, userID string, lendingID string, _a3 body.ProposeRestructuringRequest) (*models.Restructuring, error) {
+	ret := _m.Called(ctx, userID, lendingID, _a3)
 
-	var r0 *models.Voucher
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Voucher); ok {
-		r0 = rf(ctx, voucherID)
+	var r0 *models.Restructuring
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.ProposeRestructuringRequest) *models.Restructuring); ok {
+		r0 = rf(ctx, userID, lendingID, _a3)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Voucher)
+			r0 = ret.Get(0).(*models.Restructuring)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, voucherID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.ProposeRestructuringRequest) error); ok {
+		r1 = rf(ctx, userID, lendingID, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -271,22 +966,22 @@ func (_m *UseCase) GetVoucherByID(ctx context.Context, voucherID string) (*model
 	return r0, r1
 }
 
-// GetVouchers provides a mock function with given fields: ctx, name, pagination
-func (_m *UseCase) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	ret := _m.Called(ctx, name, pagination)
+// RejectLoan provides a mock function with given fields: ctx, userID, lendingID, _a3
+func (_m *UseCase) RejectLoan(ctx context.Context, userID string, lendingID string, _a3 body.RejectLoanRequest) (*models.Lending, error) {
+	ret := _m.Called(ctx, userID, lendingID, _a3)
 
-	var r0 *utils.Pagination
-	if rf, ok := ret.Get(0).(func(context.Context, string, *utils.Pagination) *utils.Pagination); ok {
-		r0 = rf(ctx, name, pagination)
+	var r0 *models.Lending
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.RejectLoanRequest) *models.Lending); ok {
+		r0 = rf(ctx, userID, lendingID, _a3)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*utils.Pagination)
+			r0 = ret.Get(0).(*models.Lending)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, *utils.Pagination) error); ok {
-		r1 = rf(ctx, name, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.RejectLoanRequest) error); ok {
+		r1 = rf(ctx, userID, lendingID, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -294,22 +989,91 @@ func (_m *UseCase) GetVouchers(ctx context.Context, name string, pagination *uti
 	return r0, r1
 }
 
-// RejectLoan provides a mock function with given fields: ctx, lendingID
-func (_m *UseCase) RejectLoan(ctx context.Context, lendingID string) (*models.Lending, error) {
-	ret := _m.Called(ctx, lendingID)
+// ResolveReconciliationItem provides a mock function with given fields: ctx, itemID, _a2
+func (_m *UseCase) ResolveReconciliationItem(ctx context.Context, itemID string, _a2 body.ResolveReconciliationRequest) (*models.ReconciliationItem, error) {
+	ret := _m.Called(ctx, itemID, _a2)
 
-	var r0 *models.Lending
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Lending); ok {
-		r0 = rf(ctx, lendingID)
+	var r0 *models.ReconciliationItem
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.ResolveReconciliationRequest) *models.ReconciliationItem); ok {
+		r0 = rf(ctx, itemID, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Lending)
+			r0 = ret.Get(0).(*models.ReconciliationItem)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, lendingID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.ResolveReconciliationRequest) error); ok {
+		r1 = rf(ctx, itemID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondCreditLimitProposal provides a mock function with given fields: ctx, userID, proposalID, _a3
+func (_m *UseCase) RespondCreditLimitProposal(ctx context.Context, userID string, proposalID string, _a3 body.RespondCreditLimitProposalRequest) (*models.CreditLimitProposal, error) {
+	ret := _m.Called(ctx, userID, proposalID, _a3)
+
+	var r0 *models.CreditLimitProposal
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.RespondCreditLimitProposalRequest) *models.CreditLimitProposal); ok {
+		r0 = rf(ctx, userID, proposalID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CreditLimitProposal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.RespondCreditLimitProposalRequest) error); ok {
+		r1 = rf(ctx, userID, proposalID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReversePayment provides a mock function with given fields: ctx, paymentID, _a2
+func (_m *UseCase) ReversePayment(ctx context.Context, paymentID string, _a2 body.ReversePaymentRequest) (*models.Payment, error) {
+	ret := _m.Called(ctx, paymentID, _a2)
+
+	var r0 *models.Payment
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.ReversePaymentRequest) *models.Payment); ok {
+		r0 = rf(ctx, paymentID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.ReversePaymentRequest) error); ok {
+		r1 = rf(ctx, paymentID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewKyc provides a mock function with given fields: ctx, userID, submissionID, _a3
+func (_m *UseCase) ReviewKyc(ctx context.Context, userID string, submissionID string, _a3 body.ReviewKycRequest) (*models.KycSubmission, error) {
+	ret := _m.Called(ctx, userID, submissionID, _a3)
+
+	var r0 *models.KycSubmission
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.ReviewKycRequest) *models.KycSubmission); ok {
+		r0 = rf(ctx, userID, submissionID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.ReviewKycRequest) error); ok {
+		r1 = rf(ctx, userID, submissionID, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -317,13 +1081,13 @@ func (_m *UseCase) RejectLoan(ctx context.Context, lendingID string) (*models.Le
 	return r0, r1
 }
 
-// UpdateDebtorByID provides a mock function with given fields: ctx, debtorID, _a2
-func (_m *UseCase) UpdateDebtorByID(ctx context.Context, debtorID string, _a2 body.UpdateContractRequest) (*models.Debtor, error) {
-	ret := _m.Called(ctx, debtorID, _a2)
+// UpdateDebtorByID provides a mock function with given fields: ctx, userID, debtorID, _a3
+func (_m *UseCase) UpdateDebtorByID(ctx context.Context, userID string, debtorID string, _a3 body.UpdateContractRequest) (*models.Debtor, error) {
+	ret := _m.Called(ctx, userID, debtorID, _a3)
 
 	var r0 *models.Debtor
-	if rf, ok := ret.Get(0).(func(context.Context, string, body.UpdateContractRequest) *models.Debtor); ok {
-		r0 = rf(ctx, debtorID, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.UpdateContractRequest) *models.Debtor); ok {
+		r0 = rf(ctx, userID, debtorID, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Debtor)
@@ -331,8 +1095,8 @@ func (_m *UseCase) UpdateDebtorByID(ctx context.Context, debtorID string, _a2 bo
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, body.UpdateContractRequest) error); ok {
-		r1 = rf(ctx, debtorID, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.UpdateContractRequest) error); ok {
+		r1 = rf(ctx, userID, debtorID, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -363,6 +1127,52 @@ func (_m *UseCase) UpdateInstallmentByID(ctx context.Context, installmentID stri
 	return r0, r1
 }
 
+// UpdateLoanProductByID provides a mock function with given fields: ctx, loanProductID, _a2
+func (_m *UseCase) UpdateLoanProductByID(ctx context.Context, loanProductID string, _a2 body.UpdateLoanProductRequest) (*models.LoanProduct, error) {
+	ret := _m.Called(ctx, loanProductID, _a2)
+
+	var r0 *models.LoanProduct
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.UpdateLoanProductRequest) *models.LoanProduct); ok {
+		r0 = rf(ctx, loanProductID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoanProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.UpdateLoanProductRequest) error); ok {
+		r1 = rf(ctx, loanProductID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRejectionReasonByID provides a mock function with given fields: ctx, reasonID, _a2
+func (_m *UseCase) UpdateRejectionReasonByID(ctx context.Context, reasonID string, _a2 body.UpdateRejectionReasonRequest) (*models.RejectionReason, error) {
+	ret := _m.Called(ctx, reasonID, _a2)
+
+	var r0 *models.RejectionReason
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.UpdateRejectionReasonRequest) *models.RejectionReason); ok {
+		r0 = rf(ctx, reasonID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RejectionReason)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.UpdateRejectionReasonRequest) error); ok {
+		r1 = rf(ctx, reasonID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateVoucherByID provides a mock function with given fields: ctx, voucherID, _a2
 func (_m *UseCase) UpdateVoucherByID(ctx context.Context, voucherID string, _a2 body.UpdateVoucherRequest) (*models.Voucher, error) {
 	ret := _m.Called(ctx, voucherID, _a2)
@@ -386,6 +1196,50 @@ func (_m *UseCase) UpdateVoucherByID(ctx context.Context, voucherID string, _a2
 	return r0, r1
 }
 
+// UpdateVoucherLifecycles provides a mock function with given fields: ctx
+func (_m *UseCase) UpdateVoucherLifecycles(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WriteOffLoan provides a mock function with given fields: ctx, userID, lendingID, _a3
+func (_m *UseCase) WriteOffLoan(ctx context.Context, userID string, lendingID string, _a3 body.WriteOffRequest) (*models.WriteOff, error) {
+	ret := _m.Called(ctx, userID, lendingID, _a3)
+
+	var r0 *models.WriteOff
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.WriteOffRequest) *models.WriteOff); ok {
+		r0 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WriteOff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.WriteOffRequest) error); ok {
+		r1 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUseCase interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,13 +3,17 @@ package admin
 import (
 	"context"
 	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
 	"final-project-backend/pkg/utils"
+	"time"
 )
 
 type Repository interface {
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
+	PaymentRepository() payment.Repository
 	GetLoans(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetPayments(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
-	GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetVouchers(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error)
 	GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetDebtorByID(ctx context.Context, debtorID string) (*models.Debtor, error)
@@ -25,11 +29,71 @@ type Repository interface {
 	CreateVoucher(ctx context.Context, voucher *models.Voucher) (*models.Voucher, error)
 	GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error)
 	UpdateVoucherByID(ctx context.Context, voucher *models.Voucher) error
-	DeleteVoucher(ctx context.Context, voucher *models.Voucher) error
+	UpdateVoucherStatus(ctx context.Context, voucher *models.Voucher) error
+	GetUnarchivedVouchers(ctx context.Context) ([]*models.Voucher, error)
+	GetVoucherRedemptionStats(ctx context.Context, voucherIDs []string) ([]*models.VoucherRedemptionStats, error)
+	GetLoanProducts(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error)
+	CreateLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) (*models.LoanProduct, error)
+	UpdateLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) (*models.LoanProduct, error)
+	DeleteLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) error
+	GetCreditHealthTypesByID(ctx context.Context, creditHealthIDs []int) ([]models.CreditHealthType, error)
 	GetUserTotal(ctx context.Context) (int64, error)
 	GetLendingTotal(ctx context.Context) (int64, error)
 	GetLendingAmount(ctx context.Context) (float64, error)
 	GetReturnAmount(ctx context.Context) (float64, error)
 	GetLendingAction(ctx context.Context) ([]*models.Lending, error)
 	GetUserAction(ctx context.Context) ([]*models.Debtor, error)
+	GetWriteOffAmount(ctx context.Context) (float64, error)
+	CreateRestructuring(ctx context.Context, restructuring *models.Restructuring) (*models.Restructuring, error)
+	GetRestructuringsByLendingID(ctx context.Context, lendingID string) ([]*models.Restructuring, error)
+	CreateWriteOff(ctx context.Context, writeOff *models.WriteOff) (*models.WriteOff, error)
+	CreateStatementImport(ctx context.Context, statementImport *models.StatementImport) (*models.StatementImport, error)
+	UpdateStatementImport(ctx context.Context, statementImport *models.StatementImport) (*models.StatementImport, error)
+	GetStatementImportByID(ctx context.Context, statementImportID string) (*models.StatementImport, error)
+	CheckReconciliationLineExist(ctx context.Context, lineHash string) (bool, error)
+	CreateReconciliationItem(ctx context.Context, item *models.ReconciliationItem) (*models.ReconciliationItem, error)
+	UpdateReconciliationItem(ctx context.Context, item *models.ReconciliationItem) (*models.ReconciliationItem, error)
+	GetReconciliationItemByID(ctx context.Context, itemID string) (*models.ReconciliationItem, error)
+	GetReconciliationItemForUpdate(ctx context.Context, itemID string) (*models.ReconciliationItem, error)
+	GetReconciliationItems(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetOpenPaymentIntentsByReference(ctx context.Context, references []string) ([]*models.PaymentIntent, error)
+	GetOpenInstallmentsByID(ctx context.Context, installmentIDs []string) ([]*models.Installment, error)
+	GetPaymentIntentByID(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error)
+	CreatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error)
+	GetScorableDebtors(ctx context.Context) ([]*models.Debtor, error)
+	GetSettledPaymentsByDebtorID(ctx context.Context, debtorID string) ([]*models.Payment, error)
+	CheckPendingCreditLimitProposal(ctx context.Context, debtorID string) (bool, error)
+	CreateCreditLimitProposal(ctx context.Context, proposal *models.CreditLimitProposal) (*models.CreditLimitProposal, error)
+	GetCreditLimitProposals(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetCreditLimitProposalForUpdate(ctx context.Context, proposalID string) (*models.CreditLimitProposal, error)
+	UpdateCreditLimitProposal(ctx context.Context, proposal *models.CreditLimitProposal) (*models.CreditLimitProposal, error)
+	CreateCreditHealthHistory(ctx context.Context, history *models.CreditHealthHistory) (*models.CreditHealthHistory, error)
+	GetKycSubmissions(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetKycSubmissionByID(ctx context.Context, submissionID string) (*models.KycSubmission, error)
+	GetKycSubmissionForUpdate(ctx context.Context, submissionID string) (*models.KycSubmission, error)
+	UpdateKycSubmission(ctx context.Context, submission *models.KycSubmission) (*models.KycSubmission, error)
+	GetLendingForUpdate(ctx context.Context, lendingID string) (*models.Lending, error)
+	GetDebtorForUpdate(ctx context.Context, debtorID string) (*models.Debtor, error)
+	CreateAdminAction(ctx context.Context, action *models.AdminAction) (*models.AdminAction, error)
+	GetLendingDecisionAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.LendingDecisionAggregate, error)
+	GetPaymentAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.PaymentAggregate, error)
+	GetDebtorAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.DebtorAggregate, error)
+	GetLoanPositions(ctx context.Context, at time.Time) ([]*models.LoanPosition, error)
+	GetLoanCohorts(ctx context.Context, from, to time.Time) ([]*models.LoanCohort, error)
+	GetLoanProductsByID(ctx context.Context, loanProductIDs []string) ([]models.LoanProduct, error)
+	GetVoucherCodes(ctx context.Context, voucherID string) ([]*models.VoucherCode, error)
+	GetExistingVoucherCodes(ctx context.Context, codes []string) ([]string, error)
+	CreateVoucherCodes(ctx context.Context, codes []*models.VoucherCode) ([]*models.VoucherCode, error)
+	GetVoucherRedemptionAggregates(ctx context.Context, voucherID, granularity string) ([]*models.VoucherRedemptionAggregate, error)
+	GetVoucherRepaymentAggregates(ctx context.Context, voucherID string, from, to time.Time) ([]*models.VoucherRepaymentAggregate, error)
+	ExportLoans(ctx context.Context, name string, status []int, sort string, fn func(row *models.LoanExport) error) error
+	ExportPayments(ctx context.Context, name string, sort string, fn func(row *models.PaymentExport) error) error
+	ExportDebtors(ctx context.Context, name string, sort string, fn func(row *models.DebtorExport) error) error
+	GetRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error)
+	GetRejectionReasonByID(ctx context.Context, reasonID int) (*models.RejectionReason, error)
+	CheckRejectionReasonCodeExist(ctx context.Context, code string) (bool, error)
+	CreateRejectionReason(ctx context.Context, reason *models.RejectionReason) (*models.RejectionReason, error)
+	UpdateRejectionReason(ctx context.Context, reason *models.RejectionReason) (*models.RejectionReason, error)
+	GetRejectionReasonCounts(ctx context.Context, period string, from, to time.Time) ([]*models.RejectionReasonCount, error)
 }
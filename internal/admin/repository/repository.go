@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"final-project-backend/internal/admin"
 	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
+	paymentRepository "final-project-backend/internal/payment/repository"
 	"final-project-backend/pkg/utils"
 	"fmt"
 	"gorm.io/gorm"
@@ -20,6 +23,18 @@ func NewAdminRepository(db *gorm.DB) admin.Repository {
 	return &adminRepo{db: db}
 }
 
+func (r *adminRepo) Transaction(ctx context.Context, fn func(repo admin.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&adminRepo{db: tx})
+	})
+}
+
+// PaymentRepository returns the payment repository on the same connection,
+// so inside Transaction payments are settled in the same transaction.
+func (r *adminRepo) PaymentRepository() payment.Repository {
+	return paymentRepository.NewPaymentRepository(r.db)
+}
+
 func (r *adminRepo) GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
 	var debtors []*models.Debtor
 
@@ -41,7 +56,7 @@ func (r *adminRepo) GetDebtors(ctx context.Context, name string, pagination *uti
 		Joins("inner join contract_tracking_types on contract_tracking_types.contract_tracking_id = debtors.contract_tracking_id").
 		Where("users.name ILIKE ? OR users.email ILIKE ?", fmt.Sprintf("%%%s%%", name), fmt.Sprintf("%%%s%%", name)).
 		Preload(clause.Associations).
-		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order("debtors." + pagination.GetSort()).
 		Find(&debtors).Error; err != nil {
 		return pagination, err
 	}
@@ -106,7 +121,8 @@ func (r *adminRepo) GetCreditHealthByID(ctx context.Context, healthID int) (*mod
 
 func (r *adminRepo) GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
 	voucher := &models.Voucher{}
-	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucherID).First(voucher).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("VoucherStatus").Preload("VoucherDiscountType").Preload("CreditHealths").Preload("LoanProducts").
+		Where("voucher_id = ?", voucherID).First(voucher).Error; err != nil {
 		return voucher, err
 	}
 
@@ -114,15 +130,21 @@ func (r *adminRepo) GetVoucherByID(ctx context.Context, voucherID string) (*mode
 }
 
 func (r *adminRepo) UpdateVoucherByID(ctx context.Context, voucher *models.Voucher) error {
-	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucher.VoucherID).Save(voucher).Error; err != nil {
-		return err
-	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("VoucherStatus", "VoucherDiscountType", "CreditHealths", "LoanProducts").Where("voucher_id = ?", voucher.VoucherID).Save(voucher).Error; err != nil {
+			return err
+		}
 
-	return nil
+		if err := tx.Model(voucher).Omit("CreditHealths.*").Association("CreditHealths").Replace(voucher.CreditHealths); err != nil {
+			return err
+		}
+
+		return tx.Model(voucher).Omit("LoanProducts.*").Association("LoanProducts").Replace(voucher.LoanProducts)
+	})
 }
 
 func (r *adminRepo) UpdateDebtorByID(ctx context.Context, debtor *models.Debtor) (*models.Debtor, error) {
-	if err := r.db.Omit("ContractTracking", "CreditHealth", "User").WithContext(ctx).Where("debtor_id = ?", debtor.DebtorID).Save(debtor).Error; err != nil {
+	if err := r.db.Omit("ContractTracking", "CreditHealth", "KycStatus", "User").WithContext(ctx).Where("debtor_id = ?", debtor.DebtorID).Save(debtor).Error; err != nil {
 		return debtor, err
 	}
 
@@ -135,7 +157,7 @@ func (r *adminRepo) UpdateDebtorByID(ctx context.Context, debtor *models.Debtor)
 }
 
 func (r *adminRepo) UpdateLendingByID(ctx context.Context, lending *models.Lending) (*models.Lending, error) {
-	if err := r.db.Omit("LoanPeriod", "LendingStatus", "Installments", "Debtor").WithContext(ctx).Where("lending_id = ?", lending.LendingID).Save(lending).Error; err != nil {
+	if err := r.db.Omit("LoanProduct", "LendingStatus", "Installments", "Guarantors", "RejectionReason", "Debtor").WithContext(ctx).Where("lending_id = ?", lending.LendingID).Save(lending).Error; err != nil {
 		return lending, err
 	}
 
@@ -174,29 +196,62 @@ func (r *adminRepo) CreateInstallments(ctx context.Context, lendingID string, in
 }
 
 func (r *adminRepo) CreateVoucher(ctx context.Context, voucher *models.Voucher) (*models.Voucher, error) {
-	if err := r.db.WithContext(ctx).Create(voucher).Error; err != nil {
+	if err := r.db.WithContext(ctx).Omit("VoucherStatus", "VoucherDiscountType", "CreditHealths.*", "LoanProducts.*").Create(voucher).Error; err != nil {
 		return nil, err
 	}
 
 	return voucher, nil
 }
 
-func (r *adminRepo) DeleteVoucher(ctx context.Context, voucher *models.Voucher) error {
-	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucher.VoucherID).Delete(voucher).Error; err != nil {
+func (r *adminRepo) UpdateVoucherStatus(ctx context.Context, voucher *models.Voucher) error {
+	if err := r.db.WithContext(ctx).Model(&models.Voucher{}).Where("voucher_id = ?", voucher.VoucherID).
+		Updates(map[string]interface{}{"voucher_status_id": voucher.VoucherStatusID, "archived_at": voucher.ArchivedAt, "updated_at": time.Now()}).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (r *adminRepo) GetUnarchivedVouchers(ctx context.Context) ([]*models.Voucher, error) {
+	var vouchers []*models.Voucher
+	if err := r.db.WithContext(ctx).Where("voucher_status_id <> ?", 5).Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+
+	return vouchers, nil
+}
+
+func (r *adminRepo) GetVoucherRedemptionStats(ctx context.Context, voucherIDs []string) ([]*models.VoucherRedemptionStats, error) {
+	var stats []*models.VoucherRedemptionStats
+	if len(voucherIDs) == 0 {
+		return stats, nil
+	}
+
+	if err := r.db.WithContext(ctx).Table("voucher_redemptions").
+		Select("voucher_redemptions.voucher_id, COUNT(*) AS redemptions, COALESCE(SUM(payments.payment_discount + payments.payment_fine_waiver), 0) AS discount_given").
+		Joins("JOIN payments ON payments.payment_id = voucher_redemptions.payment_id").
+		Where("voucher_redemptions.voucher_id IN ?", voucherIDs).
+		Group("voucher_redemptions.voucher_id").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
 func (r *adminRepo) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
 	lending := &models.Lending{}
 	if err := r.db.WithContext(ctx).
 		Preload("Debtor."+clause.Associations).
 		Preload("Installments."+clause.Associations).
 		Preload("LendingStatus").
-		Preload("LoanPeriod").
+		Preload("RejectionReason").
+		Preload("LoanProduct", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
 		Preload("Debtor").
+		Preload("Guarantors.User").
+		Preload("Guarantors.GuarantorStatus").
 		Preload("Installments", func(db *gorm.DB) *gorm.DB {
 			return db.Order("installments.due_date asc")
 		}).Where("lending_id = ?", lendingID).First(lending).Error; err != nil {
@@ -217,7 +272,7 @@ func (r *adminRepo) GetUserTotal(ctx context.Context) (int64, error) {
 
 func (r *adminRepo) GetLendingTotal(ctx context.Context) (int64, error) {
 	var lendingTotal int64
-	if err := r.db.Model(&models.Lending{}).WithContext(ctx).Where("lending_status_id NOT IN ?", []int{1, 5}).Count(&lendingTotal).Error; err != nil {
+	if err := r.db.Model(&models.Lending{}).WithContext(ctx).Where("lending_status_id NOT IN ?", []int{1, 5, 6, 7}).Count(&lendingTotal).Error; err != nil {
 		return lendingTotal, err
 	}
 
@@ -226,18 +281,62 @@ func (r *adminRepo) GetLendingTotal(ctx context.Context) (int64, error) {
 
 func (r *adminRepo) GetLendingAmount(ctx context.Context) (float64, error) {
 	var lendingAmount float64
-	r.db.Model(&models.Lending{}).WithContext(ctx).Where("lending_status_id NOT IN ?", []int{1, 5}).Select("sum(amount)").Row().Scan(&lendingAmount)
+	r.db.Model(&models.Lending{}).WithContext(ctx).Where("lending_status_id NOT IN ?", []int{1, 5, 6, 7}).Select("sum(amount)").Row().Scan(&lendingAmount)
 
 	return lendingAmount, nil
 }
 
 func (r *adminRepo) GetReturnAmount(ctx context.Context) (float64, error) {
 	var returnAmount float64
-	r.db.Model(&models.Payment{}).WithContext(ctx).Select("sum(payment_amount + payment_discount)").Row().Scan(&returnAmount)
+	r.db.Model(&models.Payment{}).WithContext(ctx).
+		Select("sum(payment_amount + payment_discount)").
+		Where("NOT EXISTS (SELECT 1 FROM payment_reversals WHERE payment_reversals.payment_id = payments.payment_id)").
+		Row().Scan(&returnAmount)
 
 	return returnAmount, nil
 }
 
+func (r *adminRepo) GetWriteOffAmount(ctx context.Context) (float64, error) {
+	var writeOffAmount float64
+	r.db.Model(&models.WriteOff{}).WithContext(ctx).Select("sum(principal_loss)").Row().Scan(&writeOffAmount)
+
+	return writeOffAmount, nil
+}
+
+func (r *adminRepo) CreateRestructuring(ctx context.Context, restructuring *models.Restructuring) (*models.Restructuring, error) {
+	if err := r.db.WithContext(ctx).Create(restructuring).Error; err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (r *adminRepo) GetRestructuringsByLendingID(ctx context.Context, lendingID string) ([]*models.Restructuring, error) {
+	var restructurings []*models.Restructuring
+	if err := r.db.WithContext(ctx).
+		Preload("RestructuringStatus").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Preload("SupersededInstallments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Where("lending_id = ?", lendingID).Order("created_at desc").
+		Find(&restructurings).Error; err != nil {
+		return restructurings, err
+	}
+
+	return restructurings, nil
+}
+
+func (r *adminRepo) CreateWriteOff(ctx context.Context, writeOff *models.WriteOff) (*models.WriteOff, error) {
+	if err := r.db.WithContext(ctx).Create(writeOff).Error; err != nil {
+		return writeOff, err
+	}
+
+	return writeOff, nil
+}
+
 func (r *adminRepo) GetLendingAction(ctx context.Context) ([]*models.Lending, error) {
 	var loans []*models.Lending
 
@@ -284,41 +383,100 @@ func (r *adminRepo) GetLoans(ctx context.Context, name string, status []int, pag
 	return pagination, nil
 }
 
-func (r *adminRepo) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	var rows []*models.Voucher
+func (r *adminRepo) GetVouchers(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	var vouchers []*models.Voucher
 
 	var totalRows int64
 	r.db.Model(vouchers).WithContext(ctx).
-		Where("name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
+		Where("name ILIKE ? AND voucher_status_id IN ?", fmt.Sprintf("%%%s%%", name), status).
 		Count(&totalRows)
 
 	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
 	pagination.TotalRows = totalRows
 	pagination.TotalPages = totalPages
 
-	if err := r.db.WithContext(ctx).Where("name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
+	if err := r.db.WithContext(ctx).Preload("VoucherStatus").Preload("VoucherDiscountType").Preload("CreditHealths").Preload("LoanProducts").
+		Where("name ILIKE ? AND voucher_status_id IN ?", fmt.Sprintf("%%%s%%", name), status).
 		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
 		Find(&vouchers).Error; err != nil {
 		return nil, err
 	}
 
-	loc, _ := time.LoadLocation("Asia/Jakarta")
-	timeNow := time.Now().In(loc)
-	for _, voucher := range vouchers {
-		if timeNow.Sub(voucher.ExpireDate) > 0 {
-			if err := r.DeleteVoucher(ctx, voucher); err != nil {
-				return nil, err
-			}
-		} else {
-			rows = append(rows, voucher)
-		}
+	pagination.Rows = vouchers
+	return pagination, nil
+}
+
+func (r *adminRepo) GetLoanProducts(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	var loanProducts []*models.LoanProduct
+
+	var totalRows int64
+	r.db.Model(loanProducts).WithContext(ctx).
+		Where("name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
+		Count(&totalRows)
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = totalPages
+
+	if err := r.db.WithContext(ctx).Preload("CreditHealths").Where("name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Find(&loanProducts).Error; err != nil {
+		return nil, err
 	}
 
-	pagination.Rows = rows
+	pagination.Rows = loanProducts
 	return pagination, nil
 }
 
+func (r *adminRepo) GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	loanProduct := &models.LoanProduct{}
+	if err := r.db.WithContext(ctx).Preload("CreditHealths").Where("loan_product_id = ?", loanProductID).First(loanProduct).Error; err != nil {
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
+func (r *adminRepo) CreateLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) (*models.LoanProduct, error) {
+	if err := r.db.WithContext(ctx).Omit("CreditHealths.*").Create(loanProduct).Error; err != nil {
+		return nil, err
+	}
+
+	return loanProduct, nil
+}
+
+func (r *adminRepo) UpdateLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) (*models.LoanProduct, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("CreditHealths").Where("loan_product_id = ?", loanProduct.LoanProductID).Save(loanProduct).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(loanProduct).Omit("CreditHealths.*").Association("CreditHealths").Replace(loanProduct.CreditHealths)
+	})
+	if err != nil {
+		return loanProduct, err
+	}
+
+	return r.GetLoanProductByID(ctx, loanProduct.LoanProductID.String())
+}
+
+func (r *adminRepo) DeleteLoanProduct(ctx context.Context, loanProduct *models.LoanProduct) error {
+	if err := r.db.WithContext(ctx).Where("loan_product_id = ?", loanProduct.LoanProductID).Delete(loanProduct).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *adminRepo) GetCreditHealthTypesByID(ctx context.Context, creditHealthIDs []int) ([]models.CreditHealthType, error) {
+	var creditHealths []models.CreditHealthType
+	if err := r.db.WithContext(ctx).Where("credit_health_id IN ?", creditHealthIDs).Find(&creditHealths).Error; err != nil {
+		return creditHealths, err
+	}
+
+	return creditHealths, nil
+}
+
 func (r *adminRepo) GetPayments(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
 	var payments []*models.Payment
 
@@ -338,9 +496,11 @@ func (r *adminRepo) GetPayments(ctx context.Context, name string, pagination *ut
 		Joins("inner join lendings on installments.lending_id = lendings.lending_id").
 		Where("lendings.name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
 		Preload("Voucher").
+		Preload("Voucher.VoucherDiscountType").
 		Preload("Installment").
 		Preload("Installment.Lending").
 		Preload("Installment.InstallmentStatus").
+		Preload("Reversal").
 		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
 		Find(&payments).Error; err != nil {
 		return pagination, err
@@ -349,3 +509,681 @@ func (r *adminRepo) GetPayments(ctx context.Context, name string, pagination *ut
 	pagination.Rows = payments
 	return pagination, nil
 }
+
+func (r *adminRepo) CreateStatementImport(ctx context.Context, statementImport *models.StatementImport) (*models.StatementImport, error) {
+	if err := r.db.WithContext(ctx).Omit("Items").Create(statementImport).Error; err != nil {
+		return statementImport, err
+	}
+
+	return statementImport, nil
+}
+
+func (r *adminRepo) UpdateStatementImport(ctx context.Context, statementImport *models.StatementImport) (*models.StatementImport, error) {
+	if err := r.db.Omit("Items").WithContext(ctx).Where("statement_import_id = ?", statementImport.StatementImportID).Save(statementImport).Error; err != nil {
+		return statementImport, err
+	}
+
+	return statementImport, nil
+}
+
+func (r *adminRepo) GetStatementImportByID(ctx context.Context, statementImportID string) (*models.StatementImport, error) {
+	statementImport := &models.StatementImport{}
+	if err := r.db.WithContext(ctx).
+		Preload("Items.ReconciliationStatus").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("reconciliation_items.line_number asc")
+		}).Where("statement_import_id = ?", statementImportID).First(statementImport).Error; err != nil {
+		return statementImport, err
+	}
+
+	return statementImport, nil
+}
+
+func (r *adminRepo) CheckReconciliationLineExist(ctx context.Context, lineHash string) (bool, error) {
+	var total int64
+	if err := r.db.Model(&models.ReconciliationItem{}).WithContext(ctx).Where("line_hash = ?", lineHash).Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *adminRepo) CreateReconciliationItem(ctx context.Context, item *models.ReconciliationItem) (*models.ReconciliationItem, error) {
+	if err := r.db.WithContext(ctx).Create(item).Error; err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *adminRepo) UpdateReconciliationItem(ctx context.Context, item *models.ReconciliationItem) (*models.ReconciliationItem, error) {
+	if err := r.db.Omit("ReconciliationStatus", "PaymentIntent", "Payment").WithContext(ctx).Where("reconciliation_item_id = ?", item.ReconciliationItemID).Save(item).Error; err != nil {
+		return item, err
+	}
+
+	item, err := r.GetReconciliationItemByID(ctx, item.ReconciliationItemID.String())
+	if err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *adminRepo) GetReconciliationItemByID(ctx context.Context, itemID string) (*models.ReconciliationItem, error) {
+	item := &models.ReconciliationItem{}
+	if err := r.db.Preload(clause.Associations).WithContext(ctx).Where("reconciliation_item_id = ?", itemID).First(item).Error; err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *adminRepo) GetReconciliationItemForUpdate(ctx context.Context, itemID string) (*models.ReconciliationItem, error) {
+	item := &models.ReconciliationItem{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("reconciliation_item_id = ?", itemID).First(item).Error; err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (r *adminRepo) GetReconciliationItems(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	var items []*models.ReconciliationItem
+
+	var totalRows int64
+	r.db.Model(items).WithContext(ctx).
+		Where("reconciliation_status_id in ?", status).
+		Count(&totalRows)
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = totalPages
+
+	if err := r.db.WithContext(ctx).
+		Preload(clause.Associations).
+		Where("reconciliation_status_id in ?", status).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Find(&items).Error; err != nil {
+		return pagination, err
+	}
+
+	pagination.Rows = items
+	return pagination, nil
+}
+
+func (r *adminRepo) GetOpenPaymentIntentsByReference(ctx context.Context, references []string) ([]*models.PaymentIntent, error) {
+	var intents []*models.PaymentIntent
+	if len(references) == 0 {
+		return intents, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("payment_intent_status_id IN ? AND (account_number IN ? OR external_id IN ?)", []int{1, 3}, references, references).
+		Find(&intents).Error; err != nil {
+		return intents, err
+	}
+
+	return intents, nil
+}
+
+func (r *adminRepo) GetOpenInstallmentsByID(ctx context.Context, installmentIDs []string) ([]*models.Installment, error) {
+	var installments []*models.Installment
+	if len(installmentIDs) == 0 {
+		return installments, nil
+	}
+
+	if err := r.db.WithContext(ctx).Preload("Lending").
+		Where("installment_status_id = ? AND installment_id IN ?", 1, installmentIDs).
+		Find(&installments).Error; err != nil {
+		return installments, err
+	}
+
+	return installments, nil
+}
+
+func (r *adminRepo) GetPaymentIntentByID(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error) {
+	intent := &models.PaymentIntent{}
+	if err := r.db.Preload("PaymentIntentStatus").WithContext(ctx).Where("payment_intent_id = ?", paymentIntentID).First(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *adminRepo) CreatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error) {
+	if err := r.db.WithContext(ctx).Create(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *adminRepo) GetScorableDebtors(ctx context.Context) ([]*models.Debtor, error) {
+	var debtors []*models.Debtor
+	if err := r.db.WithContext(ctx).Where("contract_tracking_id = ?", 5).Find(&debtors).Error; err != nil {
+		return debtors, err
+	}
+
+	return debtors, nil
+}
+
+func (r *adminRepo) GetSettledPaymentsByDebtorID(ctx context.Context, debtorID string) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Joins("inner join installments on installments.installment_id = payments.installment_id").
+		Joins("inner join lendings on installments.lending_id = lendings.lending_id").
+		Where("lendings.debtor_id = ?", debtorID).
+		Where("NOT EXISTS (SELECT 1 FROM payment_reversals WHERE payment_reversals.payment_id = payments.payment_id)").
+		Preload("Installment").
+		Order("payments.payment_date asc").
+		Find(&payments).Error; err != nil {
+		return payments, err
+	}
+
+	return payments, nil
+}
+
+func (r *adminRepo) CheckPendingCreditLimitProposal(ctx context.Context, debtorID string) (bool, error) {
+	var total int64
+	if err := r.db.Model(&models.CreditLimitProposal{}).WithContext(ctx).
+		Where("debtor_id = ? AND credit_limit_proposal_status_id = ?", debtorID, 1).
+		Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *adminRepo) CreateCreditLimitProposal(ctx context.Context, proposal *models.CreditLimitProposal) (*models.CreditLimitProposal, error) {
+	if err := r.db.WithContext(ctx).Create(proposal).Error; err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+func (r *adminRepo) GetCreditLimitProposals(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	var proposals []*models.CreditLimitProposal
+
+	var totalRows int64
+	r.db.Model(proposals).WithContext(ctx).
+		Where("credit_limit_proposal_status_id in ?", status).
+		Count(&totalRows)
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = totalPages
+
+	if err := r.db.WithContext(ctx).
+		Preload("Debtor.User").
+		Preload("CreditLimitProposalStatus").
+		Where("credit_limit_proposal_status_id in ?", status).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Find(&proposals).Error; err != nil {
+		return pagination, err
+	}
+
+	pagination.Rows = proposals
+	return pagination, nil
+}
+
+func (r *adminRepo) GetCreditLimitProposalForUpdate(ctx context.Context, proposalID string) (*models.CreditLimitProposal, error) {
+	proposal := &models.CreditLimitProposal{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("credit_limit_proposal_id = ?", proposalID).First(proposal).Error; err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+func (r *adminRepo) UpdateCreditLimitProposal(ctx context.Context, proposal *models.CreditLimitProposal) (*models.CreditLimitProposal, error) {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).
+		Where("credit_limit_proposal_id = ?", proposal.CreditLimitProposalID).Save(proposal).Error; err != nil {
+		return proposal, err
+	}
+
+	if err := r.db.WithContext(ctx).Preload("Debtor.User").Preload("CreditLimitProposalStatus").
+		Where("credit_limit_proposal_id = ?", proposal.CreditLimitProposalID).First(proposal).Error; err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+func (r *adminRepo) CreateCreditHealthHistory(ctx context.Context, history *models.CreditHealthHistory) (*models.CreditHealthHistory, error) {
+	if err := r.db.WithContext(ctx).Create(history).Error; err != nil {
+		return history, err
+	}
+
+	return history, nil
+}
+
+func (r *adminRepo) GetKycSubmissions(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	var submissions []*models.KycSubmission
+
+	var totalRows int64
+	r.db.Model(submissions).WithContext(ctx).
+		Where("kyc_status_id in ?", status).
+		Count(&totalRows)
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = totalPages
+
+	if err := r.db.WithContext(ctx).
+		Preload("Debtor.User").
+		Preload("KycStatus").
+		Where("kyc_status_id in ?", status).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Find(&submissions).Error; err != nil {
+		return pagination, err
+	}
+
+	pagination.Rows = submissions
+	return pagination, nil
+}
+
+func (r *adminRepo) GetKycSubmissionByID(ctx context.Context, submissionID string) (*models.KycSubmission, error) {
+	submission := &models.KycSubmission{}
+	if err := r.db.WithContext(ctx).Preload("Debtor.User").Preload("KycStatus").
+		Where("kyc_submission_id = ?", submissionID).First(submission).Error; err != nil {
+		return submission, err
+	}
+
+	return submission, nil
+}
+
+func (r *adminRepo) GetKycSubmissionForUpdate(ctx context.Context, submissionID string) (*models.KycSubmission, error) {
+	submission := &models.KycSubmission{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("kyc_submission_id = ?", submissionID).First(submission).Error; err != nil {
+		return submission, err
+	}
+
+	return submission, nil
+}
+
+func (r *adminRepo) UpdateKycSubmission(ctx context.Context, submission *models.KycSubmission) (*models.KycSubmission, error) {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).
+		Where("kyc_submission_id = ?", submission.KycSubmissionID).Save(submission).Error; err != nil {
+		return submission, err
+	}
+
+	return r.GetKycSubmissionByID(ctx, submission.KycSubmissionID.String())
+}
+
+func (r *adminRepo) GetLendingForUpdate(ctx context.Context, lendingID string) (*models.Lending, error) {
+	lending := &models.Lending{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("lending_id = ?", lendingID).First(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
+func (r *adminRepo) GetDebtorForUpdate(ctx context.Context, debtorID string) (*models.Debtor, error) {
+	debtor := &models.Debtor{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("debtor_id = ?", debtorID).First(debtor).Error; err != nil {
+		return debtor, err
+	}
+
+	return debtor, nil
+}
+
+func (r *adminRepo) CreateAdminAction(ctx context.Context, action *models.AdminAction) (*models.AdminAction, error) {
+	if err := r.db.WithContext(ctx).Create(action).Error; err != nil {
+		return action, err
+	}
+
+	return action, nil
+}
+
+func (r *adminRepo) GetRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error) {
+	var reasons []*models.RejectionReason
+	if err := r.db.WithContext(ctx).Order("rejection_reason_id asc").Find(&reasons).Error; err != nil {
+		return reasons, err
+	}
+
+	return reasons, nil
+}
+
+func (r *adminRepo) GetRejectionReasonByID(ctx context.Context, reasonID int) (*models.RejectionReason, error) {
+	reason := &models.RejectionReason{}
+	if err := r.db.WithContext(ctx).Where("rejection_reason_id = ?", reasonID).First(reason).Error; err != nil {
+		return reason, err
+	}
+
+	return reason, nil
+}
+
+func (r *adminRepo) CheckRejectionReasonCodeExist(ctx context.Context, code string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.RejectionReason{}).
+		Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *adminRepo) CreateRejectionReason(ctx context.Context, reason *models.RejectionReason) (*models.RejectionReason, error) {
+	if err := r.db.WithContext(ctx).Create(reason).Error; err != nil {
+		return reason, err
+	}
+
+	return reason, nil
+}
+
+func (r *adminRepo) UpdateRejectionReason(ctx context.Context, reason *models.RejectionReason) (*models.RejectionReason, error) {
+	if err := r.db.WithContext(ctx).Where("rejection_reason_id = ?", reason.RejectionReasonID).Save(reason).Error; err != nil {
+		return reason, err
+	}
+
+	return reason, nil
+}
+
+func (r *adminRepo) GetRejectionReasonCounts(ctx context.Context, period string, from, to time.Time) ([]*models.RejectionReasonCount, error) {
+	var counts []*models.RejectionReasonCount
+	if err := r.db.WithContext(ctx).Model(&models.Lending{}).
+		Select("date_trunc(?, lendings.rejected_at) AS period, rejection_reasons.rejection_reason_id, rejection_reasons.code, rejection_reasons.name, count(*) AS total", period).
+		Joins("JOIN rejection_reasons ON rejection_reasons.rejection_reason_id = lendings.rejection_reason_id").
+		Where("lendings.lending_status_id = ? AND lendings.rejected_at >= ? AND lendings.rejected_at < ?", 5, from, to).
+		Group("period, rejection_reasons.rejection_reason_id, rejection_reasons.code, rejection_reasons.name").
+		Order("period asc, total desc").
+		Scan(&counts).Error; err != nil {
+		return counts, err
+	}
+
+	return counts, nil
+}
+
+func (r *adminRepo) ExportLoans(ctx context.Context, name string, status []int, sort string, fn func(row *models.LoanExport) error) error {
+	rows, err := r.db.WithContext(ctx).Model(&models.Lending{}).
+		Select("lendings.lending_id, users.name AS debtor_name, users.email AS debtor_email, lendings.name, "+
+			"loan_products.name AS loan_product_name, lending_status_types.name AS lending_status, "+
+			"lendings.amount, lendings.duration, lendings.percentage, lendings.created_at").
+		Joins("inner join debtors on debtors.debtor_id = lendings.debtor_id").
+		Joins("inner join users on users.user_id = debtors.user_id").
+		Joins("inner join loan_products on loan_products.loan_product_id = lendings.loan_product_id").
+		Joins("inner join lending_status_types on lending_status_types.lending_status_id = lendings.lending_status_id").
+		Where("lendings.name ILIKE ? AND lendings.lending_status_id in ?", fmt.Sprintf("%%%s%%", name), status).
+		Order("lendings." + sort).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &models.LoanExport{}
+		if err := r.db.ScanRows(rows, row); err != nil {
+			return err
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *adminRepo) ExportPayments(ctx context.Context, name string, sort string, fn func(row *models.PaymentExport) error) error {
+	rows, err := r.db.WithContext(ctx).Model(&models.Payment{}).
+		Select("payments.payment_id, users.name AS debtor_name, lendings.name AS lending_name, "+
+			"installments.due_date AS installment_due, vouchers.name AS voucher_name, payments.payment_amount, "+
+			"payments.payment_discount, payments.payment_fine, payments.payment_fine_waiver, payments.payment_date, "+
+			"payment_reversals.created_at AS reversed_at").
+		Joins("inner join installments on installments.installment_id = payments.installment_id").
+		Joins("inner join lendings on installments.lending_id = lendings.lending_id").
+		Joins("inner join debtors on debtors.debtor_id = lendings.debtor_id").
+		Joins("inner join users on users.user_id = debtors.user_id").
+		Joins("left join vouchers on vouchers.voucher_id = payments.voucher_id").
+		Joins("left join payment_reversals on payment_reversals.payment_id = payments.payment_id").
+		Where("lendings.name ILIKE ?", fmt.Sprintf("%%%s%%", name)).
+		Order("payments." + sort).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &models.PaymentExport{}
+		if err := r.db.ScanRows(rows, row); err != nil {
+			return err
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *adminRepo) ExportDebtors(ctx context.Context, name string, sort string, fn func(row *models.DebtorExport) error) error {
+	rows, err := r.db.WithContext(ctx).Model(&models.Debtor{}).
+		Select("debtors.debtor_id, users.name, users.email, users.phone_number, "+
+			"credit_health_types.name AS credit_health, contract_tracking_types.name AS contract_tracking, "+
+			"debtors.credit_limit, debtors.credit_used, debtors.total_delay, debtors.created_at").
+		Joins("inner join users on users.user_id = debtors.user_id").
+		Joins("inner join credit_health_types on credit_health_types.credit_health_id = debtors.credit_health_id").
+		Joins("inner join contract_tracking_types on contract_tracking_types.contract_tracking_id = debtors.contract_tracking_id").
+		Where("users.name ILIKE ? OR users.email ILIKE ?", fmt.Sprintf("%%%s%%", name), fmt.Sprintf("%%%s%%", name)).
+		Order("debtors." + sort).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &models.DebtorExport{}
+		if err := r.db.ScanRows(rows, row); err != nil {
+			return err
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *adminRepo) GetLendingDecisionAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.LendingDecisionAggregate, error) {
+	var aggregates []*models.LendingDecisionAggregate
+	// Loans approved before approved_at was recorded fall back to created_at.
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT date_trunc(?, decided_at AT TIME ZONE 'Asia/Jakarta') AS period,
+		       count(*) FILTER (WHERE lending_status_id <> 5) AS approved,
+		       count(*) FILTER (WHERE lending_status_id = 5) AS rejected,
+		       coalesce(sum(amount) FILTER (WHERE lending_status_id <> 5), 0) AS disbursed,
+		       coalesce(sum(extract(epoch FROM approved_at - created_at)), 0) AS approval_seconds,
+		       count(approved_at) AS timed_approvals
+		FROM (SELECT lending_status_id, amount, approved_at, created_at,
+		             CASE WHEN lending_status_id = 5 THEN rejected_at ELSE coalesce(approved_at, created_at) END AS decided_at
+		      FROM lendings
+		      WHERE lending_status_id IN ?) AS decisions
+		WHERE decided_at >= ? AND decided_at < ?
+		GROUP BY period
+		ORDER BY period`, granularity, []int{2, 3, 4, 5, 6}, from, to).
+		Scan(&aggregates).Error; err != nil {
+		return aggregates, err
+	}
+
+	return aggregates, nil
+}
+
+func (r *adminRepo) GetPaymentAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.PaymentAggregate, error) {
+	var aggregates []*models.PaymentAggregate
+	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
+		Select("date_trunc(?, payments.payment_date AT TIME ZONE 'Asia/Jakarta') AS period, "+
+			"sum(payments.payment_amount) AS repaid, sum(payments.payment_fine - payments.payment_fine_waiver) AS fines, "+
+			"sum(payments.payment_discount + payments.payment_fine_waiver) AS discounts", granularity).
+		Joins("left join payment_reversals on payment_reversals.payment_id = payments.payment_id").
+		Where("payment_reversals.payment_reversal_id IS NULL AND payments.payment_date >= ? AND payments.payment_date < ?", from, to).
+		Group("period").Order("period").
+		Scan(&aggregates).Error; err != nil {
+		return aggregates, err
+	}
+
+	return aggregates, nil
+}
+
+func (r *adminRepo) GetDebtorAggregates(ctx context.Context, granularity string, from, to time.Time) ([]*models.DebtorAggregate, error) {
+	var aggregates []*models.DebtorAggregate
+	if err := r.db.WithContext(ctx).Model(&models.Debtor{}).
+		Select("date_trunc(?, debtors.created_at AT TIME ZONE 'Asia/Jakarta') AS period, count(*) AS new_debtors", granularity).
+		Where("debtors.created_at >= ? AND debtors.created_at < ?", from, to).
+		Group("period").Order("period").
+		Scan(&aggregates).Error; err != nil {
+		return aggregates, err
+	}
+
+	return aggregates, nil
+}
+
+// GetLoanPositions rebuilds every loan's unpaid principal as it stood just
+// before at, so a report can be reproduced for any past date. Payments count
+// unless reversed before at, and installments count until the restructuring
+// that replaced them created its own.
+func (r *adminRepo) GetLoanPositions(ctx context.Context, at time.Time) ([]*models.LoanPosition, error) {
+	var positions []*models.LoanPosition
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT installments.lending_id,
+		       date_trunc('month', coalesce(lendings.approved_at, lendings.created_at) AT TIME ZONE 'Asia/Jakarta') AS cohort,
+		       sum(installments.amount) AS outstanding,
+		       min(installments.due_date) AS oldest_due_date,
+		       write_offs.write_off_id IS NOT NULL AS written_off
+		FROM installments
+		JOIN lendings ON lendings.lending_id = installments.lending_id
+		LEFT JOIN write_offs ON write_offs.lending_id = installments.lending_id AND write_offs.created_at < @at
+		WHERE installments.created_at < @at
+		  AND NOT EXISTS (SELECT 1 FROM installments AS replacements
+		                  WHERE replacements.restructuring_id = installments.superseded_by_id
+		                    AND replacements.created_at < @at)
+		  AND NOT EXISTS (SELECT 1 FROM payments
+		                  LEFT JOIN payment_reversals ON payment_reversals.payment_id = payments.payment_id
+		                   AND payment_reversals.created_at < @at
+		                  WHERE payments.installment_id = installments.installment_id
+		                    AND payments.payment_date < @at
+		                    AND payment_reversals.payment_reversal_id IS NULL)
+		GROUP BY installments.lending_id, cohort, written_off`, sql.Named("at", at)).
+		Scan(&positions).Error; err != nil {
+		return positions, err
+	}
+
+	return positions, nil
+}
+
+func (r *adminRepo) GetLoanCohorts(ctx context.Context, from, to time.Time) ([]*models.LoanCohort, error) {
+	var cohorts []*models.LoanCohort
+	if err := r.db.WithContext(ctx).Model(&models.Lending{}).
+		Select("date_trunc('month', coalesce(lendings.approved_at, lendings.created_at) AT TIME ZONE 'Asia/Jakarta') AS cohort, "+
+			"count(*) AS loans, sum(lendings.amount) AS amount").
+		Where("lendings.lending_status_id IN ? AND coalesce(lendings.approved_at, lendings.created_at) >= ? "+
+			"AND coalesce(lendings.approved_at, lendings.created_at) < ?", []int{2, 3, 4, 6}, from, to).
+		Group("cohort").Order("cohort").
+		Scan(&cohorts).Error; err != nil {
+		return cohorts, err
+	}
+
+	return cohorts, nil
+}
+
+func (r *adminRepo) GetLoanProductsByID(ctx context.Context, loanProductIDs []string) ([]models.LoanProduct, error) {
+	var loanProducts []models.LoanProduct
+	if err := r.db.WithContext(ctx).Where("loan_product_id IN ?", loanProductIDs).Find(&loanProducts).Error; err != nil {
+		return loanProducts, err
+	}
+
+	return loanProducts, nil
+}
+
+func (r *adminRepo) GetVoucherCodes(ctx context.Context, voucherID string) ([]*models.VoucherCode, error) {
+	var codes []*models.VoucherCode
+	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucherID).Order("created_at, code").Find(&codes).Error; err != nil {
+		return codes, err
+	}
+
+	return codes, nil
+}
+
+func (r *adminRepo) GetExistingVoucherCodes(ctx context.Context, codes []string) ([]string, error) {
+	var existing []string
+	if err := r.db.WithContext(ctx).Model(&models.VoucherCode{}).
+		Where("code IN ?", codes).Pluck("code", &existing).Error; err != nil {
+		return existing, err
+	}
+
+	return existing, nil
+}
+
+func (r *adminRepo) CreateVoucherCodes(ctx context.Context, codes []*models.VoucherCode) ([]*models.VoucherCode, error) {
+	if err := r.db.WithContext(ctx).Omit("Voucher").CreateInBatches(codes, 500).Error; err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (r *adminRepo) GetVoucherRedemptionAggregates(ctx context.Context, voucherID, granularity string) ([]*models.VoucherRedemptionAggregate, error) {
+	var aggregates []*models.VoucherRedemptionAggregate
+	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
+		Select("date_trunc(?, payments.payment_date AT TIME ZONE 'Asia/Jakarta') AS period, count(*) AS redemptions, "+
+			"count(*) FILTER (WHERE payments.payment_date > installments.due_date) AS late_redemptions, "+
+			"sum(payments.payment_discount + payments.payment_fine_waiver) AS discounts", granularity).
+		Joins("join installments on installments.installment_id = payments.installment_id").
+		Joins("left join payment_reversals on payment_reversals.payment_id = payments.payment_id").
+		Where("payment_reversals.payment_reversal_id IS NULL AND payments.voucher_id = ?", voucherID).
+		Group("period").Order("period").
+		Scan(&aggregates).Error; err != nil {
+		return aggregates, err
+	}
+
+	return aggregates, nil
+}
+
+// GetVoucherRepaymentAggregates counts the installments due between from and
+// to, and how many of them were paid by their due date, for debtors who
+// redeemed the voucher and for everyone else. Restructured installments are
+// left out as their replacements carry the debt.
+func (r *adminRepo) GetVoucherRepaymentAggregates(ctx context.Context, voucherID string, from, to time.Time) ([]*models.VoucherRepaymentAggregate, error) {
+	var aggregates []*models.VoucherRepaymentAggregate
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT lendings.debtor_id IN (
+		           SELECT voucher_lendings.debtor_id
+		           FROM payments
+		                    JOIN installments voucher_installments ON voucher_installments.installment_id = payments.installment_id
+		                    JOIN lendings voucher_lendings ON voucher_lendings.lending_id = voucher_installments.lending_id
+		                    LEFT JOIN payment_reversals ON payment_reversals.payment_id = payments.payment_id
+		           WHERE payments.voucher_id = @voucher AND payment_reversals.payment_reversal_id IS NULL
+		       ) AS used_voucher,
+		       count(*) AS installments,
+		       count(*) FILTER (WHERE EXISTS (
+		           SELECT 1
+		           FROM payments
+		                    LEFT JOIN payment_reversals ON payment_reversals.payment_id = payments.payment_id
+		           WHERE payments.installment_id = installments.installment_id
+		             AND payments.payment_date <= installments.due_date
+		             AND payment_reversals.payment_reversal_id IS NULL
+		       )) AS on_time
+		FROM installments
+		         JOIN lendings ON lendings.lending_id = installments.lending_id
+		WHERE installments.installment_status_id <> 3
+		  AND installments.due_date >= @from
+		  AND installments.due_date < @to
+		GROUP BY used_voucher`,
+		sql.Named("voucher", voucherID), sql.Named("from", from), sql.Named("to", to)).
+		Scan(&aggregates).Error; err != nil {
+		return aggregates, err
+	}
+
+	return aggregates, nil
+}
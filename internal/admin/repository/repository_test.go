@@ -0,0 +1,1965 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"final-project-backend/internal/admin"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/models/modelstest"
+	"final-project-backend/pkg/postgres/postgrestest"
+	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+	"time"
+)
+
+var testDB *gorm.DB
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+func TestMain(m *testing.M) {
+	db, stop, err := postgrestest.Open()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	testDB = db
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func newRepo(t *testing.T) admin.Repository {
+	t.Helper()
+	require.NoError(t, postgrestest.Reset(testDB))
+
+	return NewAdminRepository(testDB)
+}
+
+func page(limit, page int, sort string) *utils.Pagination {
+	return &utils.Pagination{Limit: limit, Page: page, Sort: sort}
+}
+
+// wib parses a Jakarta wall clock time such as "2024-03-10 08:00".
+func wib(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, jakarta)
+	require.NoError(t, err)
+	return parsed
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func createAdmin(t *testing.T) *models.User {
+	return modelstest.CreateUser(t, testDB, func(u *models.User) { u.RoleID = 1 })
+}
+
+func TestTransaction(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	adminUser := createAdmin(t)
+	errRollback := errors.New("rollback")
+
+	action := func(detail string) *models.AdminAction {
+		return &models.AdminAction{AdminActionID: uuid.New(), UserID: adminUser.UserID, Action: "approve_loan", TargetID: uuid.New(), Detail: detail}
+	}
+
+	err := repo.Transaction(ctx, func(tx admin.Repository) error {
+		_, err := tx.CreateAdminAction(ctx, action("rolled back"))
+		require.NoError(t, err)
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	err = repo.Transaction(ctx, func(tx admin.Repository) error {
+		_, err := tx.CreateAdminAction(ctx, action("committed"))
+		return err
+	})
+	require.NoError(t, err)
+
+	var details []string
+	require.NoError(t, testDB.Model(&models.AdminAction{}).Pluck("detail", &details).Error)
+	assert.Equal(t, []string{"committed"}, details)
+}
+
+func TestGetDebtors(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+
+	create := func(name, email string, offset time.Duration) *models.Debtor {
+		user := modelstest.CreateUser(t, testDB, func(u *models.User) {
+			u.Name = name
+			u.Email = email
+		})
+		return modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) {
+			d.UserID = user.UserID
+			d.CreatedAt = base.Add(offset)
+		})
+	}
+	byName := create("Budi Santoso", "santoso@example.com", 0)
+	byEmail := create("Siti Aminah", "siti.BUDI@example.com", time.Minute)
+	create("Andi Wijaya", "andi@example.com", 2*time.Minute)
+
+	pagination, err := repo.GetDebtors(ctx, "budi", page(1, 1, "created_at asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	debtors := pagination.Rows.([]*models.Debtor)
+	require.Len(t, debtors, 1)
+	assert.Equal(t, byName.DebtorID, debtors[0].DebtorID)
+	require.NotNil(t, debtors[0].User)
+	assert.Equal(t, "Budi Santoso", debtors[0].User.Name)
+	assert.NotNil(t, debtors[0].CreditHealth)
+	assert.NotNil(t, debtors[0].ContractTracking)
+	assert.NotNil(t, debtors[0].KycStatus)
+
+	pagination, err = repo.GetDebtors(ctx, "budi", page(1, 2, "created_at asc"))
+	require.NoError(t, err)
+	debtors = pagination.Rows.([]*models.Debtor)
+	require.Len(t, debtors, 1)
+	assert.Equal(t, byEmail.DebtorID, debtors[0].DebtorID)
+
+	pagination, err = repo.GetDebtors(ctx, "", page(10, 1, ""))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pagination.TotalRows)
+	assert.Len(t, pagination.Rows.([]*models.Debtor), 3)
+}
+
+func TestGetLendingByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+		l.LendingStatusID = 5
+		l.RejectionReasonID = ptr(1)
+		l.RejectedAt = ptr(time.Now())
+	})
+	modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = lending.LendingID })
+	modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) { g.LendingID = lending.LendingID })
+
+	found, err := repo.GetLendingByID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.Debtor)
+	require.NotNil(t, found.LoanProduct)
+	require.NotNil(t, found.LendingStatus)
+	assert.Equal(t, "reject", found.LendingStatus.Name)
+	require.NotNil(t, found.RejectionReason)
+	assert.Equal(t, "insufficient_income", found.RejectionReason.Code)
+	require.NotNil(t, found.Installments)
+	assert.Len(t, *found.Installments, 1)
+	require.NotNil(t, found.Guarantors)
+	assert.Len(t, *found.Guarantors, 1)
+
+	_, err = repo.GetLendingByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetInstallmentByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.InstallmentStatusID = 2 })
+
+	found, err := repo.GetInstallmentByID(ctx, installment.InstallmentID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.InstallmentStatus)
+	assert.Equal(t, "paid", found.InstallmentStatus.Name)
+	require.NotNil(t, found.Lending)
+	assert.Equal(t, installment.LendingID, found.Lending.LendingID)
+
+	_, err = repo.GetInstallmentByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetLendingWithInstallmentByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = lending.LendingID })
+	modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.InstallmentStatusID = 2
+	})
+
+	found, err := repo.GetLendingWithInstallmentByID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.LendingStatus)
+	require.NotNil(t, found.Installments)
+	require.Len(t, *found.Installments, 2)
+	for _, installment := range *found.Installments {
+		assert.NotNil(t, installment.InstallmentStatus)
+	}
+
+	_, err = repo.GetLendingWithInstallmentByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetDebtorByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) {
+		d.CreditHealthID = 3
+		d.ContractTrackingID = 2
+	})
+
+	found, err := repo.GetDebtorByID(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.User)
+	assert.Equal(t, debtor.UserID, found.User.UserID)
+	require.NotNil(t, found.CreditHealth)
+	assert.Equal(t, "blocked", found.CreditHealth.Name)
+	require.NotNil(t, found.ContractTracking)
+	assert.Equal(t, "the contract was given to the expedition partner", found.ContractTracking.Name)
+	assert.Nil(t, found.KycStatus)
+
+	_, err = repo.GetDebtorByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestLookupTypes(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	contract, err := repo.GetContractStatusByID(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "confirmed contract", contract.Name)
+	_, err = repo.GetContractStatusByID(ctx, 99)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	health, err := repo.GetCreditHealthByID(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "warning", health.Name)
+	_, err = repo.GetCreditHealthByID(ctx, 99)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	healths, err := repo.GetCreditHealthTypesByID(ctx, []int{1, 3, 99})
+	require.NoError(t, err)
+	require.Len(t, healths, 2)
+	assert.ElementsMatch(t, []int{1, 3}, []int{healths[0].CreditHealthID, healths[1].CreditHealthID})
+}
+
+func TestGetVoucherByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	loanProduct := modelstest.CreateLoanProduct(t, testDB)
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+		v.VoucherDiscountTypeID = 3
+		v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}, {CreditHealthID: 2}}
+		v.LoanProducts = []models.LoanProduct{{LoanProductID: loanProduct.LoanProductID}}
+	})
+
+	found, err := repo.GetVoucherByID(ctx, voucher.VoucherID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.VoucherStatus)
+	assert.Equal(t, "active", found.VoucherStatus.Name)
+	require.NotNil(t, found.VoucherDiscountType)
+	assert.Equal(t, "fine waiver", found.VoucherDiscountType.Name)
+	assert.Len(t, found.CreditHealths, 2)
+	require.Len(t, found.LoanProducts, 1)
+	assert.Equal(t, loanProduct.LoanProductID, found.LoanProducts[0].LoanProductID)
+
+	_, err = repo.GetVoucherByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUpdateVoucherByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	before := modelstest.CreateLoanProduct(t, testDB)
+	after := modelstest.CreateLoanProduct(t, testDB)
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+		v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}}
+		v.LoanProducts = []models.LoanProduct{{LoanProductID: before.LoanProductID}}
+	})
+
+	voucher.Name = "Diskon Lebaran"
+	voucher.DiscountQuota = 5
+	voucher.CreditHealths = []models.CreditHealthType{{CreditHealthID: 2}, {CreditHealthID: 3}}
+	voucher.LoanProducts = []models.LoanProduct{{LoanProductID: after.LoanProductID}}
+	require.NoError(t, repo.UpdateVoucherByID(ctx, voucher))
+
+	found, err := repo.GetVoucherByID(ctx, voucher.VoucherID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Diskon Lebaran", found.Name)
+	assert.Equal(t, 5, found.DiscountQuota)
+	require.Len(t, found.CreditHealths, 2)
+	assert.ElementsMatch(t, []int{2, 3}, []int{found.CreditHealths[0].CreditHealthID, found.CreditHealths[1].CreditHealthID})
+	require.Len(t, found.LoanProducts, 1)
+	assert.Equal(t, after.LoanProductID, found.LoanProducts[0].LoanProductID)
+
+	// The replaced loan product itself must survive.
+	_, err = repo.GetLoanProductByID(ctx, before.LoanProductID.String())
+	require.NoError(t, err)
+
+	voucher.DiscountQuota = -1
+	assert.EqualError(t, repo.UpdateVoucherByID(ctx, voucher), response.VoucherQuotaNotValid)
+}
+
+func TestUpdateDebtorByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+
+	debtor.CreditLimit = 80000000
+	debtor.ContractTrackingID = 4
+	updated, err := repo.UpdateDebtorByID(ctx, debtor)
+	require.NoError(t, err)
+	assert.Equal(t, 80000000.0, updated.CreditLimit)
+	require.NotNil(t, updated.ContractTracking)
+	assert.Equal(t, "contract accepted by user", updated.ContractTracking.Name)
+	assert.NotNil(t, updated.User)
+	assert.NotNil(t, updated.CreditHealth)
+
+	// Lowering the limit below what is in use must not fail.
+	debtor.CreditUsed = 1000
+	debtor.CreditLimit = 0
+	_, err = repo.UpdateDebtorByID(ctx, debtor)
+	require.NoError(t, err)
+
+	debtor.CreditUsed = -1
+	_, err = repo.UpdateDebtorByID(ctx, debtor)
+	assert.EqualError(t, err, response.DataConstraintViolation)
+}
+
+func TestUpdateLendingByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 1 })
+
+	lending.LendingStatusID = 2
+	lending.ApprovedAt = ptr(time.Now())
+	updated, err := repo.UpdateLendingByID(ctx, lending)
+	require.NoError(t, err)
+	require.NotNil(t, updated.LendingStatus)
+	assert.Equal(t, "approved", updated.LendingStatus.Name)
+	assert.NotNil(t, updated.ApprovedAt)
+	assert.NotNil(t, updated.Debtor)
+
+	lending.LendingStatusID = 99
+	_, err = repo.UpdateLendingByID(ctx, lending)
+	assert.EqualError(t, err, response.ReferencedDataNotExist)
+}
+
+func TestUpdateInstallmentByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB)
+
+	installment.InstallmentStatusID = 2
+	updated, err := repo.UpdateInstallmentByID(ctx, installment)
+	require.NoError(t, err)
+	require.NotNil(t, updated.InstallmentStatus)
+	assert.Equal(t, "paid", updated.InstallmentStatus.Name)
+	assert.NotNil(t, updated.Lending)
+}
+
+func TestCreateInstallments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 2 })
+
+	var installments []*models.Installment
+	for i := 1; i <= lending.Duration; i++ {
+		installments = append(installments, &models.Installment{
+			InstallmentID:       uuid.New(),
+			LendingID:           lending.LendingID,
+			InstallmentStatusID: 1,
+			Amount:              lending.Amount / float64(lending.Duration),
+			DueDate:             time.Now().AddDate(0, i, 0),
+		})
+	}
+
+	found, err := repo.CreateInstallments(ctx, lending.LendingID.String(), installments)
+	require.NoError(t, err)
+	require.NotNil(t, found.Installments)
+	require.Len(t, *found.Installments, lending.Duration)
+	assert.Equal(t, "on progress", (*found.Installments)[0].InstallmentStatus.Name)
+}
+
+func TestCreateVoucher(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	loanProduct := modelstest.CreateLoanProduct(t, testDB)
+	now := time.Now()
+
+	voucher := &models.Voucher{
+		VoucherID:             uuid.New(),
+		Name:                  "Potongan Akhir Tahun",
+		VoucherStatusID:       1,
+		VoucherDiscountTypeID: 2,
+		DiscountAmount:        50000,
+		DiscountQuota:         10,
+		ActiveDate:            now.AddDate(0, 0, 1),
+		ExpireDate:            now.AddDate(0, 1, 0),
+		CreditHealths:         []models.CreditHealthType{{CreditHealthID: 1, Name: "not written"}},
+		LoanProducts:          []models.LoanProduct{{LoanProductID: loanProduct.LoanProductID}},
+	}
+	_, err := repo.CreateVoucher(ctx, voucher)
+	require.NoError(t, err)
+
+	found, err := repo.GetVoucherByID(ctx, voucher.VoucherID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled", found.VoucherStatus.Name)
+	assert.Equal(t, "fixed amount", found.VoucherDiscountType.Name)
+	require.Len(t, found.CreditHealths, 1)
+	assert.NotEqual(t, "not written", found.CreditHealths[0].Name)
+	assert.Len(t, found.LoanProducts, 1)
+
+	_, err = repo.CreateVoucher(ctx, &models.Voucher{
+		VoucherID:             uuid.New(),
+		Name:                  "Backwards",
+		VoucherStatusID:       1,
+		VoucherDiscountTypeID: 1,
+		DiscountQuota:         1,
+		ActiveDate:            now,
+		ExpireDate:            now.AddDate(0, 0, -1),
+	})
+	assert.EqualError(t, err, response.ExpireDateBeforeActiveDate)
+}
+
+func TestVoucherStatuses(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	active := modelstest.CreateVoucher(t, testDB)
+	scheduled := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.VoucherStatusID = 1 })
+	modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+		v.VoucherStatusID = 5
+		v.ArchivedAt = ptr(time.Now())
+	})
+
+	vouchers, err := repo.GetUnarchivedVouchers(ctx)
+	require.NoError(t, err)
+	require.Len(t, vouchers, 2)
+	assert.ElementsMatch(t, []uuid.UUID{active.VoucherID, scheduled.VoucherID}, []uuid.UUID{vouchers[0].VoucherID, vouchers[1].VoucherID})
+
+	active.VoucherStatusID = 5
+	active.ArchivedAt = ptr(time.Now())
+	require.NoError(t, repo.UpdateVoucherStatus(ctx, active))
+
+	found, err := repo.GetVoucherByID(ctx, active.VoucherID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "archived", found.VoucherStatus.Name)
+	assert.NotNil(t, found.ArchivedAt)
+
+	vouchers, err = repo.GetUnarchivedVouchers(ctx)
+	require.NoError(t, err)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, scheduled.VoucherID, vouchers[0].VoucherID)
+}
+
+func TestGetVoucherRedemptionStats(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	popular := modelstest.CreateVoucher(t, testDB)
+	rare := modelstest.CreateVoucher(t, testDB)
+	unused := modelstest.CreateVoucher(t, testDB)
+	debtor := modelstest.CreateDebtor(t, testDB)
+
+	redeem := func(voucher *models.Voucher, discount, fineWaiver float64) {
+		payment := modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.VoucherID = &voucher.VoucherID
+			p.PaymentDiscount = discount
+			p.PaymentFineWaiver = fineWaiver
+		})
+		modelstest.CreateVoucherRedemption(t, testDB, payment, debtor)
+	}
+	redeem(popular, 100000, 20000)
+	redeem(popular, 50000, 0)
+	redeem(rare, 0, 15000)
+
+	stats, err := repo.GetVoucherRedemptionStats(ctx, []string{popular.VoucherID.String(), rare.VoucherID.String(), unused.VoucherID.String()})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	byVoucher := map[uuid.UUID]*models.VoucherRedemptionStats{}
+	for _, stat := range stats {
+		byVoucher[stat.VoucherID] = stat
+	}
+	require.Contains(t, byVoucher, popular.VoucherID)
+	assert.EqualValues(t, 2, byVoucher[popular.VoucherID].Redemptions)
+	assert.Equal(t, 170000.0, byVoucher[popular.VoucherID].DiscountGiven)
+	require.Contains(t, byVoucher, rare.VoucherID)
+	assert.EqualValues(t, 1, byVoucher[rare.VoucherID].Redemptions)
+	assert.Equal(t, 15000.0, byVoucher[rare.VoucherID].DiscountGiven)
+
+	stats, err = repo.GetVoucherRedemptionStats(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestGetLoanByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	lending := modelstest.CreateLending(t, testDB)
+	late := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = now.AddDate(0, 2, 0)
+	})
+	early := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = now.AddDate(0, 1, 0)
+	})
+	guarantor := modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = lending.LendingID
+		g.GuarantorStatusID = 2
+	})
+	require.NoError(t, repo.DeleteLoanProduct(ctx, &models.LoanProduct{LoanProductID: lending.LoanProductID}))
+
+	found, err := repo.GetLoanByID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.LoanProduct)
+	assert.Equal(t, lending.LoanProductID, found.LoanProduct.LoanProductID)
+	require.NotNil(t, found.Debtor)
+	assert.NotNil(t, found.Debtor.User)
+	assert.NotNil(t, found.Debtor.CreditHealth)
+	assert.NotNil(t, found.LendingStatus)
+
+	require.NotNil(t, found.Installments)
+	require.Len(t, *found.Installments, 2)
+	assert.Equal(t, early.InstallmentID, (*found.Installments)[0].InstallmentID)
+	assert.Equal(t, late.InstallmentID, (*found.Installments)[1].InstallmentID)
+	assert.NotNil(t, (*found.Installments)[0].InstallmentStatus)
+
+	require.NotNil(t, found.Guarantors)
+	require.Len(t, *found.Guarantors, 1)
+	assert.Equal(t, guarantor.GuarantorID, (*found.Guarantors)[0].GuarantorID)
+	require.NotNil(t, (*found.Guarantors)[0].User)
+	assert.Equal(t, guarantor.UserID, (*found.Guarantors)[0].User.UserID)
+	require.NotNil(t, (*found.Guarantors)[0].GuarantorStatus)
+	assert.Equal(t, "accepted", (*found.Guarantors)[0].GuarantorStatus.Name)
+
+	_, err = repo.GetLoanByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestDashboardTotals(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	userTotal, err := repo.GetUserTotal(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, userTotal)
+	lendingAmount, err := repo.GetLendingAmount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, lendingAmount)
+	returnAmount, err := repo.GetReturnAmount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, returnAmount)
+	writeOffAmount, err := repo.GetWriteOffAmount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, writeOffAmount)
+
+	debtor := modelstest.CreateDebtor(t, testDB)
+	modelstest.CreateDebtor(t, testDB)
+	lending := func(status int, amount float64) *models.Lending {
+		return modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.DebtorID = debtor.DebtorID
+			l.LendingStatusID = status
+			l.Amount = amount
+		})
+	}
+	lending(1, 1000000)
+	approved := lending(2, 2000000)
+	lending(3, 3000000)
+	lending(4, 4000000)
+	lending(5, 5000000)
+	writtenOff := lending(6, 6000000)
+	lending(7, 7000000)
+
+	installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = approved.LendingID })
+	modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+		p.InstallmentID = installment.InstallmentID
+		p.PaymentAmount = 600000
+		p.PaymentDiscount = 100000
+	})
+	modelstest.CreatePayment(t, testDB, func(p *models.Payment) { p.PaymentAmount = 300000 })
+	reversed := modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+		p.InstallmentID = installment.InstallmentID
+		p.PaymentAmount = 800000
+	})
+	modelstest.CreatePaymentReversal(t, testDB, reversed)
+	modelstest.CreateWriteOff(t, testDB, func(w *models.WriteOff) {
+		w.LendingID = writtenOff.LendingID
+		w.PrincipalLoss = 4500000
+		w.FineLoss = 250000
+	})
+
+	userTotal, err = repo.GetUserTotal(ctx)
+	require.NoError(t, err)
+	// CreatePayment and CreateWriteOff add a debtor for their own lendings.
+	assert.EqualValues(t, 3, userTotal)
+
+	lendingTotal, err := repo.GetLendingTotal(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, lendingTotal)
+
+	lendingAmount, err = repo.GetLendingAmount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2000000.0+3000000+4000000+3000000, lendingAmount)
+
+	returnAmount, err = repo.GetReturnAmount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000.0, returnAmount)
+
+	writeOffAmount, err = repo.GetWriteOffAmount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4500000.0, writeOffAmount)
+}
+
+func TestRestructurings(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	adminUser := createAdmin(t)
+	now := time.Now()
+
+	older := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) {
+		r.LendingID = lending.LendingID
+		r.RestructuringStatusID = 3
+		r.CreatedAt = now.Add(-time.Hour)
+	})
+	superseded := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.InstallmentStatusID = 3
+		i.SupersededByID = &older.RestructuringID
+	})
+
+	restructuring, err := repo.CreateRestructuring(ctx, &models.Restructuring{
+		RestructuringID:       uuid.New(),
+		LendingID:             lending.LendingID,
+		RestructuringStatusID: 1,
+		UserID:                adminUser.UserID,
+		Version:               2,
+		Tenor:                 6,
+		CapitalizeFines:       true,
+		OutstandingAmount:     2000000,
+		CapitalizedFine:       100000,
+		TotalAmount:           2100000,
+		InstallmentAmount:     350000,
+		FirstDueDate:          now.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	replacement := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.RestructuringID = &restructuring.RestructuringID
+	})
+	modelstest.CreateRestructuring(t, testDB)
+
+	restructurings, err := repo.GetRestructuringsByLendingID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	require.Len(t, restructurings, 2)
+	assert.Equal(t, restructuring.RestructuringID, restructurings[0].RestructuringID)
+	assert.Equal(t, older.RestructuringID, restructurings[1].RestructuringID)
+	require.NotNil(t, restructurings[0].RestructuringStatus)
+	require.NotNil(t, restructurings[0].Installments)
+	require.Len(t, *restructurings[0].Installments, 1)
+	assert.Equal(t, replacement.InstallmentID, (*restructurings[0].Installments)[0].InstallmentID)
+	require.NotNil(t, restructurings[1].SupersededInstallments)
+	require.Len(t, *restructurings[1].SupersededInstallments, 1)
+	assert.Equal(t, superseded.InstallmentID, (*restructurings[1].SupersededInstallments)[0].InstallmentID)
+
+	_, err = repo.CreateRestructuring(ctx, &models.Restructuring{
+		RestructuringID:       uuid.New(),
+		LendingID:             lending.LendingID,
+		RestructuringStatusID: 1,
+		UserID:                adminUser.UserID,
+		Version:               3,
+		Tenor:                 0,
+		FirstDueDate:          now,
+	})
+	assert.EqualError(t, err, response.DataConstraintViolation)
+}
+
+func TestCreateWriteOff(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 6 })
+	adminUser := createAdmin(t)
+
+	writeOff := func() *models.WriteOff {
+		return &models.WriteOff{WriteOffID: uuid.New(), LendingID: lending.LendingID, UserID: adminUser.UserID, Reason: "Unreachable", PrincipalLoss: 1000000}
+	}
+	_, err := repo.CreateWriteOff(ctx, writeOff())
+	require.NoError(t, err)
+
+	_, err = repo.CreateWriteOff(ctx, writeOff())
+	assert.EqualError(t, err, response.DataAlreadyExist)
+}
+
+func TestActionLists(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	pending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 1 })
+	modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 2 })
+
+	loans, err := repo.GetLendingAction(ctx)
+	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	assert.Equal(t, pending.LendingID, loans[0].LendingID)
+	assert.NotNil(t, loans[0].Debtor)
+	assert.NotNil(t, loans[0].LoanProduct)
+	assert.NotNil(t, loans[0].LendingStatus)
+
+	// Both lendings above created debtors with confirmed contracts.
+	inDelivery := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.ContractTrackingID = 3 })
+	modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.ContractTrackingID = 4 })
+
+	debtors, err := repo.GetUserAction(ctx)
+	require.NoError(t, err)
+	require.Len(t, debtors, 1)
+	assert.Equal(t, inDelivery.DebtorID, debtors[0].DebtorID)
+	assert.NotNil(t, debtors[0].User)
+	assert.NotNil(t, debtors[0].ContractTracking)
+}
+
+func TestGetLoans(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+
+	create := func(name string, status int, offset time.Duration) *models.Lending {
+		return modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.Name = name
+			l.LendingStatusID = status
+			l.CreatedAt = base.Add(offset)
+		})
+	}
+	first := create("Modal Warung", 3, 0)
+	second := create("modal usaha", 2, time.Minute)
+	create("MODAL Toko", 5, 2*time.Minute)
+	create("Renovasi", 3, 3*time.Minute)
+	modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = first.LendingID })
+
+	pagination, err := repo.GetLoans(ctx, "modal", []int{2, 3}, page(1, 1, "created_at asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	loans := pagination.Rows.([]*models.Lending)
+	require.Len(t, loans, 1)
+	assert.Equal(t, first.LendingID, loans[0].LendingID)
+	require.NotNil(t, loans[0].Debtor)
+	assert.NotNil(t, loans[0].Debtor.User)
+	assert.NotNil(t, loans[0].LoanProduct)
+	require.NotNil(t, loans[0].Installments)
+	require.Len(t, *loans[0].Installments, 1)
+	assert.NotNil(t, (*loans[0].Installments)[0].InstallmentStatus)
+
+	pagination, err = repo.GetLoans(ctx, "modal", []int{2, 3}, page(1, 2, "created_at asc"))
+	require.NoError(t, err)
+	loans = pagination.Rows.([]*models.Lending)
+	require.Len(t, loans, 1)
+	assert.Equal(t, second.LendingID, loans[0].LendingID)
+
+	pagination, err = repo.GetLoans(ctx, "", []int{1, 2, 3, 4, 5, 6, 7}, page(10, 1, "amount desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pagination.TotalRows)
+	assert.Equal(t, 1, pagination.TotalPages)
+}
+
+func TestGetVouchers(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	create := func(name string, status int, expireIn int) *models.Voucher {
+		return modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+			v.Name = name
+			v.VoucherStatusID = status
+			v.ExpireDate = now.AddDate(0, 0, expireIn)
+			v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}}
+		})
+	}
+	soon := create("Diskon Merdeka", 2, 5)
+	later := create("diskon tahun baru", 3, 10)
+	create("DISKON Arsip", 5, 15)
+	create("Cashback", 2, 20)
+
+	pagination, err := repo.GetVouchers(ctx, "diskon", []int{1, 2, 3, 4}, page(1, 1, "expire_date asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	vouchers := pagination.Rows.([]*models.Voucher)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, soon.VoucherID, vouchers[0].VoucherID)
+	require.NotNil(t, vouchers[0].VoucherStatus)
+	assert.Equal(t, "active", vouchers[0].VoucherStatus.Name)
+	assert.NotNil(t, vouchers[0].VoucherDiscountType)
+	assert.Len(t, vouchers[0].CreditHealths, 1)
+
+	pagination, err = repo.GetVouchers(ctx, "diskon", []int{1, 2, 3, 4}, page(1, 2, "expire_date asc"))
+	require.NoError(t, err)
+	vouchers = pagination.Rows.([]*models.Voucher)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, later.VoucherID, vouchers[0].VoucherID)
+	assert.Equal(t, "exhausted", vouchers[0].VoucherStatus.Name)
+
+	pagination, err = repo.GetVouchers(ctx, "", []int{5}, page(10, 1, "created_at desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pagination.TotalRows)
+}
+
+func TestGetLoanProducts(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	seeded, err := repo.GetLoanProducts(ctx, "", page(100, 1, "created_at asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, seeded.TotalRows)
+
+	create := func(name string, duration int) *models.LoanProduct {
+		return modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+			l.Name = name
+			l.Duration = duration
+			l.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}, {CreditHealthID: 2}}
+		})
+	}
+	short := create("Kredit Kilat", 2)
+	long := create("KREDIT Panjang", 9)
+	deleted := create("kredit lama", 4)
+	require.NoError(t, repo.DeleteLoanProduct(ctx, deleted))
+
+	pagination, err := repo.GetLoanProducts(ctx, "kredit", page(1, 1, "duration desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	loanProducts := pagination.Rows.([]*models.LoanProduct)
+	require.Len(t, loanProducts, 1)
+	assert.Equal(t, long.LoanProductID, loanProducts[0].LoanProductID)
+	assert.Len(t, loanProducts[0].CreditHealths, 2)
+
+	pagination, err = repo.GetLoanProducts(ctx, "kredit", page(1, 2, "duration desc"))
+	require.NoError(t, err)
+	loanProducts = pagination.Rows.([]*models.LoanProduct)
+	require.Len(t, loanProducts, 1)
+	assert.Equal(t, short.LoanProductID, loanProducts[0].LoanProductID)
+}
+
+func TestLoanProductLifecycle(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	loanProduct := &models.LoanProduct{
+		LoanProductID: uuid.New(),
+		Name:          "Kredit Pendidikan",
+		Duration:      12,
+		Percentage:    110,
+		FinePerDay:    2500,
+		MinAmount:     2000000,
+		MaxAmount:     20000000,
+		ActiveDate:    now,
+		ExpireDate:    now.AddDate(1, 0, 0),
+		CreditHealths: []models.CreditHealthType{{CreditHealthID: 1}},
+	}
+	_, err := repo.CreateLoanProduct(ctx, loanProduct)
+	require.NoError(t, err)
+
+	found, err := repo.GetLoanProductByID(ctx, loanProduct.LoanProductID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Kredit Pendidikan", found.Name)
+	require.Len(t, found.CreditHealths, 1)
+	assert.Equal(t, "good", found.CreditHealths[0].Name)
+
+	loanProduct.MaxAmount = 30000000
+	loanProduct.CreditHealths = []models.CreditHealthType{{CreditHealthID: 2}, {CreditHealthID: 3}}
+	updated, err := repo.UpdateLoanProduct(ctx, loanProduct)
+	require.NoError(t, err)
+	assert.Equal(t, 30000000.0, updated.MaxAmount)
+	assert.ElementsMatch(t, []int{2, 3}, []int{updated.CreditHealths[0].CreditHealthID, updated.CreditHealths[1].CreditHealthID})
+
+	loanProduct.MinAmount = loanProduct.MaxAmount + 1
+	_, err = repo.UpdateLoanProduct(ctx, loanProduct)
+	assert.EqualError(t, err, response.LoanProductAmountNotValid)
+
+	byID, err := repo.GetLoanProductsByID(ctx, []string{loanProduct.LoanProductID.String(), uuid.NewString()})
+	require.NoError(t, err)
+	require.Len(t, byID, 1)
+	assert.Equal(t, 30000000.0, byID[0].MaxAmount)
+
+	require.NoError(t, repo.DeleteLoanProduct(ctx, loanProduct))
+	_, err = repo.GetLoanProductByID(ctx, loanProduct.LoanProductID.String())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	var stored models.LoanProduct
+	require.NoError(t, testDB.Unscoped().Where("loan_product_id = ?", loanProduct.LoanProductID).First(&stored).Error)
+	assert.True(t, stored.DeletedAt.Valid)
+
+	byID, err = repo.GetLoanProductsByID(ctx, []string{loanProduct.LoanProductID.String()})
+	require.NoError(t, err)
+	assert.Empty(t, byID)
+
+	_, err = repo.CreateLoanProduct(ctx, &models.LoanProduct{
+		LoanProductID: uuid.New(),
+		Name:          "Backwards",
+		Duration:      1,
+		MinAmount:     1,
+		MaxAmount:     2,
+		ActiveDate:    now,
+		ExpireDate:    now.AddDate(0, 0, -1),
+	})
+	assert.EqualError(t, err, response.ExpireDateBeforeActiveDate)
+}
+
+func TestGetPayments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB)
+	now := time.Now()
+
+	pay := func(name string, paidAt time.Time, amount float64) *models.Payment {
+		lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.Name = name })
+		installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.InstallmentStatusID = 2
+		})
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentDate = paidAt
+			p.PaymentAmount = amount
+		})
+	}
+	older := pay("Modal Warung", now.AddDate(0, 0, -2), 700000)
+	newer := pay("modal usaha", now.AddDate(0, 0, -1), 900000)
+	pay("Renovasi", now, 800000)
+
+	reversed := modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+		p.PaymentDate = now.AddDate(0, 0, -3)
+		p.PaymentAmount = 600000
+		p.VoucherID = &voucher.VoucherID
+		p.PaymentDiscount = 50000
+	})
+	require.NoError(t, testDB.Model(&models.Lending{}).
+		Where("lending_id = (SELECT lending_id FROM installments WHERE installment_id = ?)", reversed.InstallmentID).
+		Update("name", "MODAL Toko").Error)
+	reversal := modelstest.CreatePaymentReversal(t, testDB, reversed)
+
+	pagination, err := repo.GetPayments(ctx, "modal", page(2, 1, "payment_date desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	payments := pagination.Rows.([]*models.Payment)
+	require.Len(t, payments, 2)
+	assert.Equal(t, newer.PaymentID, payments[0].PaymentID)
+	assert.Equal(t, older.PaymentID, payments[1].PaymentID)
+	assert.Nil(t, payments[0].Reversal)
+	require.NotNil(t, payments[0].Installment)
+	require.NotNil(t, payments[0].Installment.Lending)
+	assert.Equal(t, "modal usaha", payments[0].Installment.Lending.Name)
+	assert.NotNil(t, payments[0].Installment.InstallmentStatus)
+
+	pagination, err = repo.GetPayments(ctx, "modal", page(2, 2, "payment_date desc"))
+	require.NoError(t, err)
+	payments = pagination.Rows.([]*models.Payment)
+	require.Len(t, payments, 1)
+	assert.Equal(t, reversed.PaymentID, payments[0].PaymentID)
+	require.NotNil(t, payments[0].Reversal)
+	assert.Equal(t, reversal.PaymentReversalID, payments[0].Reversal.PaymentReversalID)
+	require.NotNil(t, payments[0].Voucher)
+	assert.NotNil(t, payments[0].Voucher.VoucherDiscountType)
+
+	pagination, err = repo.GetPayments(ctx, "", page(10, 1, "payment_amount asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pagination.TotalRows)
+	assert.Equal(t, reversed.PaymentID, pagination.Rows.([]*models.Payment)[0].PaymentID)
+}
+
+func TestStatementImports(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	statementImport, err := repo.CreateStatementImport(ctx, &models.StatementImport{
+		StatementImportID: uuid.New(),
+		FileName:          "bca-march.csv",
+		Format:            "csv",
+		TotalLines:        2,
+		Items:             &[]models.ReconciliationItem{{ReconciliationItemID: uuid.New()}},
+	})
+	require.NoError(t, err)
+
+	second := modelstest.CreateReconciliationItem(t, testDB, func(r *models.ReconciliationItem) {
+		r.StatementImportID = statementImport.StatementImportID
+		r.LineNumber = 2
+		r.ReconciliationStatusID = 3
+	})
+	first := modelstest.CreateReconciliationItem(t, testDB, func(r *models.ReconciliationItem) {
+		r.StatementImportID = statementImport.StatementImportID
+		r.LineNumber = 1
+	})
+
+	statementImport.MatchedLines = 1
+	statementImport.UnmatchedLines = 1
+	_, err = repo.UpdateStatementImport(ctx, statementImport)
+	require.NoError(t, err)
+
+	found, err := repo.GetStatementImportByID(ctx, statementImport.StatementImportID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.MatchedLines)
+	assert.Equal(t, 1, found.UnmatchedLines)
+	require.NotNil(t, found.Items)
+	require.Len(t, *found.Items, 2)
+	assert.Equal(t, first.ReconciliationItemID, (*found.Items)[0].ReconciliationItemID)
+	assert.Equal(t, second.ReconciliationItemID, (*found.Items)[1].ReconciliationItemID)
+	require.NotNil(t, (*found.Items)[1].ReconciliationStatus)
+	assert.Equal(t, "matched", (*found.Items)[1].ReconciliationStatus.Name)
+
+	_, err = repo.CreateStatementImport(ctx, &models.StatementImport{StatementImportID: uuid.New(), FileName: "statement.pdf", Format: "pdf"})
+	assert.EqualError(t, err, response.DataConstraintViolation)
+
+	_, err = repo.GetStatementImportByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestReconciliationItems(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	statementImport := modelstest.CreateStatementImport(t, testDB)
+	intent := modelstest.CreatePaymentIntent(t, testDB)
+	base := time.Now().Add(-time.Hour)
+
+	item, err := repo.CreateReconciliationItem(ctx, &models.ReconciliationItem{
+		ReconciliationItemID:   uuid.New(),
+		StatementImportID:      statementImport.StatementImportID,
+		ReconciliationStatusID: 1,
+		LineNumber:             1,
+		LineHash:               "hash-1",
+		TransactionDate:        time.Now(),
+		Reference:              intent.AccountNumber,
+		Description:            "Transfer",
+		Amount:                 intent.PaymentAmount,
+		CreatedAt:              base,
+	})
+	require.NoError(t, err)
+
+	exist, err := repo.CheckReconciliationLineExist(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = repo.CheckReconciliationLineExist(ctx, "hash-2")
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	_, err = repo.CreateReconciliationItem(ctx, &models.ReconciliationItem{
+		ReconciliationItemID:   uuid.New(),
+		StatementImportID:      statementImport.StatementImportID,
+		ReconciliationStatusID: 1,
+		LineNumber:             2,
+		LineHash:               "hash-1",
+		TransactionDate:        time.Now(),
+	})
+	assert.EqualError(t, err, response.DataAlreadyExist)
+
+	item.ReconciliationStatusID = 4
+	item.PaymentIntentID = &intent.PaymentIntentID
+	item.Note = "Matched by hand"
+	item.ResolvedAt = ptr(time.Now())
+	updated, err := repo.UpdateReconciliationItem(ctx, item)
+	require.NoError(t, err)
+	require.NotNil(t, updated.ReconciliationStatus)
+	assert.Equal(t, "resolved", updated.ReconciliationStatus.Name)
+	require.NotNil(t, updated.PaymentIntent)
+	assert.Equal(t, intent.PaymentIntentID, updated.PaymentIntent.PaymentIntentID)
+	assert.Nil(t, updated.Payment)
+
+	_, err = repo.GetReconciliationItemByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	err = repo.Transaction(ctx, func(tx admin.Repository) error {
+		locked, err := tx.GetReconciliationItemForUpdate(ctx, item.ReconciliationItemID.String())
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 4, locked.ReconciliationStatusID)
+		assert.Nil(t, locked.ReconciliationStatus)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetReconciliationItemForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	unmatched := modelstest.CreateReconciliationItem(t, testDB, func(r *models.ReconciliationItem) { r.CreatedAt = base.Add(time.Minute) })
+	ambiguous := modelstest.CreateReconciliationItem(t, testDB, func(r *models.ReconciliationItem) {
+		r.ReconciliationStatusID = 2
+		r.CreatedAt = base.Add(2 * time.Minute)
+	})
+
+	pagination, err := repo.GetReconciliationItems(ctx, []int{1, 2}, page(1, 1, "created_at desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	items := pagination.Rows.([]*models.ReconciliationItem)
+	require.Len(t, items, 1)
+	assert.Equal(t, ambiguous.ReconciliationItemID, items[0].ReconciliationItemID)
+	assert.NotNil(t, items[0].ReconciliationStatus)
+
+	pagination, err = repo.GetReconciliationItems(ctx, []int{1, 2}, page(1, 2, "created_at desc"))
+	require.NoError(t, err)
+	items = pagination.Rows.([]*models.ReconciliationItem)
+	require.Len(t, items, 1)
+	assert.Equal(t, unmatched.ReconciliationItemID, items[0].ReconciliationItemID)
+}
+
+func TestPaymentIntents(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB)
+
+	intent, err := repo.CreatePaymentIntent(ctx, &models.PaymentIntent{
+		PaymentIntentID:       uuid.New(),
+		InstallmentID:         installment.InstallmentID,
+		PaymentIntentStatusID: 1,
+		Provider:              "xendit",
+		Channel:               "BRI",
+		ExternalID:            "inv-open",
+		AccountNumber:         "8808001",
+		PaymentAmount:         installment.Amount,
+		ExpireDate:            time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	expired := modelstest.CreatePaymentIntent(t, testDB, func(p *models.PaymentIntent) {
+		p.PaymentIntentStatusID = 3
+		p.AccountNumber = "8808002"
+	})
+	modelstest.CreatePaymentIntent(t, testDB, func(p *models.PaymentIntent) {
+		p.PaymentIntentStatusID = 2
+		p.ExternalID = "inv-paid"
+	})
+
+	found, err := repo.GetPaymentIntentByID(ctx, intent.PaymentIntentID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.PaymentIntentStatus)
+	assert.Equal(t, 1, found.PaymentIntentStatus.PaymentIntentStatusID)
+	_, err = repo.GetPaymentIntentByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	intents, err := repo.GetOpenPaymentIntentsByReference(ctx, []string{"inv-open", "8808002", "inv-paid", "unknown"})
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.ElementsMatch(t, []uuid.UUID{intent.PaymentIntentID, expired.PaymentIntentID}, []uuid.UUID{intents[0].PaymentIntentID, intents[1].PaymentIntentID})
+
+	intents, err = repo.GetOpenPaymentIntentsByReference(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+}
+
+func TestGetOpenInstallmentsByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	open := modelstest.CreateInstallment(t, testDB)
+	paid := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.InstallmentStatusID = 2 })
+	modelstest.CreateInstallment(t, testDB)
+
+	installments, err := repo.GetOpenInstallmentsByID(ctx, []string{open.InstallmentID.String(), paid.InstallmentID.String()})
+	require.NoError(t, err)
+	require.Len(t, installments, 1)
+	assert.Equal(t, open.InstallmentID, installments[0].InstallmentID)
+	require.NotNil(t, installments[0].Lending)
+	assert.Equal(t, open.LendingID, installments[0].Lending.LendingID)
+
+	installments, err = repo.GetOpenInstallmentsByID(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, installments)
+}
+
+func TestGetScorableDebtors(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	confirmed := modelstest.CreateDebtor(t, testDB)
+	modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.ContractTrackingID = 4 })
+
+	debtors, err := repo.GetScorableDebtors(ctx)
+	require.NoError(t, err)
+	require.Len(t, debtors, 1)
+	assert.Equal(t, confirmed.DebtorID, debtors[0].DebtorID)
+}
+
+func TestGetSettledPaymentsByDebtorID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.DebtorID = debtor.DebtorID })
+	now := time.Now()
+
+	pay := func(paidAt time.Time) *models.Payment {
+		installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.InstallmentStatusID = 2
+		})
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentDate = paidAt
+		})
+	}
+	later := pay(now.AddDate(0, 0, -1))
+	earlier := pay(now.AddDate(0, 0, -20))
+	modelstest.CreatePaymentReversal(t, testDB, pay(now.AddDate(0, 0, -10)))
+	modelstest.CreatePayment(t, testDB)
+
+	payments, err := repo.GetSettledPaymentsByDebtorID(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	require.Len(t, payments, 2)
+	assert.Equal(t, earlier.PaymentID, payments[0].PaymentID)
+	assert.Equal(t, later.PaymentID, payments[1].PaymentID)
+	require.NotNil(t, payments[0].Installment)
+	assert.Equal(t, earlier.InstallmentID, payments[0].Installment.InstallmentID)
+}
+
+func TestCreditLimitProposals(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	base := time.Now().Add(-time.Hour)
+
+	pending, err := repo.CheckPendingCreditLimitProposal(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	proposal, err := repo.CreateCreditLimitProposal(ctx, &models.CreditLimitProposal{
+		CreditLimitProposalID:       uuid.New(),
+		DebtorID:                    debtor.DebtorID,
+		CreditLimitProposalStatusID: 1,
+		RulesVersion:                "v1",
+		Score:                       700,
+		CurrentLimit:                debtor.CreditLimit,
+		ProposedLimit:               debtor.CreditLimit * 1.2,
+		CreatedAt:                   base,
+	})
+	require.NoError(t, err)
+
+	pending, err = repo.CheckPendingCreditLimitProposal(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.True(t, pending)
+
+	newer := modelstest.CreateCreditLimitProposal(t, testDB, func(p *models.CreditLimitProposal) { p.CreatedAt = base.Add(time.Minute) })
+	modelstest.CreateCreditLimitProposal(t, testDB, func(p *models.CreditLimitProposal) { p.CreditLimitProposalStatusID = 3 })
+
+	pagination, err := repo.GetCreditLimitProposals(ctx, []int{1}, page(1, 1, "created_at desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	proposals := pagination.Rows.([]*models.CreditLimitProposal)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, newer.CreditLimitProposalID, proposals[0].CreditLimitProposalID)
+	require.NotNil(t, proposals[0].Debtor)
+	assert.NotNil(t, proposals[0].Debtor.User)
+	require.NotNil(t, proposals[0].CreditLimitProposalStatus)
+	assert.Equal(t, "pending", proposals[0].CreditLimitProposalStatus.Name)
+
+	adminUser := createAdmin(t)
+	err = repo.Transaction(ctx, func(tx admin.Repository) error {
+		locked, err := tx.GetCreditLimitProposalForUpdate(ctx, proposal.CreditLimitProposalID.String())
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, locked.Debtor)
+
+		locked.CreditLimitProposalStatusID = 2
+		locked.UserID = &adminUser.UserID
+		locked.RespondedAt = ptr(time.Now())
+		updated, err := tx.UpdateCreditLimitProposal(ctx, locked)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, "approved", updated.CreditLimitProposalStatus.Name)
+		require.NotNil(t, updated.Debtor)
+		assert.NotNil(t, updated.Debtor.User)
+		return nil
+	})
+	require.NoError(t, err)
+
+	pending, err = repo.CheckPendingCreditLimitProposal(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.False(t, pending)
+
+	_, err = repo.GetCreditLimitProposalForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCreateCreditHealthHistory(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	adminUser := createAdmin(t)
+
+	history, err := repo.CreateCreditHealthHistory(ctx, &models.CreditHealthHistory{
+		CreditHealthHistoryID:  uuid.New(),
+		DebtorID:               debtor.DebtorID,
+		CreditHealthEventID:    3,
+		PreviousCreditHealthID: 1,
+		CreditHealthID:         3,
+		UserID:                 &adminUser.UserID,
+	})
+	require.NoError(t, err)
+
+	var stored models.CreditHealthHistory
+	require.NoError(t, testDB.Where("credit_health_history_id = ?", history.CreditHealthHistoryID).First(&stored).Error)
+	assert.Equal(t, 3, stored.CreditHealthEventID)
+	require.NotNil(t, stored.UserID)
+	assert.Equal(t, adminUser.UserID, *stored.UserID)
+
+	_, err = repo.CreateCreditHealthHistory(ctx, &models.CreditHealthHistory{
+		CreditHealthHistoryID:  uuid.New(),
+		DebtorID:               debtor.DebtorID,
+		CreditHealthEventID:    99,
+		PreviousCreditHealthID: 1,
+		CreditHealthID:         1,
+	})
+	assert.EqualError(t, err, response.ReferencedDataNotExist)
+}
+
+func TestKycSubmissions(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+
+	older := modelstest.CreateKycSubmission(t, testDB, func(k *models.KycSubmission) { k.CreatedAt = base })
+	newer := modelstest.CreateKycSubmission(t, testDB, func(k *models.KycSubmission) { k.CreatedAt = base.Add(time.Minute) })
+	modelstest.CreateKycSubmission(t, testDB, func(k *models.KycSubmission) { k.KycStatusID = 3 })
+
+	pagination, err := repo.GetKycSubmissions(ctx, []int{2}, page(1, 1, "created_at asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	submissions := pagination.Rows.([]*models.KycSubmission)
+	require.Len(t, submissions, 1)
+	assert.Equal(t, older.KycSubmissionID, submissions[0].KycSubmissionID)
+	require.NotNil(t, submissions[0].Debtor)
+	assert.NotNil(t, submissions[0].Debtor.User)
+	require.NotNil(t, submissions[0].KycStatus)
+	assert.Equal(t, "pending", submissions[0].KycStatus.Name)
+
+	adminUser := createAdmin(t)
+	err = repo.Transaction(ctx, func(tx admin.Repository) error {
+		locked, err := tx.GetKycSubmissionForUpdate(ctx, newer.KycSubmissionID.String())
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, locked.Debtor)
+
+		locked.KycStatusID = 4
+		locked.RejectReason = "ID card expired"
+		locked.UserID = &adminUser.UserID
+		locked.ReviewedAt = ptr(time.Now())
+		_, err = tx.UpdateKycSubmission(ctx, locked)
+		return err
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetKycSubmissionByID(ctx, newer.KycSubmissionID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", found.KycStatus.Name)
+	assert.Equal(t, "ID card expired", found.RejectReason)
+	assert.NotNil(t, found.Debtor.User)
+
+	_, err = repo.GetKycSubmissionByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetKycSubmissionForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRowLocks(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+
+	err := repo.Transaction(ctx, func(tx admin.Repository) error {
+		locked, err := tx.GetLendingForUpdate(ctx, lending.LendingID.String())
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, lending.Name, locked.Name)
+		assert.Nil(t, locked.Debtor)
+
+		debtor, err := tx.GetDebtorForUpdate(ctx, lending.DebtorID.String())
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, lending.DebtorID, debtor.DebtorID)
+		assert.Nil(t, debtor.User)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetLendingForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetDebtorForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestRejectionReasons(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	reasons, err := repo.GetRejectionReasons(ctx)
+	require.NoError(t, err)
+	require.Len(t, reasons, 5)
+	assert.Equal(t, "insufficient_income", reasons[0].Code)
+	assert.Equal(t, "other", reasons[4].Code)
+
+	exist, err := repo.CheckRejectionReasonCodeExist(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, exist)
+	exist, err = repo.CheckRejectionReasonCodeExist(ctx, "fraud")
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	reason, err := repo.CreateRejectionReason(ctx, &models.RejectionReason{Code: "fraud", Name: "Suspected fraud", IsActive: true})
+	require.NoError(t, err)
+	assert.Equal(t, 6, reason.RejectionReasonID)
+
+	reason.Name = "Suspected fraud or identity theft"
+	reason.IsActive = false
+	_, err = repo.UpdateRejectionReason(ctx, reason)
+	require.NoError(t, err)
+
+	found, err := repo.GetRejectionReasonByID(ctx, reason.RejectionReasonID)
+	require.NoError(t, err)
+	assert.Equal(t, "Suspected fraud or identity theft", found.Name)
+	assert.False(t, found.IsActive)
+
+	_, err = repo.GetRejectionReasonByID(ctx, 99)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	_, err = repo.CreateRejectionReason(ctx, &models.RejectionReason{Code: "other", Name: "Duplicate"})
+	assert.EqualError(t, err, response.RejectionReasonCodeExist)
+}
+
+func TestGetRejectionReasonCounts(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	reject := func(reasonID int, rejectedAt time.Time) {
+		modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.LendingStatusID = 5
+			l.RejectionReasonID = ptr(reasonID)
+			l.RejectedAt = ptr(rejectedAt)
+		})
+	}
+	reject(1, wib(t, "2024-03-05 12:00"))
+	reject(1, wib(t, "2024-03-15 12:00"))
+	reject(2, wib(t, "2024-03-20 12:00"))
+	reject(2, wib(t, "2024-04-10 12:00"))
+	reject(3, wib(t, "2024-06-10 12:00"))
+	// A cancelled loan keeps its reason but is not a rejection.
+	modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+		l.LendingStatusID = 7
+		l.RejectionReasonID = ptr(1)
+		l.RejectedAt = ptr(wib(t, "2024-03-10 12:00"))
+	})
+
+	counts, err := repo.GetRejectionReasonCounts(ctx, "month", wib(t, "2024-03-01 00:00"), wib(t, "2024-05-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, counts, 3)
+	assert.Equal(t, "insufficient_income", counts[0].Code)
+	assert.EqualValues(t, 2, counts[0].Total)
+	assert.Equal(t, "high_existing_debt", counts[1].Code)
+	assert.EqualValues(t, 1, counts[1].Total)
+	assert.True(t, counts[1].Period.Equal(counts[0].Period))
+	assert.Equal(t, "high_existing_debt", counts[2].Code)
+	assert.True(t, counts[2].Period.After(counts[0].Period))
+}
+
+func TestExportLoans(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB, func(u *models.User) {
+		u.Name = "Budi Santoso"
+		u.Email = "budi@example.com"
+	})
+	debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.UserID = user.UserID })
+	loanProduct := modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) { l.Name = "Kredit Kilat" })
+	base := time.Now().Add(-time.Hour)
+
+	create := func(name string, status int, offset time.Duration) *models.Lending {
+		return modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.DebtorID = debtor.DebtorID
+			l.LoanProductID = loanProduct.LoanProductID
+			l.Name = name
+			l.LendingStatusID = status
+			l.CreatedAt = base.Add(offset)
+		})
+	}
+	first := create("Modal Warung", 3, 0)
+	second := create("modal usaha", 4, time.Minute)
+	create("Modal Toko", 5, 2*time.Minute)
+	create("Renovasi", 3, 3*time.Minute)
+
+	var rows []*models.LoanExport
+	err := repo.ExportLoans(ctx, "modal", []int{3, 4}, "created_at asc", func(row *models.LoanExport) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, first.LendingID, rows[0].LendingID)
+	assert.Equal(t, second.LendingID, rows[1].LendingID)
+	assert.Equal(t, "Budi Santoso", rows[0].DebtorName)
+	assert.Equal(t, "budi@example.com", rows[0].DebtorEmail)
+	assert.Equal(t, "Modal Warung", rows[0].Name)
+	assert.Equal(t, "Kredit Kilat", rows[0].LoanProductName)
+	assert.Equal(t, "on progress", rows[0].LendingStatus)
+	assert.Equal(t, "paid", rows[1].LendingStatus)
+	assert.Equal(t, first.Amount, rows[0].Amount)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = repo.ExportLoans(ctx, "", []int{3, 4, 5}, "created_at desc", func(row *models.LoanExport) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestExportPayments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB, func(u *models.User) { u.Name = "Siti Aminah" })
+	debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.UserID = user.UserID })
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+		l.DebtorID = debtor.DebtorID
+		l.Name = "Modal Warung"
+	})
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.Name = "Diskon Merdeka" })
+	dueDate := wib(t, "2024-03-10 00:00")
+	installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = dueDate
+	})
+	now := time.Now()
+
+	plain := modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+		p.InstallmentID = installment.InstallmentID
+		p.PaymentDate = now.AddDate(0, 0, -2)
+	})
+	discounted := modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+		p.InstallmentID = installment.InstallmentID
+		p.PaymentDate = now.AddDate(0, 0, -1)
+		p.VoucherID = &voucher.VoucherID
+		p.PaymentDiscount = 105000
+		p.PaymentFine = 15000
+		p.PaymentFineWaiver = 15000
+	})
+	reversal := modelstest.CreatePaymentReversal(t, testDB, discounted)
+	modelstest.CreatePayment(t, testDB)
+
+	var rows []*models.PaymentExport
+	err := repo.ExportPayments(ctx, "modal", "payment_date desc", func(row *models.PaymentExport) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, discounted.PaymentID, rows[0].PaymentID)
+	assert.Equal(t, "Siti Aminah", rows[0].DebtorName)
+	assert.Equal(t, "Modal Warung", rows[0].LendingName)
+	assert.True(t, dueDate.Equal(rows[0].InstallmentDue))
+	require.NotNil(t, rows[0].VoucherName)
+	assert.Equal(t, "Diskon Merdeka", *rows[0].VoucherName)
+	assert.Equal(t, 105000.0, rows[0].PaymentDiscount)
+	assert.Equal(t, 15000.0, rows[0].PaymentFineWaiver)
+	require.NotNil(t, rows[0].ReversedAt)
+	assert.WithinDuration(t, reversal.CreatedAt, *rows[0].ReversedAt, time.Millisecond)
+
+	assert.Equal(t, plain.PaymentID, rows[1].PaymentID)
+	assert.Nil(t, rows[1].VoucherName)
+	assert.Nil(t, rows[1].ReversedAt)
+}
+
+func TestExportDebtors(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	create := func(name, email string, creditUsed float64) *models.Debtor {
+		user := modelstest.CreateUser(t, testDB, func(u *models.User) {
+			u.Name = name
+			u.Email = email
+		})
+		return modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) {
+			d.UserID = user.UserID
+			d.CreditUsed = creditUsed
+			d.CreditHealthID = 2
+		})
+	}
+	low := create("Budi Santoso", "santoso@example.com", 1000000)
+	high := create("Siti Aminah", "siti.budi@example.com", 5000000)
+	create("Andi Wijaya", "andi@example.com", 9000000)
+
+	var rows []*models.DebtorExport
+	err := repo.ExportDebtors(ctx, "BUDI", "credit_used desc", func(row *models.DebtorExport) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, high.DebtorID, rows[0].DebtorID)
+	assert.Equal(t, low.DebtorID, rows[1].DebtorID)
+	assert.Equal(t, "Siti Aminah", rows[0].Name)
+	assert.Equal(t, "siti.budi@example.com", rows[0].Email)
+	assert.Equal(t, "081234567890", rows[0].PhoneNumber)
+	assert.Equal(t, "warning", rows[0].CreditHealth)
+	assert.Equal(t, "confirmed contract", rows[0].ContractTracking)
+	assert.Equal(t, 5000000.0, rows[0].CreditUsed)
+	assert.Equal(t, high.CreditLimit, rows[0].CreditLimit)
+}
+
+func TestGetLendingDecisionAggregates(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	create := func(status int, amount float64, createdAt string, decidedAt *time.Time) {
+		modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.LendingStatusID = status
+			l.Amount = amount
+			l.CreatedAt = wib(t, createdAt)
+			if status == 5 {
+				l.RejectedAt = decidedAt
+			} else {
+				l.ApprovedAt = decidedAt
+			}
+		})
+	}
+	create(3, 3000000, "2024-03-10 08:00", ptr(wib(t, "2024-03-10 10:00")))
+	create(4, 2000000, "2024-03-20 09:00", nil)
+	create(5, 5000000, "2024-03-30 09:00", ptr(wib(t, "2024-04-02 09:00")))
+	create(1, 1000000, "2024-03-15 09:00", nil)
+	create(3, 1000000, "2023-12-15 09:00", ptr(wib(t, "2023-12-15 10:00")))
+
+	aggregates, err := repo.GetLendingDecisionAggregates(ctx, "month", wib(t, "2024-01-01 00:00"), wib(t, "2024-07-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+
+	assert.Equal(t, "2024-03-01", aggregates[0].Period.Format("2006-01-02"))
+	assert.EqualValues(t, 2, aggregates[0].Approved)
+	assert.Zero(t, aggregates[0].Rejected)
+	assert.Equal(t, 5000000.0, aggregates[0].Disbursed)
+	assert.Equal(t, 7200.0, aggregates[0].ApprovalSeconds)
+	assert.EqualValues(t, 1, aggregates[0].TimedApprovals)
+
+	assert.Equal(t, "2024-04-01", aggregates[1].Period.Format("2006-01-02"))
+	assert.Zero(t, aggregates[1].Approved)
+	assert.EqualValues(t, 1, aggregates[1].Rejected)
+	assert.Zero(t, aggregates[1].Disbursed)
+}
+
+func TestGetPaymentAggregates(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	pay := func(paidAt string, amount, fine, fineWaiver, discount float64) *models.Payment {
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.PaymentDate = wib(t, paidAt)
+			p.PaymentAmount = amount
+			p.PaymentFine = fine
+			p.PaymentFineWaiver = fineWaiver
+			p.PaymentDiscount = discount
+		})
+	}
+	pay("2024-03-05 10:00", 1000000, 50000, 20000, 10000)
+	pay("2024-03-31 23:30", 500000, 0, 0, 0)
+	modelstest.CreatePaymentReversal(t, testDB, pay("2024-04-02 10:00", 700000, 0, 0, 0))
+	pay("2024-04-10 10:00", 300000, 0, 0, 0)
+	pay("2024-08-10 10:00", 900000, 0, 0, 0)
+
+	aggregates, err := repo.GetPaymentAggregates(ctx, "month", wib(t, "2024-01-01 00:00"), wib(t, "2024-07-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+
+	assert.Equal(t, "2024-03-01", aggregates[0].Period.Format("2006-01-02"))
+	assert.Equal(t, 1500000.0, aggregates[0].Repaid)
+	assert.Equal(t, 30000.0, aggregates[0].Fines)
+	assert.Equal(t, 30000.0, aggregates[0].Discounts)
+
+	assert.Equal(t, "2024-04-01", aggregates[1].Period.Format("2006-01-02"))
+	assert.Equal(t, 300000.0, aggregates[1].Repaid)
+}
+
+func TestGetDebtorAggregates(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	create := func(createdAt string) {
+		modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.CreatedAt = wib(t, createdAt) })
+	}
+	create("2024-03-01 10:00")
+	create("2024-03-31 23:30")
+	// Still March in UTC but already April in Jakarta.
+	create("2024-04-01 03:00")
+	create("2023-12-31 10:00")
+
+	aggregates, err := repo.GetDebtorAggregates(ctx, "month", wib(t, "2024-01-01 00:00"), wib(t, "2024-07-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+	assert.Equal(t, "2024-03-01", aggregates[0].Period.Format("2006-01-02"))
+	assert.EqualValues(t, 2, aggregates[0].NewDebtors)
+	assert.Equal(t, "2024-04-01", aggregates[1].Period.Format("2006-01-02"))
+	assert.EqualValues(t, 1, aggregates[1].NewDebtors)
+}
+
+func TestGetLoanPositions(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	at := wib(t, "2024-04-01 00:00")
+
+	lending := func(approvedAt string, status int) *models.Lending {
+		return modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.LendingStatusID = status
+			l.ApprovedAt = ptr(wib(t, approvedAt))
+			l.CreatedAt = wib(t, approvedAt)
+		})
+	}
+	installment := func(lending *models.Lending, dueDate string, amount float64, opts ...func(*models.Installment)) *models.Installment {
+		return modelstest.CreateInstallment(t, testDB, append([]func(*models.Installment){func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.DueDate = wib(t, dueDate)
+			i.Amount = amount
+			i.CreatedAt = *lending.ApprovedAt
+		}}, opts...)...)
+	}
+	pay := func(installment *models.Installment, paidAt string) *models.Payment {
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentDate = wib(t, paidAt)
+		})
+	}
+
+	// Paid, paid then reversed before at, and unpaid installments.
+	active := lending("2024-01-15 10:00", 3)
+	pay(installment(active, "2024-02-15 00:00", 1000000), "2024-02-10 10:00")
+	reversedPayment := pay(installment(active, "2024-03-15 00:00", 1000000), "2024-03-14 10:00")
+	modelstest.CreatePaymentReversal(t, testDB, reversedPayment, func(r *models.PaymentReversal) {
+		r.CreatedAt = wib(t, "2024-03-20 10:00")
+	})
+	installment(active, "2024-04-15 00:00", 1000000)
+	// Created after at, so not part of the position yet.
+	installment(active, "2024-05-15 00:00", 1000000, func(i *models.Installment) { i.CreatedAt = wib(t, "2024-04-05 10:00") })
+
+	writtenOff := lending("2023-11-20 10:00", 6)
+	installment(writtenOff, "2024-02-01 00:00", 800000)
+	modelstest.CreateWriteOff(t, testDB, func(w *models.WriteOff) {
+		w.LendingID = writtenOff.LendingID
+		w.CreatedAt = wib(t, "2024-03-01 10:00")
+	})
+
+	restructured := lending("2024-02-05 10:00", 3)
+	restructuring := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) {
+		r.LendingID = restructured.LendingID
+		r.RestructuringStatusID = 2
+	})
+	installment(restructured, "2024-02-20 00:00", 900000, func(i *models.Installment) {
+		i.InstallmentStatusID = 3
+		i.SupersededByID = &restructuring.RestructuringID
+	})
+	installment(restructured, "2024-05-01 00:00", 950000, func(i *models.Installment) {
+		i.RestructuringID = &restructuring.RestructuringID
+		i.CreatedAt = wib(t, "2024-03-10 10:00")
+	})
+
+	positions, err := repo.GetLoanPositions(ctx, at)
+	require.NoError(t, err)
+	require.Len(t, positions, 3)
+
+	byLending := map[uuid.UUID]*models.LoanPosition{}
+	for _, position := range positions {
+		byLending[position.LendingID] = position
+	}
+
+	require.Contains(t, byLending, active.LendingID)
+	assert.Equal(t, 2000000.0, byLending[active.LendingID].Outstanding)
+	assert.True(t, wib(t, "2024-03-15 00:00").Equal(byLending[active.LendingID].OldestDueDate))
+	assert.Equal(t, "2024-01-01", byLending[active.LendingID].Cohort.Format("2006-01-02"))
+	assert.False(t, byLending[active.LendingID].WrittenOff)
+
+	require.Contains(t, byLending, writtenOff.LendingID)
+	assert.Equal(t, 800000.0, byLending[writtenOff.LendingID].Outstanding)
+	assert.True(t, byLending[writtenOff.LendingID].WrittenOff)
+	assert.Equal(t, "2023-11-01", byLending[writtenOff.LendingID].Cohort.Format("2006-01-02"))
+
+	require.Contains(t, byLending, restructured.LendingID)
+	assert.Equal(t, 950000.0, byLending[restructured.LendingID].Outstanding)
+	assert.True(t, wib(t, "2024-05-01 00:00").Equal(byLending[restructured.LendingID].OldestDueDate))
+
+	// Before the replacement installments existed the old schedule still counts.
+	positions, err = repo.GetLoanPositions(ctx, wib(t, "2024-03-01 00:00"))
+	require.NoError(t, err)
+	for _, position := range positions {
+		if position.LendingID == restructured.LendingID {
+			assert.Equal(t, 900000.0, position.Outstanding)
+		}
+		if position.LendingID == writtenOff.LendingID {
+			assert.False(t, position.WrittenOff)
+		}
+	}
+}
+
+func TestGetLoanCohorts(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	create := func(status int, amount float64, createdAt string, approvedAt *time.Time) {
+		modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.LendingStatusID = status
+			l.Amount = amount
+			l.CreatedAt = wib(t, createdAt)
+			l.ApprovedAt = approvedAt
+		})
+	}
+	create(3, 3000000, "2023-12-28 10:00", ptr(wib(t, "2024-01-02 10:00")))
+	create(6, 2000000, "2024-01-20 10:00", ptr(wib(t, "2024-01-21 10:00")))
+	create(4, 1000000, "2024-02-10 10:00", nil)
+	create(5, 4000000, "2024-02-11 10:00", nil)
+	create(1, 4000000, "2024-02-12 10:00", nil)
+	create(3, 4000000, "2024-03-12 10:00", ptr(wib(t, "2024-03-12 11:00")))
+
+	cohorts, err := repo.GetLoanCohorts(ctx, wib(t, "2024-01-01 00:00"), wib(t, "2024-03-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, cohorts, 2)
+	assert.Equal(t, "2024-01-01", cohorts[0].Cohort.Format("2006-01-02"))
+	assert.EqualValues(t, 2, cohorts[0].Loans)
+	assert.Equal(t, 5000000.0, cohorts[0].Amount)
+	assert.Equal(t, "2024-02-01", cohorts[1].Cohort.Format("2006-01-02"))
+	assert.EqualValues(t, 1, cohorts[1].Loans)
+	assert.Equal(t, 1000000.0, cohorts[1].Amount)
+}
+
+func TestVoucherCodes(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.CodeRequired = true })
+	other := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.CodeRequired = true })
+	modelstest.CreateVoucherCode(t, testDB, other, "TAKEN")
+
+	existing, err := repo.GetExistingVoucherCodes(ctx, []string{"TAKEN", "HEMAT-B", "HEMAT-A"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TAKEN"}, existing)
+
+	createdAt := time.Now()
+	codes, err := repo.CreateVoucherCodes(ctx, []*models.VoucherCode{
+		{VoucherCodeID: uuid.New(), VoucherID: voucher.VoucherID, Code: "HEMAT-B", SingleUse: true, CreatedAt: createdAt},
+		{VoucherCodeID: uuid.New(), VoucherID: voucher.VoucherID, Code: "HEMAT-A", SingleUse: true, CreatedAt: createdAt, Voucher: &models.Voucher{VoucherID: uuid.New()}},
+	})
+	require.NoError(t, err)
+	require.Len(t, codes, 2)
+
+	found, err := repo.GetVoucherCodes(ctx, voucher.VoucherID.String())
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "HEMAT-A", found[0].Code)
+	assert.Equal(t, "HEMAT-B", found[1].Code)
+	assert.Nil(t, found[0].Voucher)
+
+	_, err = repo.CreateVoucherCodes(ctx, []*models.VoucherCode{
+		{VoucherCodeID: uuid.New(), VoucherID: voucher.VoucherID, Code: "TAKEN"},
+	})
+	assert.EqualError(t, err, response.VoucherCodeExist)
+}
+
+func TestGetVoucherRedemptionAggregates(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB)
+	other := modelstest.CreateVoucher(t, testDB)
+
+	redeem := func(voucher *models.Voucher, dueDate, paidAt string, discount, fineWaiver float64) *models.Payment {
+		installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.InstallmentStatusID = 2
+			i.DueDate = wib(t, dueDate)
+		})
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.VoucherID = &voucher.VoucherID
+			p.PaymentDate = wib(t, paidAt)
+			p.PaymentDiscount = discount
+			p.PaymentFineWaiver = fineWaiver
+		})
+	}
+	redeem(voucher, "2024-03-10 00:00", "2024-03-05 10:00", 100000, 0)
+	redeem(voucher, "2024-03-15 00:00", "2024-03-20 10:00", 50000, 20000)
+	modelstest.CreatePaymentReversal(t, testDB, redeem(voucher, "2024-04-10 00:00", "2024-04-02 10:00", 80000, 0))
+	redeem(voucher, "2024-05-10 00:00", "2024-05-09 10:00", 40000, 0)
+	redeem(other, "2024-03-10 00:00", "2024-03-05 10:00", 999000, 0)
+
+	aggregates, err := repo.GetVoucherRedemptionAggregates(ctx, voucher.VoucherID.String(), "month")
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+
+	assert.Equal(t, "2024-03-01", aggregates[0].Period.Format("2006-01-02"))
+	assert.EqualValues(t, 2, aggregates[0].Redemptions)
+	assert.EqualValues(t, 1, aggregates[0].LateRedemptions)
+	assert.Equal(t, 170000.0, aggregates[0].Discounts)
+
+	assert.Equal(t, "2024-05-01", aggregates[1].Period.Format("2006-01-02"))
+	assert.EqualValues(t, 1, aggregates[1].Redemptions)
+	assert.Zero(t, aggregates[1].LateRedemptions)
+}
+
+func TestGetVoucherRepaymentAggregates(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB)
+
+	installment := func(lending *models.Lending, dueDate string, status int) *models.Installment {
+		return modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.DueDate = wib(t, dueDate)
+			i.InstallmentStatusID = status
+		})
+	}
+	pay := func(installment *models.Installment, paidAt string, withVoucher bool) *models.Payment {
+		return modelstest.CreatePayment(t, testDB, func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentDate = wib(t, paidAt)
+			if withVoucher {
+				p.VoucherID = &voucher.VoucherID
+			}
+		})
+	}
+
+	// Redeemed the voucher: one installment paid on time, one still open.
+	user := modelstest.CreateLending(t, testDB)
+	pay(installment(user, "2024-03-10 00:00", 2), "2024-03-05 10:00", true)
+	installment(user, "2024-04-10 00:00", 1)
+
+	// Never redeemed: one late, one on time, one restructured and one
+	// outside the window.
+	nonUser := modelstest.CreateLending(t, testDB)
+	pay(installment(nonUser, "2024-03-12 00:00", 2), "2024-03-15 10:00", false)
+	pay(installment(nonUser, "2024-03-20 00:00", 2), "2024-03-19 10:00", false)
+	installment(nonUser, "2024-03-25 00:00", 3)
+	installment(nonUser, "2024-08-01 00:00", 1)
+
+	// A reversed redemption does not make the debtor a voucher user, and the
+	// reversed payment does not count as paying on time.
+	reversed := modelstest.CreateLending(t, testDB)
+	modelstest.CreatePaymentReversal(t, testDB, pay(installment(reversed, "2024-03-25 00:00", 1), "2024-03-24 10:00", true))
+
+	aggregates, err := repo.GetVoucherRepaymentAggregates(ctx, voucher.VoucherID.String(), wib(t, "2024-03-01 00:00"), wib(t, "2024-05-01 00:00"))
+	require.NoError(t, err)
+	require.Len(t, aggregates, 2)
+
+	byUse := map[bool]*models.VoucherRepaymentAggregate{}
+	for _, aggregate := range aggregates {
+		byUse[aggregate.UsedVoucher] = aggregate
+	}
+	require.Contains(t, byUse, true)
+	assert.EqualValues(t, 2, byUse[true].Installments)
+	assert.EqualValues(t, 1, byUse[true].OnTime)
+	require.Contains(t, byUse, false)
+	assert.EqualValues(t, 3, byUse[false].Installments)
+	assert.EqualValues(t, 1, byUse[false].OnTime)
+}
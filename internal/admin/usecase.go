@@ -4,7 +4,10 @@ import (
 	"context"
 	"final-project-backend/internal/admin/delivery/body"
 	"final-project-backend/internal/models"
+	"final-project-backend/pkg/scoring"
 	"final-project-backend/pkg/utils"
+	"io"
+	"time"
 )
 
 type UseCase interface {
@@ -12,16 +15,54 @@ type UseCase interface {
 	GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetLoans(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetPayments(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
-	GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetVouchers(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error)
 	GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error)
-	UpdateDebtorByID(ctx context.Context, debtorID string, body body.UpdateContractRequest) (*models.Debtor, error)
+	UpdateDebtorByID(ctx context.Context, userID, debtorID string, body body.UpdateContractRequest) (*models.Debtor, error)
 	UpdateInstallmentByID(ctx context.Context, installmentID string, body body.UpdateInstallmentRequest) (*models.Installment, error)
-	ApproveLoan(ctx context.Context, lendingID string) (*models.Lending, error)
-	RejectLoan(ctx context.Context, lendingID string) (*models.Lending, error)
+	ApproveLoan(ctx context.Context, userID, lendingID string) (*models.Lending, error)
+	RejectLoan(ctx context.Context, userID, lendingID string, body body.RejectLoanRequest) (*models.Lending, error)
+	BulkLoanAction(ctx context.Context, userID string, body body.BulkLoanRequest) (*body.BulkResponse, error)
+	BulkAdvanceContract(ctx context.Context, userID string, body body.BulkContractRequest) (*body.BulkResponse, error)
+	GetAnalytics(ctx context.Context, granularity string, from, to time.Time) (*body.AnalyticsResponse, error)
+	GetAgingReport(ctx context.Context, asOf time.Time) (*body.AgingReport, error)
+	GetParReport(ctx context.Context, asOf time.Time) (*body.ParReport, error)
+	GetRollRateReport(ctx context.Context, asOf time.Time, months int) (*body.RollRateReport, error)
+	GetVintageReport(ctx context.Context, asOf time.Time, months int) (*body.VintageReport, error)
+	ExportLoans(ctx context.Context, name string, status []int, sort, format string, w io.Writer) error
+	ExportPayments(ctx context.Context, name, sort, format string, w io.Writer) error
+	ExportDebtors(ctx context.Context, name, sort, format string, w io.Writer) error
+	BulkSetCreditLimit(ctx context.Context, userID string, body body.BulkCreditLimitRequest) (*body.BulkResponse, error)
 	CreateVoucher(ctx context.Context, body body.CreateVoucherRequest) (*models.Voucher, error)
 	GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error)
 	GetSummary(ctx context.Context) (*body.SummaryResponse, error)
 	UpdateVoucherByID(ctx context.Context, voucherID string, body body.UpdateVoucherRequest) (*models.Voucher, error)
 	DeleteVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error)
+	UpdateVoucherLifecycles(ctx context.Context) (int, error)
+	GetVoucherCodes(ctx context.Context, voucherID string) (*body.VoucherCodesResponse, error)
+	CreateVoucherCodes(ctx context.Context, voucherID string, body body.CreateVoucherCodesRequest) (*body.VoucherCodesResponse, error)
+	GetVoucherStats(ctx context.Context, voucherID, granularity string) (*body.VoucherStatsResponse, error)
+	GetLoanProducts(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error)
+	CreateLoanProduct(ctx context.Context, body body.CreateLoanProductRequest) (*models.LoanProduct, error)
+	UpdateLoanProductByID(ctx context.Context, loanProductID string, body body.UpdateLoanProductRequest) (*models.LoanProduct, error)
+	DeleteLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error)
+	ImportStatement(ctx context.Context, body body.ImportStatementRequest) (*models.StatementImport, error)
+	GetReconciliationItems(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	ProposeRestructuring(ctx context.Context, userID string, lendingID string, body body.ProposeRestructuringRequest) (*models.Restructuring, error)
+	GetRestructurings(ctx context.Context, lendingID string) ([]*models.Restructuring, error)
+	WriteOffLoan(ctx context.Context, userID string, lendingID string, body body.WriteOffRequest) (*models.WriteOff, error)
+	ReversePayment(ctx context.Context, paymentID string, body body.ReversePaymentRequest) (*models.Payment, error)
+	ResolveReconciliationItem(ctx context.Context, itemID string, body body.ResolveReconciliationRequest) (*models.ReconciliationItem, error)
+	GetDebtorScore(ctx context.Context, debtorID string) (*scoring.Result, error)
+	ProposeCreditLimits(ctx context.Context) (int, error)
+	GetCreditLimitProposals(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	RespondCreditLimitProposal(ctx context.Context, userID, proposalID string, body body.RespondCreditLimitProposalRequest) (*models.CreditLimitProposal, error)
+	GetKycSubmissions(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetKycDocument(ctx context.Context, submissionID, document string) (io.ReadCloser, string, error)
+	ReviewKyc(ctx context.Context, userID, submissionID string, body body.ReviewKycRequest) (*models.KycSubmission, error)
+	GetRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error)
+	CreateRejectionReason(ctx context.Context, body body.CreateRejectionReasonRequest) (*models.RejectionReason, error)
+	UpdateRejectionReasonByID(ctx context.Context, reasonID string, body body.UpdateRejectionReasonRequest) (*models.RejectionReason, error)
+	GetRejectionReport(ctx context.Context, period string, from, to time.Time) (*body.RejectionReportResponse, error)
 }
@@ -2,26 +2,41 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"final-project-backend/config"
 	"final-project-backend/internal/admin"
 	"final-project-backend/internal/admin/delivery/body"
 	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
+	"final-project-backend/pkg/export"
 	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/metrics"
 	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/scoring"
+	"final-project-backend/pkg/statement"
+	"final-project-backend/pkg/storage"
 	"final-project-backend/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"io"
 	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 )
 
 type adminUC struct {
 	cfg       *config.Config
 	adminRepo admin.Repository
+	paymentUC payment.UseCase
+	storage   storage.Storage
+	rules     scoring.Rules
 }
 
-func NewAdminUseCase(cfg *config.Config, adminRepo admin.Repository) admin.UseCase {
-	return &adminUC{cfg: cfg, adminRepo: adminRepo}
+func NewAdminUseCase(cfg *config.Config, adminRepo admin.Repository, paymentUC payment.UseCase, storage storage.Storage) admin.UseCase {
+	return &adminUC{cfg: cfg, adminRepo: adminRepo, paymentUC: paymentUC, storage: storage, rules: scoring.NewRules(cfg)}
 }
 
 func (u *adminUC) GetDebtors(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
@@ -71,12 +86,13 @@ func (u *adminUC) GetVoucherByID(ctx context.Context, id string) (*models.Vouche
 
 func (u *adminUC) GetSummary(ctx context.Context) (*body.SummaryResponse, error) {
 	response := &body.SummaryResponse{
-		LendingAmount: 0,
-		ReturnAmount:  0,
-		LendingTotal:  0,
-		UserTotal:     0,
-		LendingAction: []*models.Lending{},
-		UserAction:    []*models.Debtor{},
+		LendingAmount:  0,
+		ReturnAmount:   0,
+		WriteOffAmount: 0,
+		LendingTotal:   0,
+		UserTotal:      0,
+		LendingAction:  []*models.Lending{},
+		UserAction:     []*models.Debtor{},
 	}
 
 	userTotal, err := u.adminRepo.GetUserTotal(ctx)
@@ -99,6 +115,11 @@ func (u *adminUC) GetSummary(ctx context.Context) (*body.SummaryResponse, error)
 		return response, err
 	}
 
+	writeOffAmount, err := u.adminRepo.GetWriteOffAmount(ctx)
+	if err != nil {
+		return response, err
+	}
+
 	lendingAction, err := u.adminRepo.GetLendingAction(ctx)
 	if err != nil {
 		return response, err
@@ -113,34 +134,192 @@ func (u *adminUC) GetSummary(ctx context.Context) (*body.SummaryResponse, error)
 	response.LendingTotal = lendingTotal
 	response.LendingAmount = lendingAmount
 	response.ReturnAmount = returnAmount
+	response.WriteOffAmount = writeOffAmount
 	response.LendingAction = lendingAction
 	response.UserAction = userAction
 
 	return response, nil
 }
 
-func (u *adminUC) ApproveLoan(ctx context.Context, lendingID string) (*models.Lending, error) {
-	lending, err := u.adminRepo.GetLendingByID(ctx, lendingID)
+func (u *adminUC) ApproveLoan(ctx context.Context, userID, lendingID string) (*models.Lending, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		return u.approveLoan(ctx, repo, adminID, lendingID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.LoanApproved()
+
+	lending, err := u.adminRepo.GetLendingWithInstallmentByID(ctx, lendingID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return lending, httperror.New(http.StatusBadRequest, response.DebtorIDNotExist)
-		}
 		return lending, err
 	}
 
-	lending.LendingStatusID = 2
-	lending, err = u.adminRepo.UpdateLendingByID(ctx, lending)
+	return lending, nil
+}
+
+func (u *adminUC) RejectLoan(ctx context.Context, userID, lendingID string, body body.RejectLoanRequest) (*models.Lending, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := u.getActiveRejectionReason(ctx, body.ReasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		return u.rejectLoan(ctx, repo, adminID, lendingID, reason, body.Note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.LoanRejected()
+
+	lending, err := u.adminRepo.GetLoanByID(ctx, lendingID)
 	if err != nil {
 		return lending, err
 	}
 
-	var installments []*models.Installment
-	installmentAmount := math.Ceil(lending.Amount / float64(lending.LoanPeriod.Duration))
+	return lending, nil
+}
+
+func (u *adminUC) BulkLoanAction(ctx context.Context, userID string, body body.BulkLoanRequest) (*body.BulkResponse, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason *models.RejectionReason
+	if body.Action == "reject" {
+		reason, err = u.getActiveRejectionReason(ctx, body.ReasonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := newBulkResponse()
+	for _, lendingID := range body.LendingIDs {
+		err := u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+			if body.Action == "reject" {
+				return u.rejectLoan(ctx, repo, adminID, lendingID, reason, body.Note)
+			}
+			return u.approveLoan(ctx, repo, adminID, lendingID)
+		})
+		if err == nil && body.Action == "reject" {
+			metrics.LoanRejected()
+		} else if err == nil {
+			metrics.LoanApproved()
+		}
+		result.Add(lendingID, err)
+	}
+
+	return result, nil
+}
+
+func (u *adminUC) BulkAdvanceContract(ctx context.Context, userID string, body body.BulkContractRequest) (*body.BulkResponse, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBulkResponse()
+	for _, debtorID := range body.DebtorIDs {
+		err := u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+			debtor, err := lockDebtor(ctx, repo, debtorID)
+			if err != nil {
+				return err
+			}
+
+			// Status 5 is set by the debtor confirming the contract, so admins
+			// only move it up to "accepted by user".
+			if debtor.ContractTrackingID >= 4 {
+				return httperror.New(http.StatusBadRequest, response.ContractCannotAdvance)
+			}
+
+			previousContractID := debtor.ContractTrackingID
+			debtor.ContractTrackingID++
+			if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+				return err
+			}
+
+			return recordAdminAction(ctx, repo, adminID, models.AdminActionAdvanceContract, debtor.DebtorID,
+				fmt.Sprintf("contract status %d to %d", previousContractID, debtor.ContractTrackingID))
+		})
+		result.Add(debtorID, err)
+	}
+
+	return result, nil
+}
+
+func (u *adminUC) BulkSetCreditLimit(ctx context.Context, userID string, body body.BulkCreditLimitRequest) (*body.BulkResponse, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBulkResponse()
+	for _, row := range body.Rows {
+		if row.Err != nil {
+			result.Add(row.DebtorID, row.Err)
+			continue
+		}
+
+		err := u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+			debtor, err := lockDebtor(ctx, repo, row.DebtorID)
+			if err != nil {
+				return err
+			}
+
+			previousLimit := debtor.CreditLimit
+			debtor.CreditLimit = row.CreditLimit
+			if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+				return err
+			}
+
+			return recordAdminAction(ctx, repo, adminID, models.AdminActionSetCreditLimit, debtor.DebtorID,
+				fmt.Sprintf("credit limit %.2f to %.2f", previousLimit, debtor.CreditLimit))
+		})
+		result.Add(row.DebtorID, err)
+	}
+
+	return result, nil
+}
+
+// approveLoan approves a new lending and schedules its installments. It must
+// run inside a transaction.
+func (u *adminUC) approveLoan(ctx context.Context, repo admin.Repository, adminID uuid.UUID, lendingID string) error {
+	lending, err := lockLending(ctx, repo, lendingID)
+	if err != nil {
+		return err
+	}
+
+	if lending.LendingStatusID != 1 {
+		return httperror.New(http.StatusBadRequest, response.LendingCannotApprove)
+	}
 
 	loc, _ := time.LoadLocation("Asia/Jakarta")
-	for i := 0; i < lending.LoanPeriod.Duration; i++ {
+	timeNow := time.Now().In(loc)
+
+	lending.LendingStatusID = 2
+	lending.ApprovedAt = &timeNow
+	lending, err = repo.UpdateLendingByID(ctx, lending)
+	if err != nil {
+		return err
+	}
+
+	var installments []*models.Installment
+	installmentAmount := math.Ceil(lending.Amount / float64(lending.Duration))
+
+	for i := 0; i < lending.Duration; i++ {
 		installment := &models.Installment{}
-		installmentDate := time.Now().In(loc)
+		installmentDate := timeNow
 		installmentDate = installmentDate.AddDate(0, i+1, 0)
 
 		installment.LendingID = lending.LendingID
@@ -149,47 +328,123 @@ func (u *adminUC) ApproveLoan(ctx context.Context, lendingID string) (*models.Le
 		installments = append(installments, installment)
 
 		if err := installment.PrepareCreate(); err != nil {
-			return lending, err
+			return err
 		}
 	}
 
-	lending, err = u.adminRepo.CreateInstallments(ctx, lendingID, installments)
-	if err != nil {
-		return lending, err
+	if _, err := repo.CreateInstallments(ctx, lendingID, installments); err != nil {
+		return err
 	}
 
-	return lending, nil
+	return recordAdminAction(ctx, repo, adminID, models.AdminActionApproveLoan, lending.LendingID,
+		fmt.Sprintf("%d installments of %.2f", len(installments), installmentAmount))
 }
 
-func (u *adminUC) RejectLoan(ctx context.Context, lendingID string) (*models.Lending, error) {
-	lending, err := u.adminRepo.GetLendingByID(ctx, lendingID)
+// rejectLoan rejects a new lending and releases the credit it reserved. It
+// must run inside a transaction.
+func (u *adminUC) rejectLoan(ctx context.Context, repo admin.Repository, adminID uuid.UUID, lendingID string, reason *models.RejectionReason, note string) error {
+	lending, err := lockLending(ctx, repo, lendingID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return lending, httperror.New(http.StatusBadRequest, response.DebtorIDNotExist)
-		}
-		return lending, err
+		return err
+	}
+
+	if lending.LendingStatusID != 1 {
+		return httperror.New(http.StatusBadRequest, response.LendingCannotReject)
 	}
 
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timeNow := time.Now().In(loc)
+
 	lending.LendingStatusID = 5
-	lending, err = u.adminRepo.UpdateLendingByID(ctx, lending)
-	if err != nil {
-		return lending, err
+	lending.RejectionReasonID = &reason.RejectionReasonID
+	lending.RejectionNote = &note
+	lending.RejectedAt = &timeNow
+	if _, err := repo.UpdateLendingByID(ctx, lending); err != nil {
+		return err
 	}
 
-	debtor, err := u.adminRepo.GetDebtorByID(ctx, lending.DebtorID.String())
+	debtor, err := repo.GetDebtorForUpdate(ctx, lending.DebtorID.String())
 	if err != nil {
-		return lending, err
+		return err
 	}
 
 	debtor.CreditUsed -= lending.Amount
-	if _, err := u.adminRepo.UpdateDebtorByID(ctx, debtor); err != nil {
+	if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+		return err
+	}
+
+	return recordAdminAction(ctx, repo, adminID, models.AdminActionRejectLoan, lending.LendingID,
+		fmt.Sprintf("%s: %s", reason.Code, note))
+}
+
+func (u *adminUC) getActiveRejectionReason(ctx context.Context, reasonID int) (*models.RejectionReason, error) {
+	reason, err := u.adminRepo.GetRejectionReasonByID(ctx, reasonID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.RejectionReasonNotExist)
+		}
+		return nil, err
+	}
+
+	if !reason.IsActive {
+		return nil, httperror.New(http.StatusBadRequest, response.RejectionReasonNotActive)
+	}
+
+	return reason, nil
+}
+
+func lockLending(ctx context.Context, repo admin.Repository, lendingID string) (*models.Lending, error) {
+	if _, err := uuid.Parse(lendingID); err != nil {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+	}
+
+	lending, err := repo.GetLendingForUpdate(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
 		return nil, err
 	}
 
 	return lending, nil
 }
 
-func (u *adminUC) UpdateDebtorByID(ctx context.Context, debtorID string, body body.UpdateContractRequest) (*models.Debtor, error) {
+func lockDebtor(ctx context.Context, repo admin.Repository, debtorID string) (*models.Debtor, error) {
+	if _, err := uuid.Parse(debtorID); err != nil {
+		return nil, httperror.New(http.StatusBadRequest, response.DebtorIDNotExist)
+	}
+
+	debtor, err := repo.GetDebtorForUpdate(ctx, debtorID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.DebtorIDNotExist)
+		}
+		return nil, err
+	}
+
+	return debtor, nil
+}
+
+func recordAdminAction(ctx context.Context, repo admin.Repository, adminID uuid.UUID, action string, targetID uuid.UUID, detail string) error {
+	record, err := models.NewAdminAction(adminID, action, targetID, detail)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateAdminAction(ctx, record)
+	return err
+}
+
+func newBulkResponse() *body.BulkResponse {
+	return &body.BulkResponse{Items: []*body.BulkItemResult{}}
+}
+
+func (u *adminUC) UpdateDebtorByID(ctx context.Context, userID, debtorID string, body body.UpdateContractRequest) (*models.Debtor, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	debtor, err := u.adminRepo.GetDebtorByID(ctx, debtorID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -214,10 +469,34 @@ func (u *adminUC) UpdateDebtorByID(ctx context.Context, debtorID string, body bo
 		return debtor, err
 	}
 
-	debtor.CreditLimit = body.CreditLimit
-	debtor.CreditHealthID = health.CreditHealthID
-	debtor.ContractTrackingID = contract.ContractTrackingID
-	debtor, err = u.adminRepo.UpdateDebtorByID(ctx, debtor)
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		locked, err := lockDebtor(ctx, repo, debtorID)
+		if err != nil {
+			return err
+		}
+
+		previousCreditHealthID := locked.CreditHealthID
+		locked.CreditLimit = body.CreditLimit
+		locked.CreditHealthID = health.CreditHealthID
+		locked.ContractTrackingID = contract.ContractTrackingID
+		debtor, err = repo.UpdateDebtorByID(ctx, locked)
+		if err != nil {
+			return err
+		}
+
+		history, err := models.NewCreditHealthHistory(debtor, 3, previousCreditHealthID, debtor.TotalDelay)
+		if err != nil {
+			return err
+		}
+		if history != nil {
+			history.UserID = &adminID
+			if _, err := repo.CreateCreditHealthHistory(ctx, history); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return debtor, err
 	}
@@ -234,11 +513,30 @@ func (u *adminUC) UpdateVoucherByID(ctx context.Context, voucherID string, body
 		return voucher, err
 	}
 
+	if voucher.IsArchived() {
+		return voucher, httperror.New(http.StatusBadRequest, response.VoucherArchived)
+	}
+
+	creditHealths, loanProducts, err := u.getVoucherSegment(ctx, body.CreditHealthIDs, body.LoanProductIDs)
+	if err != nil {
+		return voucher, err
+	}
+
 	voucher.Name = body.Name
+	voucher.VoucherDiscountTypeID = body.VoucherDiscountTypeID
 	voucher.DiscountPayment = body.DiscountPayment
+	voucher.DiscountAmount = body.DiscountAmount
+	voucher.MaxDiscount = body.MaxDiscount
 	voucher.DiscountQuota = body.DiscountQuota
+	voucher.PerUserLimit = body.PerUserLimit
+	voucher.MinInstallmentAmount = body.MinInstallmentAmount
+	voucher.CodeRequired = body.CodeRequired
 	voucher.ActiveDate = body.ActiveDateTime
 	voucher.ExpireDate = body.ExpireDateTime
+	voucher.CreditHealths = creditHealths
+	voucher.LoanProducts = loanProducts
+	voucher.VoucherDiscountType = nil
+	voucher.RefreshStatus(time.Now())
 
 	if err := u.adminRepo.UpdateVoucherByID(ctx, voucher); err != nil {
 		return voucher, err
@@ -248,18 +546,32 @@ func (u *adminUC) UpdateVoucherByID(ctx context.Context, voucherID string, body
 }
 
 func (u *adminUC) CreateVoucher(ctx context.Context, body body.CreateVoucherRequest) (*models.Voucher, error) {
+	creditHealths, loanProducts, err := u.getVoucherSegment(ctx, body.CreditHealthIDs, body.LoanProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	voucher := &models.Voucher{}
 	voucher.Name = body.Name
+	voucher.VoucherDiscountTypeID = body.VoucherDiscountTypeID
 	voucher.DiscountPayment = body.DiscountPayment
+	voucher.DiscountAmount = body.DiscountAmount
+	voucher.MaxDiscount = body.MaxDiscount
 	voucher.DiscountQuota = body.DiscountQuota
+	voucher.PerUserLimit = body.PerUserLimit
+	voucher.MinInstallmentAmount = body.MinInstallmentAmount
+	voucher.CodeRequired = body.CodeRequired
 	voucher.ActiveDate = body.ActiveDateTime
 	voucher.ExpireDate = body.ExpireDateTime
+	voucher.CreditHealths = creditHealths
+	voucher.LoanProducts = loanProducts
+	voucher.RefreshStatus(time.Now())
 
 	if err := voucher.PrepareCreate(); err != nil {
 		return voucher, err
 	}
 
-	voucher, err := u.adminRepo.CreateVoucher(ctx, voucher)
+	voucher, err = u.adminRepo.CreateVoucher(ctx, voucher)
 	if err != nil {
 		return voucher, err
 	}
@@ -294,14 +606,119 @@ func (u *adminUC) DeleteVoucherByID(ctx context.Context, voucherID string) (*mod
 		return voucher, err
 	}
 
-	err = u.adminRepo.DeleteVoucher(ctx, voucher)
-	if err := u.adminRepo.DeleteVoucher(ctx, voucher); err != nil {
+	if voucher.IsArchived() {
+		return voucher, httperror.New(http.StatusBadRequest, response.VoucherArchived)
+	}
+
+	archivedAt := time.Now()
+	voucher.VoucherStatusID = 5
+	voucher.VoucherStatus = nil
+	voucher.ArchivedAt = &archivedAt
+	if err := u.adminRepo.UpdateVoucherStatus(ctx, voucher); err != nil {
 		return voucher, err
 	}
 
 	return voucher, nil
 }
 
+func (u *adminUC) GetLoanProducts(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	loanProducts, err := u.adminRepo.GetLoanProducts(ctx, name, pagination)
+	if err != nil {
+		return loanProducts, err
+	}
+
+	return loanProducts, nil
+}
+
+func (u *adminUC) GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	loanProduct, err := u.adminRepo.GetLoanProductByID(ctx, loanProductID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return loanProduct, httperror.New(http.StatusBadRequest, response.LoanProductNotExist)
+		}
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
+func (u *adminUC) CreateLoanProduct(ctx context.Context, body body.CreateLoanProductRequest) (*models.LoanProduct, error) {
+	creditHealths, err := u.adminRepo.GetCreditHealthTypesByID(ctx, body.CreditHealthIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	loanProduct := &models.LoanProduct{}
+	loanProduct.Name = body.Name
+	loanProduct.Duration = body.Duration
+	loanProduct.Percentage = body.Percentage
+	loanProduct.FinePerDay = body.FinePerDay
+	loanProduct.MinAmount = body.MinAmount
+	loanProduct.MaxAmount = body.MaxAmount
+	loanProduct.ActiveDate = body.ActiveDateTime
+	loanProduct.ExpireDate = body.ExpireDateTime
+	loanProduct.CreditHealths = creditHealths
+
+	if err := loanProduct.PrepareCreate(); err != nil {
+		return loanProduct, err
+	}
+
+	loanProduct, err = u.adminRepo.CreateLoanProduct(ctx, loanProduct)
+	if err != nil {
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
+func (u *adminUC) UpdateLoanProductByID(ctx context.Context, loanProductID string, body body.UpdateLoanProductRequest) (*models.LoanProduct, error) {
+	loanProduct, err := u.adminRepo.GetLoanProductByID(ctx, loanProductID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return loanProduct, httperror.New(http.StatusBadRequest, response.LoanProductNotExist)
+		}
+		return loanProduct, err
+	}
+
+	creditHealths, err := u.adminRepo.GetCreditHealthTypesByID(ctx, body.CreditHealthIDs)
+	if err != nil {
+		return loanProduct, err
+	}
+
+	loanProduct.Name = body.Name
+	loanProduct.Duration = body.Duration
+	loanProduct.Percentage = body.Percentage
+	loanProduct.FinePerDay = body.FinePerDay
+	loanProduct.MinAmount = body.MinAmount
+	loanProduct.MaxAmount = body.MaxAmount
+	loanProduct.ActiveDate = body.ActiveDateTime
+	loanProduct.ExpireDate = body.ExpireDateTime
+	loanProduct.CreditHealths = creditHealths
+
+	loanProduct, err = u.adminRepo.UpdateLoanProduct(ctx, loanProduct)
+	if err != nil {
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
+func (u *adminUC) DeleteLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	loanProduct, err := u.adminRepo.GetLoanProductByID(ctx, loanProductID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return loanProduct, httperror.New(http.StatusBadRequest, response.LoanProductNotExist)
+		}
+		return loanProduct, err
+	}
+
+	if err := u.adminRepo.DeleteLoanProduct(ctx, loanProduct); err != nil {
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
 func (u *adminUC) GetLoans(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	loans, err := u.adminRepo.GetLoans(ctx, name, status, pagination)
 	if err != nil {
@@ -319,15 +736,68 @@ func (u *adminUC) GetPayments(ctx context.Context, name string, pagination *util
 	return payments, nil
 }
 
-func (u *adminUC) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	vouchers, err := u.adminRepo.GetVouchers(ctx, name, pagination)
+func (u *adminUC) GetVouchers(ctx context.Context, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	vouchers, err := u.adminRepo.GetVouchers(ctx, name, status, pagination)
+	if err != nil {
+		return vouchers, err
+	}
+
+	rows, _ := vouchers.Rows.([]*models.Voucher)
+	var voucherIDs []string
+	for _, voucher := range rows {
+		voucherIDs = append(voucherIDs, voucher.VoucherID.String())
+	}
+
+	stats, err := u.adminRepo.GetVoucherRedemptionStats(ctx, voucherIDs)
 	if err != nil {
 		return vouchers, err
 	}
 
+	statsByVoucher := map[uuid.UUID]*models.VoucherRedemptionStats{}
+	for _, stat := range stats {
+		statsByVoucher[stat.VoucherID] = stat
+	}
+
+	items := make([]*body.VoucherResponse, 0, len(rows))
+	for _, voucher := range rows {
+		item := &body.VoucherResponse{Voucher: voucher}
+		if stat, ok := statsByVoucher[voucher.VoucherID]; ok {
+			item.Redemptions = stat.Redemptions
+			item.DiscountGiven = stat.DiscountGiven
+		}
+		items = append(items, item)
+	}
+
+	vouchers.Rows = items
 	return vouchers, nil
 }
 
+// UpdateVoucherLifecycles moves vouchers into the status they have now, as
+// dates pass and quotas run out, and returns how many changed.
+func (u *adminUC) UpdateVoucherLifecycles(ctx context.Context) (int, error) {
+	vouchers, err := u.adminRepo.GetUnarchivedVouchers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	updated := 0
+	for _, voucher := range vouchers {
+		if !voucher.RefreshStatus(now) {
+			continue
+		}
+
+		if err := u.adminRepo.UpdateVoucherStatus(ctx, voucher); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
 func (u *adminUC) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
 	lending, err := u.adminRepo.GetLoanByID(ctx, lendingID)
 	if err != nil {
@@ -339,3 +809,1433 @@ func (u *adminUC) GetLoanByID(ctx context.Context, lendingID string) (*models.Le
 
 	return lending, nil
 }
+
+func (u *adminUC) ProposeRestructuring(ctx context.Context, userID string, lendingID string, body body.ProposeRestructuringRequest) (*models.Restructuring, error) {
+	lending, err := u.adminRepo.GetLendingWithInstallmentByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	if lending.LendingStatusID != 2 && lending.LendingStatusID != 3 {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingCannotBeRestructured)
+	}
+
+	restructurings, err := u.adminRepo.GetRestructuringsByLendingID(ctx, lendingID)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := 0
+	for _, restructuring := range restructurings {
+		switch restructuring.RestructuringStatusID {
+		case 1:
+			return nil, httperror.New(http.StatusBadRequest, response.RestructuringAlreadyPending)
+		case 2:
+			accepted++
+		}
+	}
+
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	restructuring := &models.Restructuring{}
+	for _, installment := range *lending.Installments {
+		if installment.InstallmentStatusID != 1 {
+			continue
+		}
+
+		restructuring.OutstandingAmount += installment.Amount
+		if body.CapitalizeFines {
+			restructuring.CapitalizedFine += installment.Fine(now, lending.FinePerDay)
+		}
+	}
+
+	if restructuring.OutstandingAmount == 0 {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingCannotBeRestructured)
+	}
+
+	restructuring.TotalAmount = restructuring.OutstandingAmount + restructuring.CapitalizedFine
+	restructuring.InstallmentAmount = body.InstallmentAmount
+	if body.Tenor > 0 {
+		restructuring.InstallmentAmount = math.Ceil(restructuring.TotalAmount / float64(body.Tenor))
+	}
+
+	// Rounding the installment up can leave nothing for the last months, so
+	// the tenor is always derived from the installment amount.
+	restructuring.Tenor = int(math.Ceil(restructuring.TotalAmount / restructuring.InstallmentAmount))
+	if restructuring.Tenor > models.MaxRestructuringTenor {
+		return nil, httperror.New(http.StatusBadRequest, response.RestructuringTenorExceeded)
+	}
+
+	restructuring.LendingID = lending.LendingID
+	restructuring.UserID = adminID
+	restructuring.Version = accepted + 2
+	restructuring.CapitalizeFines = body.CapitalizeFines
+	restructuring.FirstDueDate = models.DueDateAfter(now, 1)
+	if err := restructuring.PrepareCreate(); err != nil {
+		return restructuring, err
+	}
+
+	restructuring, err = u.adminRepo.CreateRestructuring(ctx, restructuring)
+	if err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (u *adminUC) GetRestructurings(ctx context.Context, lendingID string) ([]*models.Restructuring, error) {
+	if _, err := u.adminRepo.GetLendingByID(ctx, lendingID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	restructurings, err := u.adminRepo.GetRestructuringsByLendingID(ctx, lendingID)
+	if err != nil {
+		return restructurings, err
+	}
+
+	return restructurings, nil
+}
+
+func (u *adminUC) WriteOffLoan(ctx context.Context, userID string, lendingID string, body body.WriteOffRequest) (*models.WriteOff, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	writeOff := &models.WriteOff{}
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		locked, err := lockLending(ctx, repo, lendingID)
+		if err != nil {
+			return err
+		}
+
+		if locked.LendingStatusID != 2 && locked.LendingStatusID != 3 {
+			return httperror.New(http.StatusBadRequest, response.LendingCannotBeWrittenOff)
+		}
+
+		lending, err := repo.GetLendingWithInstallmentByID(ctx, lendingID)
+		if err != nil {
+			return err
+		}
+
+		for _, installment := range *lending.Installments {
+			if installment.InstallmentStatusID != 1 {
+				continue
+			}
+
+			writeOff.PrincipalLoss += installment.Amount
+			writeOff.FineLoss += installment.Fine(now, lending.FinePerDay)
+		}
+
+		writeOff.LendingID = lending.LendingID
+		writeOff.UserID = adminID
+		writeOff.Reason = body.Reason
+		if err := writeOff.PrepareCreate(); err != nil {
+			return err
+		}
+
+		writeOff, err = repo.CreateWriteOff(ctx, writeOff)
+		if err != nil {
+			return err
+		}
+
+		lending.LendingStatusID = 6
+		if _, err := repo.UpdateLendingByID(ctx, lending); err != nil {
+			return err
+		}
+
+		debtor, err := lockDebtor(ctx, repo, lending.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		previousCreditHealthID := debtor.CreditHealthID
+		debtor.CreditHealthID = 3
+		if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+			return err
+		}
+
+		history, err := models.NewCreditHealthHistory(debtor, 4, previousCreditHealthID, debtor.TotalDelay)
+		if err != nil {
+			return err
+		}
+		if history != nil {
+			history.ReferenceID = &writeOff.WriteOffID
+			history.UserID = &adminID
+			if _, err := repo.CreateCreditHealthHistory(ctx, history); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return writeOff, err
+	}
+
+	return writeOff, nil
+}
+
+func (u *adminUC) ReversePayment(ctx context.Context, paymentID string, body body.ReversePaymentRequest) (*models.Payment, error) {
+	payment, err := u.paymentUC.ReversePayment(ctx, paymentID, body.Reason)
+	if err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+func (u *adminUC) ImportStatement(ctx context.Context, body body.ImportStatementRequest) (*models.StatementImport, error) {
+	file, err := body.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines, err := statement.Parse(body.Format, file)
+	if err != nil {
+		return nil, httperror.New(http.StatusBadRequest, response.InvalidStatementFile)
+	}
+
+	statementImport := &models.StatementImport{
+		FileName:   body.File.Filename,
+		Format:     body.Format,
+		TotalLines: len(lines),
+	}
+	if err := statementImport.PrepareCreate(); err != nil {
+		return statementImport, err
+	}
+
+	// The whole file is one transaction, so a failed import leaves no
+	// settled payment behind without its reconciliation item.
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		paymentUC := u.paymentUC.WithRepository(repo.PaymentRepository())
+
+		statementImport, err = repo.CreateStatementImport(ctx, statementImport)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			lineHash := line.Hash()
+			exist, err := repo.CheckReconciliationLineExist(ctx, lineHash)
+			if err != nil {
+				return err
+			}
+
+			if exist {
+				statementImport.DuplicateLines++
+				continue
+			}
+
+			item := &models.ReconciliationItem{
+				StatementImportID: statementImport.StatementImportID,
+				LineNumber:        line.Number,
+				LineHash:          lineHash,
+				TransactionDate:   line.TransactionDate,
+				Reference:         line.Reference,
+				Description:       line.Description,
+				Amount:            line.Amount,
+			}
+			if err := item.PrepareCreate(); err != nil {
+				return err
+			}
+
+			if err := u.reconcileLine(ctx, repo, paymentUC, item, line.References()); err != nil {
+				return err
+			}
+
+			if item.ReconciliationStatusID == 3 {
+				statementImport.MatchedLines++
+			} else {
+				statementImport.UnmatchedLines++
+			}
+
+			if _, err := repo.CreateReconciliationItem(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		statementImport, err = repo.UpdateStatementImport(ctx, statementImport)
+		return err
+	})
+	if err != nil {
+		return statementImport, err
+	}
+
+	statementImport, err = u.adminRepo.GetStatementImportByID(ctx, statementImport.StatementImportID.String())
+	if err != nil {
+		return statementImport, err
+	}
+
+	return statementImport, nil
+}
+
+func (u *adminUC) GetReconciliationItems(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	items, err := u.adminRepo.GetReconciliationItems(ctx, status, pagination)
+	if err != nil {
+		return items, err
+	}
+
+	return items, nil
+}
+
+func (u *adminUC) ResolveReconciliationItem(ctx context.Context, itemID string, body body.ResolveReconciliationRequest) (*models.ReconciliationItem, error) {
+	var item *models.ReconciliationItem
+	err := u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		var err error
+		item, err = repo.GetReconciliationItemForUpdate(ctx, itemID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return httperror.New(http.StatusBadRequest, response.ReconciliationItemNotExist)
+			}
+			return err
+		}
+
+		if item.ReconciliationStatusID > 2 {
+			return httperror.New(http.StatusBadRequest, response.ReconciliationItemAlreadyResolved)
+		}
+
+		switch body.Action {
+		case "settle":
+			intent := &models.PaymentIntent{}
+			if body.PaymentIntentID != "" {
+				intent, err = repo.GetPaymentIntentByID(ctx, body.PaymentIntentID)
+				if err != nil {
+					if err == gorm.ErrRecordNotFound {
+						return httperror.New(http.StatusBadRequest, response.PaymentIntentNotExist)
+					}
+					return err
+				}
+			} else {
+				installment, err := repo.GetInstallmentByID(ctx, body.InstallmentID)
+				if err != nil {
+					if err == gorm.ErrRecordNotFound {
+						return httperror.New(http.StatusBadRequest, response.InstallmentNotExist)
+					}
+					return err
+				}
+
+				if installment.InstallmentStatusID != 1 {
+					return httperror.New(http.StatusBadRequest, response.InstallmentAlreadyPaid)
+				}
+
+				intent, err = createStatementIntent(ctx, repo, item, installment)
+				if err != nil {
+					return err
+				}
+			}
+
+			payment, err := u.paymentUC.WithRepository(repo.PaymentRepository()).SettleIntent(ctx, intent.PaymentIntentID.String(), item.TransactionDate)
+			if err != nil {
+				return err
+			}
+
+			item.PaymentIntentID = &intent.PaymentIntentID
+			item.PaymentID = &payment.PaymentID
+			item.ReconciliationStatusID = 4
+		case "ignore":
+			item.ReconciliationStatusID = 5
+		}
+
+		loc, _ := time.LoadLocation("Asia/Jakarta")
+		resolvedAt := time.Now().In(loc)
+		item.ResolvedAt = &resolvedAt
+		item.Note = body.Note
+
+		item, err = repo.UpdateReconciliationItem(ctx, item)
+		return err
+	})
+	if err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+// reconcileLine matches a statement line against open payment intents by
+// virtual account number or payment code, and against open installments by
+// id for transfers made without a payment intent. Only a single candidate
+// with the exact amount is settled; everything else is left for review.
+func (u *adminUC) reconcileLine(ctx context.Context, repo admin.Repository, paymentUC payment.UseCase, item *models.ReconciliationItem, references []string) error {
+	intents, err := repo.GetOpenPaymentIntentsByReference(ctx, references)
+	if err != nil {
+		return err
+	}
+
+	var installmentIDs []string
+	for _, reference := range references {
+		if _, err := uuid.Parse(reference); err == nil {
+			installmentIDs = append(installmentIDs, reference)
+		}
+	}
+
+	installments, err := repo.GetOpenInstallmentsByID(ctx, installmentIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(intents) == 0 && len(installments) == 0 {
+		item.Note = response.ReconciliationReferenceNotFound
+		return nil
+	}
+
+	matchedIntents := map[uuid.UUID]*models.PaymentIntent{}
+	for _, intent := range intents {
+		if math.Abs(intent.PaymentAmount-item.Amount) < 0.01 {
+			matchedIntents[intent.InstallmentID] = intent
+		}
+	}
+
+	matchedInstallments := map[uuid.UUID]*models.Installment{}
+	for _, installment := range installments {
+		if _, ok := matchedIntents[installment.InstallmentID]; ok {
+			continue
+		}
+		if math.Abs(installment.Amount+installment.Fine(item.TransactionDate, installment.Lending.FinePerDay)-item.Amount) < 0.01 {
+			matchedInstallments[installment.InstallmentID] = installment
+		}
+	}
+
+	switch len(matchedIntents) + len(matchedInstallments) {
+	case 0:
+		item.Note = response.ReconciliationAmountNotMatch
+		return nil
+	case 1:
+	default:
+		item.ReconciliationStatusID = 2
+		item.Note = response.ReconciliationAmbiguous
+		return nil
+	}
+
+	var intent *models.PaymentIntent
+	for _, matched := range matchedIntents {
+		intent = matched
+	}
+	for _, installment := range matchedInstallments {
+		intent, err = createStatementIntent(ctx, repo, item, installment)
+		if err != nil {
+			return err
+		}
+	}
+
+	payment, err := paymentUC.SettleIntent(ctx, intent.PaymentIntentID.String(), item.TransactionDate)
+	if err != nil {
+		var e *httperror.Error
+		if errors.As(err, &e) {
+			item.Note = e.Error()
+			return nil
+		}
+		return err
+	}
+
+	item.PaymentIntentID = &intent.PaymentIntentID
+	item.PaymentID = &payment.PaymentID
+	item.ReconciliationStatusID = 3
+	item.Note = ""
+
+	return nil
+}
+
+func createStatementIntent(ctx context.Context, repo admin.Repository, item *models.ReconciliationItem, installment *models.Installment) (*models.PaymentIntent, error) {
+	fine := installment.Fine(item.TransactionDate, installment.Lending.FinePerDay)
+	intent := &models.PaymentIntent{
+		InstallmentID: installment.InstallmentID,
+		Provider:      "bank_statement",
+		Channel:       "bank_transfer",
+		ExternalID:    item.ReconciliationItemID.String(),
+		AccountNumber: item.Reference,
+		PaymentFine:   fine,
+		PaymentAmount: installment.Amount + fine,
+		ExpireDate:    item.TransactionDate,
+	}
+	if err := intent.PrepareCreate(); err != nil {
+		return intent, err
+	}
+
+	intent, err := repo.CreatePaymentIntent(ctx, intent)
+	if err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (u *adminUC) GetDebtorScore(ctx context.Context, debtorID string) (*scoring.Result, error) {
+	debtor, err := u.adminRepo.GetDebtorByID(ctx, debtorID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.DebtorIDNotExist)
+		}
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	result, err := u.scoreDebtor(ctx, debtor, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ProposeCreditLimits scores every debtor with a confirmed contract and
+// records a pending proposal wherever the recommended limit differs from
+// the current one. Debtors that already have a pending proposal are skipped.
+func (u *adminUC) ProposeCreditLimits(ctx context.Context) (int, error) {
+	debtors, err := u.adminRepo.GetScorableDebtors(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	proposed := 0
+	for _, debtor := range debtors {
+		pending, err := u.adminRepo.CheckPendingCreditLimitProposal(ctx, debtor.DebtorID.String())
+		if err != nil {
+			return proposed, err
+		}
+		if pending {
+			continue
+		}
+
+		result, err := u.scoreDebtor(ctx, debtor, now)
+		if err != nil {
+			return proposed, err
+		}
+		if result.RecommendedLimit == debtor.CreditLimit {
+			continue
+		}
+
+		proposal := &models.CreditLimitProposal{
+			DebtorID:      debtor.DebtorID,
+			RulesVersion:  result.Version,
+			Score:         result.Score,
+			CurrentLimit:  debtor.CreditLimit,
+			ProposedLimit: result.RecommendedLimit,
+		}
+		if err := proposal.PrepareCreate(); err != nil {
+			return proposed, err
+		}
+
+		if _, err := u.adminRepo.CreateCreditLimitProposal(ctx, proposal); err != nil {
+			return proposed, err
+		}
+		proposed++
+	}
+
+	return proposed, nil
+}
+
+func (u *adminUC) GetCreditLimitProposals(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	proposals, err := u.adminRepo.GetCreditLimitProposals(ctx, status, pagination)
+	if err != nil {
+		return proposals, err
+	}
+
+	return proposals, nil
+}
+
+func (u *adminUC) RespondCreditLimitProposal(ctx context.Context, userID, proposalID string, body body.RespondCreditLimitProposalRequest) (*models.CreditLimitProposal, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	var proposal *models.CreditLimitProposal
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		proposal, err = repo.GetCreditLimitProposalForUpdate(ctx, proposalID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return httperror.New(http.StatusBadRequest, response.CreditLimitProposalNotExist)
+			}
+			return err
+		}
+
+		if proposal.CreditLimitProposalStatusID != 1 {
+			return httperror.New(http.StatusBadRequest, response.CreditLimitProposalResponded)
+		}
+
+		proposal.CreditLimitProposalStatusID = 3
+		if body.Action == "approve" {
+			debtor, err := lockDebtor(ctx, repo, proposal.DebtorID.String())
+			if err != nil {
+				return err
+			}
+
+			if debtor.CreditLimit != proposal.CurrentLimit {
+				return httperror.New(http.StatusBadRequest, response.CreditLimitProposalOutdated)
+			}
+
+			debtor.CreditLimit = proposal.ProposedLimit
+			if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+				return err
+			}
+			proposal.CreditLimitProposalStatusID = 2
+		}
+
+		proposal.UserID = &adminID
+		proposal.RespondedAt = &now
+		proposal, err = repo.UpdateCreditLimitProposal(ctx, proposal)
+		return err
+	})
+	if err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+func (u *adminUC) scoreDebtor(ctx context.Context, debtor *models.Debtor, at time.Time) (*scoring.Result, error) {
+	payments, err := u.adminRepo.GetSettledPaymentsByDebtorID(ctx, debtor.DebtorID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	repayments := make([]scoring.Repayment, 0, len(payments))
+	for _, payment := range payments {
+		repayments = append(repayments, scoring.Repayment{
+			DueDate:     payment.Installment.DueDate,
+			PaymentDate: payment.PaymentDate,
+		})
+	}
+
+	result := u.rules.Score(scoring.Input{
+		Repayments:  repayments,
+		TotalDelay:  debtor.TotalDelay,
+		CreditUsed:  debtor.CreditUsed,
+		CreditLimit: debtor.CreditLimit,
+		Since:       debtor.CreatedAt,
+		At:          at,
+	})
+
+	return &result, nil
+}
+
+func (u *adminUC) GetKycSubmissions(ctx context.Context, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
+	submissions, err := u.adminRepo.GetKycSubmissions(ctx, status, pagination)
+	if err != nil {
+		return submissions, err
+	}
+
+	return submissions, nil
+}
+
+func (u *adminUC) GetKycDocument(ctx context.Context, submissionID, document string) (io.ReadCloser, string, error) {
+	submission, err := u.adminRepo.GetKycSubmissionByID(ctx, submissionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, "", httperror.New(http.StatusBadRequest, response.KycSubmissionNotExist)
+		}
+		return nil, "", err
+	}
+
+	var key string
+	switch document {
+	case "id-card":
+		key = submission.IDCardKey
+	case "selfie":
+		key = submission.SelfieKey
+	default:
+		return nil, "", httperror.New(http.StatusBadRequest, response.KycDocumentNotExist)
+	}
+
+	file, err := u.storage.Open(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, key, nil
+}
+
+func (u *adminUC) ReviewKyc(ctx context.Context, userID, submissionID string, body body.ReviewKycRequest) (*models.KycSubmission, error) {
+	adminID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	var submission *models.KycSubmission
+	err = u.adminRepo.Transaction(ctx, func(repo admin.Repository) error {
+		submission, err = repo.GetKycSubmissionForUpdate(ctx, submissionID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return httperror.New(http.StatusBadRequest, response.KycSubmissionNotExist)
+			}
+			return err
+		}
+
+		if submission.KycStatusID != 2 {
+			return httperror.New(http.StatusBadRequest, response.KycAlreadyReviewed)
+		}
+
+		debtor, err := lockDebtor(ctx, repo, submission.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		submission.KycStatusID = 3
+		if body.Action == "reject" {
+			submission.KycStatusID = 4
+			submission.RejectReason = body.Reason
+		}
+		submission.UserID = &adminID
+		submission.ReviewedAt = &now
+
+		debtor.KycStatusID = submission.KycStatusID
+		if _, err := repo.UpdateDebtorByID(ctx, debtor); err != nil {
+			return err
+		}
+
+		submission, err = repo.UpdateKycSubmission(ctx, submission)
+		return err
+	})
+	if err != nil {
+		return submission, err
+	}
+
+	return submission, nil
+}
+
+func (u *adminUC) GetRejectionReasons(ctx context.Context) ([]*models.RejectionReason, error) {
+	reasons, err := u.adminRepo.GetRejectionReasons(ctx)
+	if err != nil {
+		return reasons, err
+	}
+
+	return reasons, nil
+}
+
+func (u *adminUC) CreateRejectionReason(ctx context.Context, body body.CreateRejectionReasonRequest) (*models.RejectionReason, error) {
+	exist, err := u.adminRepo.CheckRejectionReasonCodeExist(ctx, body.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return nil, httperror.New(http.StatusBadRequest, response.RejectionReasonCodeExist)
+	}
+
+	reason := &models.RejectionReason{
+		Code:     body.Code,
+		Name:     body.Name,
+		IsActive: true,
+	}
+
+	reason, err = u.adminRepo.CreateRejectionReason(ctx, reason)
+	if err != nil {
+		return reason, err
+	}
+
+	return reason, nil
+}
+
+func (u *adminUC) UpdateRejectionReasonByID(ctx context.Context, reasonID string, body body.UpdateRejectionReasonRequest) (*models.RejectionReason, error) {
+	id, err := strconv.Atoi(reasonID)
+	if err != nil {
+		return nil, httperror.New(http.StatusBadRequest, response.RejectionReasonNotExist)
+	}
+
+	reason, err := u.adminRepo.GetRejectionReasonByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.RejectionReasonNotExist)
+		}
+		return nil, err
+	}
+
+	reason.Name = body.Name
+	reason.IsActive = body.IsActive
+	reason, err = u.adminRepo.UpdateRejectionReason(ctx, reason)
+	if err != nil {
+		return reason, err
+	}
+
+	return reason, nil
+}
+
+func (u *adminUC) GetRejectionReport(ctx context.Context, period string, from, to time.Time) (*body.RejectionReportResponse, error) {
+	report := &body.RejectionReportResponse{
+		Period: period,
+		From:   from,
+		To:     to,
+		Rows:   []*models.RejectionReasonCount{},
+	}
+
+	counts, err := u.adminRepo.GetRejectionReasonCounts(ctx, period, from, to)
+	if err != nil {
+		return report, err
+	}
+
+	if len(counts) > 0 {
+		report.Rows = counts
+	}
+
+	return report, nil
+}
+
+func (u *adminUC) ExportLoans(ctx context.Context, name string, status []int, sort, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, []export.Column{
+		{Name: "Lending ID", Kind: export.Text},
+		{Name: "Debtor", Kind: export.Text},
+		{Name: "Debtor Email", Kind: export.Text},
+		{Name: "Lending", Kind: export.Text},
+		{Name: "Loan Product", Kind: export.Text},
+		{Name: "Status", Kind: export.Text},
+		{Name: "Amount", Kind: export.Money},
+		{Name: "Duration", Kind: export.Integer},
+		{Name: "Percentage", Kind: export.Integer},
+		{Name: "Created At", Kind: export.Date},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.adminRepo.ExportLoans(ctx, name, status, sort, func(row *models.LoanExport) error {
+		return writer.Write(row.LendingID.String(), row.DebtorName, row.DebtorEmail, row.Name, row.LoanProductName,
+			row.LendingStatus, row.Amount, row.Duration, row.Percentage, row.CreatedAt)
+	})
+	if err != nil {
+		writer.Abort()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (u *adminUC) ExportPayments(ctx context.Context, name, sort, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, []export.Column{
+		{Name: "Payment ID", Kind: export.Text},
+		{Name: "Debtor", Kind: export.Text},
+		{Name: "Lending", Kind: export.Text},
+		{Name: "Installment Due Date", Kind: export.Date},
+		{Name: "Voucher", Kind: export.Text},
+		{Name: "Amount", Kind: export.Money},
+		{Name: "Discount", Kind: export.Money},
+		{Name: "Fine", Kind: export.Money},
+		{Name: "Fine Waiver", Kind: export.Money},
+		{Name: "Payment Date", Kind: export.Date},
+		{Name: "Reversed At", Kind: export.Date},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.adminRepo.ExportPayments(ctx, name, sort, func(row *models.PaymentExport) error {
+		voucher := ""
+		if row.VoucherName != nil {
+			voucher = *row.VoucherName
+		}
+
+		return writer.Write(row.PaymentID.String(), row.DebtorName, row.LendingName, row.InstallmentDue, voucher,
+			row.PaymentAmount, row.PaymentDiscount, row.PaymentFine, row.PaymentFineWaiver, row.PaymentDate, row.ReversedAt)
+	})
+	if err != nil {
+		writer.Abort()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (u *adminUC) ExportDebtors(ctx context.Context, name, sort, format string, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, []export.Column{
+		{Name: "Debtor ID", Kind: export.Text},
+		{Name: "Name", Kind: export.Text},
+		{Name: "Email", Kind: export.Text},
+		{Name: "Phone Number", Kind: export.Text},
+		{Name: "Credit Health", Kind: export.Text},
+		{Name: "Contract Status", Kind: export.Text},
+		{Name: "Credit Limit", Kind: export.Money},
+		{Name: "Credit Used", Kind: export.Money},
+		{Name: "Total Delay", Kind: export.Integer},
+		{Name: "Created At", Kind: export.Date},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.adminRepo.ExportDebtors(ctx, name, sort, func(row *models.DebtorExport) error {
+		return writer.Write(row.DebtorID.String(), row.Name, row.Email, row.PhoneNumber, row.CreditHealth,
+			row.ContractTracking, row.CreditLimit, row.CreditUsed, row.TotalDelay, row.CreatedAt)
+	})
+	if err != nil {
+		writer.Abort()
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (u *adminUC) GetAnalytics(ctx context.Context, granularity string, from, to time.Time) (*body.AnalyticsResponse, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	analytics := &body.AnalyticsResponse{
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+		Totals:      &body.AnalyticsPoint{},
+		Series:      []*body.AnalyticsPoint{},
+	}
+
+	// Every period is listed, including empty ones, so charts have no gaps.
+	points := map[string]*body.AnalyticsPoint{}
+	for period := truncatePeriod(from.In(loc), granularity); period.Before(to); period = nextPeriod(period, granularity) {
+		point := &body.AnalyticsPoint{Period: period}
+		points[period.Format("2006-01-02")] = point
+		analytics.Series = append(analytics.Series, point)
+	}
+
+	pointAt := func(period time.Time) *body.AnalyticsPoint {
+		// Periods come back as Jakarta wall time without a zone.
+		key := time.Date(period.Year(), period.Month(), period.Day(), 0, 0, 0, 0, loc).Format("2006-01-02")
+		if point, ok := points[key]; ok {
+			return point
+		}
+		return &body.AnalyticsPoint{}
+	}
+
+	decisions, err := u.adminRepo.GetLendingDecisionAggregates(ctx, granularity, from, to)
+	if err != nil {
+		return analytics, err
+	}
+	for _, decision := range decisions {
+		point := pointAt(decision.Period)
+		point.DisbursedAmount += decision.Disbursed
+		point.AddApprovals(decision.Approved, decision.Rejected, decision.ApprovalSeconds, decision.TimedApprovals)
+
+		analytics.Totals.DisbursedAmount += decision.Disbursed
+		analytics.Totals.AddApprovals(decision.Approved, decision.Rejected, decision.ApprovalSeconds, decision.TimedApprovals)
+	}
+
+	payments, err := u.adminRepo.GetPaymentAggregates(ctx, granularity, from, to)
+	if err != nil {
+		return analytics, err
+	}
+	for _, payment := range payments {
+		point := pointAt(payment.Period)
+		point.RepaidAmount += payment.Repaid
+		point.FinesCollected += payment.Fines
+		point.VoucherDiscounts += payment.Discounts
+
+		analytics.Totals.RepaidAmount += payment.Repaid
+		analytics.Totals.FinesCollected += payment.Fines
+		analytics.Totals.VoucherDiscounts += payment.Discounts
+	}
+
+	debtors, err := u.adminRepo.GetDebtorAggregates(ctx, granularity, from, to)
+	if err != nil {
+		return analytics, err
+	}
+	for _, debtor := range debtors {
+		pointAt(debtor.Period).NewDebtors += debtor.NewDebtors
+		analytics.Totals.NewDebtors += debtor.NewDebtors
+	}
+
+	return analytics, nil
+}
+
+// truncatePeriod matches Postgres date_trunc, whose weeks start on Monday.
+func truncatePeriod(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// Risk reports are taken at the end of the asOf day. Every report is rebuilt
+// from the payment and installment history, so a past date gives the same
+// result whenever it is run.
+
+func (u *adminUC) GetAgingReport(ctx context.Context, asOf time.Time) (*body.AgingReport, error) {
+	at := asOf.AddDate(0, 0, 1)
+	report := &body.AgingReport{AsOf: asOf, Rows: []*body.AgingRow{}}
+
+	positions, err := u.adminRepo.GetLoanPositions(ctx, at)
+	if err != nil {
+		return report, err
+	}
+
+	rows := map[string]*body.AgingRow{}
+	for _, bucket := range models.RiskBuckets() {
+		rows[bucket] = &body.AgingRow{Bucket: bucket}
+		report.Rows = append(report.Rows, rows[bucket])
+	}
+
+	for _, position := range positions {
+		row, ok := rows[position.Bucket(at)]
+		if !ok {
+			continue
+		}
+
+		row.Loans++
+		row.Outstanding += position.Outstanding
+		report.TotalLoans++
+		report.TotalOutstanding += position.Outstanding
+	}
+
+	for _, row := range report.Rows {
+		row.Share = ratio(row.Outstanding, report.TotalOutstanding)
+	}
+
+	return report, nil
+}
+
+func (u *adminUC) GetParReport(ctx context.Context, asOf time.Time) (*body.ParReport, error) {
+	at := asOf.AddDate(0, 0, 1)
+	report := &body.ParReport{AsOf: asOf, Rows: []*body.ParRow{}}
+
+	positions, err := u.adminRepo.GetLoanPositions(ctx, at)
+	if err != nil {
+		return report, err
+	}
+
+	// PAR n is the share of the book held by loans more than n days late,
+	// taken where each delinquency bucket starts. Any arrears is PAR1.
+	for _, bucket := range models.DelinquencyBuckets {
+		days := bucket.MinDays - 1
+		if days == 0 {
+			days = 1
+		}
+		report.Rows = append(report.Rows, &body.ParRow{Name: fmt.Sprintf("PAR%d", days), MinDays: bucket.MinDays})
+	}
+
+	for _, position := range positions {
+		if position.WrittenOff {
+			continue
+		}
+
+		report.TotalOutstanding += position.Outstanding
+		daysPastDue := position.DaysPastDue(at)
+		for _, row := range report.Rows {
+			if daysPastDue >= row.MinDays {
+				row.Loans++
+				row.Outstanding += position.Outstanding
+			}
+		}
+	}
+
+	for _, row := range report.Rows {
+		row.Ratio = ratio(row.Outstanding, report.TotalOutstanding)
+	}
+
+	return report, nil
+}
+
+func (u *adminUC) GetRollRateReport(ctx context.Context, asOf time.Time, months int) (*body.RollRateReport, error) {
+	report := &body.RollRateReport{AsOf: asOf, Months: months, Rows: []*body.RollRateRow{}}
+
+	// Only whole months are compared, ending with the last month closed by
+	// the end of the asOf day.
+	last := startOfMonth(asOf.AddDate(0, 0, 1))
+	var previous map[uuid.UUID]*models.LoanPosition
+	for i := months; i >= 0; i-- {
+		at := last.AddDate(0, -i, 0)
+		positions, err := u.loanPositions(ctx, at)
+		if err != nil {
+			return report, err
+		}
+
+		if previous != nil {
+			report.Rows = append(report.Rows, rollRates(at.AddDate(0, -1, 0), previous, positions)...)
+		}
+		previous = positions
+	}
+
+	return report, nil
+}
+
+func (u *adminUC) GetVintageReport(ctx context.Context, asOf time.Time, months int) (*body.VintageReport, error) {
+	report := &body.VintageReport{AsOf: asOf, Months: months, Rows: []*body.VintageRow{}}
+
+	// Cohorts are the months originated before the last month closed by the
+	// end of the asOf day, so each has at least one month on book.
+	last := startOfMonth(asOf.AddDate(0, 0, 1))
+	first := last.AddDate(0, -months, 0)
+
+	cohorts, err := u.adminRepo.GetLoanCohorts(ctx, first, last)
+	if err != nil {
+		return report, err
+	}
+	if len(cohorts) == 0 {
+		return report, nil
+	}
+
+	cohortByMonth := map[string]*models.LoanCohort{}
+	for _, cohort := range cohorts {
+		cohortByMonth[cohort.Cohort.Format("2006-01")] = cohort
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	for at := first.AddDate(0, 1, 0); !at.After(last); at = at.AddDate(0, 1, 0) {
+		positions, err := u.loanPositions(ctx, at)
+		if err != nil {
+			return report, err
+		}
+
+		// Loans more than 30 days late or written off count as bad.
+		bad := map[string]float64{}
+		for _, position := range positions {
+			if position.WrittenOff || position.DaysPastDue(at) > 30 {
+				bad[position.Cohort.Format("2006-01")] += position.Outstanding
+			}
+		}
+
+		for cohortStart := first; cohortStart.Before(at); cohortStart = cohortStart.AddDate(0, 1, 0) {
+			key := cohortStart.Format("2006-01")
+			cohort, ok := cohortByMonth[key]
+			if !ok {
+				continue
+			}
+
+			report.Rows = append(report.Rows, &body.VintageRow{
+				Cohort:         time.Date(cohortStart.Year(), cohortStart.Month(), 1, 0, 0, 0, 0, loc),
+				Loans:          int(cohort.Loans),
+				Amount:         cohort.Amount,
+				MonthsOnBook:   monthsBetween(cohortStart, at),
+				BadOutstanding: bad[key],
+				BadRate:        ratio(bad[key], cohort.Amount),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Cohort.Before(report.Rows[j].Cohort)
+	})
+
+	return report, nil
+}
+
+func (u *adminUC) loanPositions(ctx context.Context, at time.Time) (map[uuid.UUID]*models.LoanPosition, error) {
+	positions, err := u.adminRepo.GetLoanPositions(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	positionByLending := map[uuid.UUID]*models.LoanPosition{}
+	for _, position := range positions {
+		positionByLending[position.LendingID] = position
+	}
+
+	return positionByLending, nil
+}
+
+// rollRates compares where loans stood at the start and the end of a month.
+// Loans with nothing left unpaid at the end have closed, and loans already
+// written off at the start are left out as they cannot roll any further.
+func rollRates(month time.Time, from, to map[uuid.UUID]*models.LoanPosition) []*body.RollRateRow {
+	start := month
+	end := month.AddDate(0, 1, 0)
+	toBuckets := append(models.RiskBuckets(), models.RiskBucketWrittenOff, models.RiskBucketClosed)
+
+	rows := map[string]*body.RollRateRow{}
+	totals := map[string]float64{}
+	for _, position := range from {
+		if position.WrittenOff {
+			continue
+		}
+
+		fromBucket := position.Bucket(start)
+		toBucket := models.RiskBucketClosed
+		if next, ok := to[position.LendingID]; ok {
+			toBucket = next.Bucket(end)
+		}
+
+		key := fromBucket + "|" + toBucket
+		if _, ok := rows[key]; !ok {
+			rows[key] = &body.RollRateRow{Month: month, FromBucket: fromBucket, ToBucket: toBucket}
+		}
+		rows[key].Loans++
+		rows[key].Outstanding += position.Outstanding
+		totals[fromBucket] += position.Outstanding
+	}
+
+	var rollRates []*body.RollRateRow
+	for _, fromBucket := range models.RiskBuckets() {
+		for _, toBucket := range toBuckets {
+			row, ok := rows[fromBucket+"|"+toBucket]
+			if !ok {
+				continue
+			}
+
+			row.Rate = ratio(row.Outstanding, totals[fromBucket])
+			rollRates = append(rollRates, row)
+		}
+	}
+
+	return rollRates
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+}
+
+// GetVoucherStats reports how a voucher campaign performed. The series runs
+// from the active date to the expiry, or to now for a running campaign, and
+// falls back to a coarser granularity when a fine one would be too long.
+func (u *adminUC) GetVoucherStats(ctx context.Context, voucherID, granularity string) (*body.VoucherStatsResponse, error) {
+	voucher, err := u.adminRepo.GetVoucherByID(ctx, voucherID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		}
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	from := voucher.ActiveDate.In(loc)
+	to := time.Now().In(loc)
+	if voucher.ExpireDate.Before(to) {
+		to = voucher.ExpireDate.In(loc)
+	}
+
+	days := to.Sub(from).Hours() / 24
+	if granularity == "day" && days > 366 {
+		granularity = "week"
+	}
+	if granularity == "week" && days > 2*366 {
+		granularity = "month"
+	}
+
+	stats := &body.VoucherStatsResponse{
+		Voucher:      voucher,
+		Granularity:  granularity,
+		From:         from,
+		To:           to,
+		VoucherUsers: &body.VoucherRepaymentRate{},
+		NonUsers:     &body.VoucherRepaymentRate{},
+		Series:       []*body.VoucherStatsPoint{},
+	}
+
+	points := map[string]*body.VoucherStatsPoint{}
+	for period := truncatePeriod(from, granularity); period.Before(to); period = nextPeriod(period, granularity) {
+		point := &body.VoucherStatsPoint{Period: period}
+		points[period.Format("2006-01-02")] = point
+		stats.Series = append(stats.Series, point)
+	}
+
+	redemptions, err := u.adminRepo.GetVoucherRedemptionAggregates(ctx, voucherID, granularity)
+	if err != nil {
+		return stats, err
+	}
+	for _, redemption := range redemptions {
+		// Periods come back as Jakarta wall time without a zone.
+		key := time.Date(redemption.Period.Year(), redemption.Period.Month(), redemption.Period.Day(), 0, 0, 0, 0, loc).Format("2006-01-02")
+		if point, ok := points[key]; ok {
+			point.Redemptions += redemption.Redemptions
+			point.LateRedemptions += redemption.LateRedemptions
+			point.DiscountCost += redemption.Discounts
+		}
+
+		stats.Redemptions += redemption.Redemptions
+		stats.LateRedemptions += redemption.LateRedemptions
+		stats.DiscountCost += redemption.Discounts
+	}
+	stats.LateRedemptionShare = ratio(float64(stats.LateRedemptions), float64(stats.Redemptions))
+
+	repayments, err := u.adminRepo.GetVoucherRepaymentAggregates(ctx, voucherID, from, to)
+	if err != nil {
+		return stats, err
+	}
+	for _, repayment := range repayments {
+		rate := stats.NonUsers
+		if repayment.UsedVoucher {
+			rate = stats.VoucherUsers
+		}
+
+		rate.Installments = repayment.Installments
+		rate.OnTime = repayment.OnTime
+		rate.OnTimeRate = ratio(float64(repayment.OnTime), float64(repayment.Installments))
+	}
+
+	return stats, nil
+}
+
+func ratio(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return part / total
+}
+
+// getVoucherSegment loads the credit healths and loan products a voucher is
+// limited to. Empty lists leave the voucher open to everyone.
+func (u *adminUC) getVoucherSegment(ctx context.Context, creditHealthIDs []int, loanProductIDs []string) ([]models.CreditHealthType, []models.LoanProduct, error) {
+	creditHealths := []models.CreditHealthType{}
+	if len(creditHealthIDs) > 0 {
+		var err error
+		creditHealths, err = u.adminRepo.GetCreditHealthTypesByID(ctx, creditHealthIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	loanProducts := []models.LoanProduct{}
+	if len(loanProductIDs) > 0 {
+		var err error
+		loanProducts, err = u.adminRepo.GetLoanProductsByID(ctx, loanProductIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		found := map[string]bool{}
+		for _, loanProduct := range loanProducts {
+			found[loanProduct.LoanProductID.String()] = true
+		}
+		for _, loanProductID := range loanProductIDs {
+			if !found[loanProductID] {
+				return nil, nil, httperror.New(http.StatusBadRequest, response.LoanProductNotExist)
+			}
+		}
+	}
+
+	return creditHealths, loanProducts, nil
+}
+
+func (u *adminUC) GetVoucherCodes(ctx context.Context, voucherID string) (*body.VoucherCodesResponse, error) {
+	voucher, err := u.adminRepo.GetVoucherByID(ctx, voucherID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		}
+		return nil, err
+	}
+
+	codes, err := u.adminRepo.GetVoucherCodes(ctx, voucherID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &body.VoucherCodesResponse{Voucher: voucher, Codes: codes}, nil
+}
+
+func (u *adminUC) CreateVoucherCodes(ctx context.Context, voucherID string, request body.CreateVoucherCodesRequest) (*body.VoucherCodesResponse, error) {
+	voucher, err := u.adminRepo.GetVoucherByID(ctx, voucherID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		}
+		return nil, err
+	}
+
+	var codes []string
+	singleUse := request.Code == ""
+	if singleUse {
+		codes, err = u.generateVoucherCodes(ctx, request.Prefix, request.Count)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		existing, err := u.adminRepo.GetExistingVoucherCodes(ctx, []string{request.Code})
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, httperror.New(http.StatusBadRequest, response.VoucherCodeExist)
+		}
+		codes = []string{request.Code}
+	}
+
+	var voucherCodes []*models.VoucherCode
+	for _, code := range codes {
+		voucherCode := &models.VoucherCode{}
+		if err := voucherCode.PrepareCreate(voucher.VoucherID, code, singleUse); err != nil {
+			return nil, err
+		}
+		voucherCodes = append(voucherCodes, voucherCode)
+	}
+
+	voucherCodes, err = u.adminRepo.CreateVoucherCodes(ctx, voucherCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &body.VoucherCodesResponse{Voucher: voucher, Codes: voucherCodes}, nil
+}
+
+// generateVoucherCodes draws random codes until count of them are unused.
+// Collisions are rare, so a few rounds are plenty.
+func (u *adminUC) generateVoucherCodes(ctx context.Context, prefix string, count int) ([]string, error) {
+	unique := map[string]bool{}
+	for attempt := 0; attempt < 5 && len(unique) < count; attempt++ {
+		var candidates []string
+		for len(unique)+len(candidates) < count {
+			code, err := models.GenerateVoucherCode(prefix)
+			if err != nil {
+				return nil, err
+			}
+			if !unique[code] {
+				candidates = append(candidates, code)
+			}
+		}
+
+		existing, err := u.adminRepo.GetExistingVoucherCodes(ctx, candidates)
+		if err != nil {
+			return nil, err
+		}
+
+		taken := map[string]bool{}
+		for _, code := range existing {
+			taken[code] = true
+		}
+		for _, code := range candidates {
+			if !taken[code] {
+				unique[code] = true
+			}
+		}
+	}
+
+	if len(unique) < count {
+		return nil, errors.New("could not generate unique voucher codes")
+	}
+
+	codes := make([]string, 0, count)
+	for code := range unique {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes, nil
+}
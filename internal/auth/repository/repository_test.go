@@ -0,0 +1,133 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"final-project-backend/internal/auth"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/models/modelstest"
+	"final-project-backend/pkg/postgres/postgrestest"
+	"final-project-backend/pkg/response"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+)
+
+var testDB *gorm.DB
+
+func TestMain(m *testing.M) {
+	db, stop, err := postgrestest.Open()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	testDB = db
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func newRepo(t *testing.T) auth.Repository {
+	t.Helper()
+	require.NoError(t, postgrestest.Reset(testDB))
+
+	return NewAuthRepository(testDB)
+}
+
+func TestRegister(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	user := &models.User{
+		UserID:      uuid.New(),
+		RoleID:      2,
+		Name:        "Budi Santoso",
+		PhoneNumber: "081234567890",
+		Address:     "Jl. Merdeka No. 10",
+		Email:       "budi@example.com",
+		Password:    "hashed",
+	}
+	created, err := repo.Register(ctx, user)
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = repo.Register(ctx, &models.User{
+		UserID:      uuid.New(),
+		RoleID:      2,
+		Name:        "Budi Lain",
+		PhoneNumber: "081234567891",
+		Address:     "Jl. Merdeka No. 11",
+		Email:       "budi@example.com",
+		Password:    "hashed",
+	})
+	assert.EqualError(t, err, response.EmailAlreadyExistMessage)
+}
+
+func TestCreateDebtor(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB)
+
+	debtor := &models.Debtor{}
+	require.NoError(t, debtor.PrepareCreate(user.UserID, 1, 1))
+	created, err := repo.CreateDebtor(ctx, debtor)
+	require.NoError(t, err)
+
+	var stored models.Debtor
+	require.NoError(t, testDB.Where("debtor_id = ?", created.DebtorID).First(&stored).Error)
+	assert.Equal(t, user.UserID, stored.UserID)
+	assert.Equal(t, 1, stored.CreditHealthID)
+	assert.Equal(t, 1, stored.ContractTrackingID)
+	assert.Equal(t, 1, stored.KycStatusID)
+
+	_, err = repo.CreateDebtor(ctx, &models.Debtor{DebtorID: uuid.New(), UserID: uuid.New(), CreditHealthID: 1, ContractTrackingID: 1, KycStatusID: 1})
+	assert.EqualError(t, err, response.ReferencedDataNotExist)
+}
+
+func TestCheckEmailExist(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB, func(u *models.User) { u.Email = "Siti@Example.com" })
+
+	found, err := repo.CheckEmailExist(ctx, &models.User{Email: "siti@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, found.UserID)
+
+	_, err = repo.CheckEmailExist(ctx, &models.User{Email: "nobody@example.com"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestFindByEmail(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB, func(u *models.User) { u.Email = "andi@example.com" })
+
+	found, err := repo.FindByEmail(ctx, &models.User{Email: "ANDI@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, user.UserID, found.UserID)
+	assert.Equal(t, user.Password, found.Password)
+
+	_, err = repo.FindByEmail(ctx, &models.User{Email: "andi@example.org"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetUserDetailsByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	user := modelstest.CreateUser(t, testDB, func(u *models.User) { u.RoleID = 1 })
+
+	found, err := repo.GetUserDetailsByID(ctx, user.UserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, user.Name, found.Name)
+	require.NotNil(t, found.Role)
+	assert.Equal(t, "admin", found.Role.Name)
+
+	_, err = repo.GetUserDetailsByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -7,6 +7,7 @@ import (
 	"final-project-backend/internal/auth/delivery/body"
 	"final-project-backend/internal/models"
 	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/metrics"
 	"final-project-backend/pkg/response"
 	"final-project-backend/pkg/utils"
 	"net/http"
@@ -63,10 +64,12 @@ func (u *authUC) Login(ctx context.Context, body body.LoginRequest) (*models.Use
 
 	foundUser, err := u.authRepo.FindByEmail(ctx, user)
 	if err != nil {
+		metrics.LoginFailed()
 		return nil, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
 	}
 
 	if err = foundUser.ComparePasswords(user.Password); err != nil {
+		metrics.LoginFailed()
 		return nil, httperror.New(http.StatusUnauthorized, response.UnauthorizedMessage)
 	}
 
@@ -0,0 +1,13 @@
+package collection
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	GetDelinquentLoans(c *gin.Context)
+	GetCollectionCase(c *gin.Context)
+	AssignCollector(c *gin.Context)
+	CreateNote(c *gin.Context)
+	CreatePromise(c *gin.Context)
+	GetCollectors(c *gin.Context)
+	CreateCollector(c *gin.Context)
+}
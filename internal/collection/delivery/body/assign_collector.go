@@ -0,0 +1,37 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+type AssignCollectorRequest struct {
+	CollectorID string `json:"collector_id"`
+}
+
+func (r *AssignCollectorRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"collector_id": "",
+		},
+	}
+
+	r.CollectorID = strings.TrimSpace(r.CollectorID)
+	if _, err := uuid.Parse(r.CollectorID); err != nil {
+		unprocessableEntity = true
+		entity.Fields["collector_id"] = InvalidCollectorIDFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,18 @@
+package body
+
+const (
+	InvalidCollectorIDFormatMessage = "Invalid collector id format."
+	InvalidChannelFormatMessage     = "Invalid channel format."
+	InvalidNoteFormatMessage        = "Invalid note format."
+	InvalidAmountFormatMessage      = "Invalid amount format."
+	InvalidDateFormatMessage        = "Invalid promise date format."
+	InvalidNameFormatMessage        = "Invalid name format."
+	InvalidPhoneNumberFormatMessage = "Invalid phone number format."
+	InvalidAddressFormatMessage     = "Invalid address format."
+	InvalidEmailFormatMessage       = "Invalid email format."
+	InvalidPasswordFormatMessage    = "Invalid password format."
+)
+
+type UnprocessableEntity struct {
+	Fields map[string]string `json:"fields"`
+}
@@ -0,0 +1,46 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type CreateNoteRequest struct {
+	Channel string `json:"channel"`
+	Note    string `json:"note"`
+}
+
+func (r *CreateNoteRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"channel": "",
+			"note":    "",
+		},
+	}
+
+	r.Channel = strings.ToLower(strings.TrimSpace(r.Channel))
+	switch r.Channel {
+	case "call", "sms", "email", "whatsapp", "visit":
+	default:
+		unprocessableEntity = true
+		entity.Fields["channel"] = InvalidChannelFormatMessage
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Note == "" {
+		unprocessableEntity = true
+		entity.Fields["note"] = InvalidNoteFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,48 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type CreatePromiseRequest struct {
+	Amount          float64   `json:"amount"`
+	PromiseDate     string    `json:"promise_date"`
+	PromiseDateTime time.Time `json:"-"`
+}
+
+func (r *CreatePromiseRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"amount":       "",
+			"promise_date": "",
+		},
+	}
+
+	if r.Amount <= 0 {
+		unprocessableEntity = true
+		entity.Fields["amount"] = InvalidAmountFormatMessage
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	r.PromiseDate = strings.TrimSpace(r.PromiseDate)
+	t, err := time.ParseInLocation("02-01-2006 15:04:05", r.PromiseDate, loc)
+	if err != nil || t.Before(time.Now().In(loc)) {
+		unprocessableEntity = true
+		entity.Fields["promise_date"] = InvalidDateFormatMessage
+	}
+
+	r.PromiseDateTime = t
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,10 @@
+package body
+
+import "final-project-backend/internal/models"
+
+type DelinquentLoan struct {
+	Lending        *models.Lending        `json:"lending"`
+	DaysPastDue    int                    `json:"days_past_due"`
+	Bucket         string                 `json:"bucket"`
+	CollectionCase *models.CollectionCase `json:"collection_case"`
+}
@@ -0,0 +1,285 @@
+package delivery
+
+import (
+	"errors"
+	"final-project-backend/config"
+	"final-project-backend/internal/collection"
+	"final-project-backend/internal/collection/delivery/body"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/logger"
+	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/utils"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type collectionHandlers struct {
+	cfg          *config.Config
+	collectionUC collection.UseCase
+	logger       logger.Logger
+}
+
+func NewCollectionHandlers(cfg *config.Config, collectionUC collection.UseCase, log logger.Logger) collection.Handlers {
+	return &collectionHandlers{cfg: cfg, collectionUC: collectionUC, logger: log}
+}
+
+func (h *collectionHandlers) GetDelinquentLoans(c *gin.Context) {
+	userID, roleID, exist := h.getUser(c)
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	pagination := &utils.Pagination{}
+	bucket := h.ValidateQueryDelinquentLoans(c, pagination)
+
+	loans, err := h.collectionUC.GetDelinquentLoans(c, userID, roleID, bucket, pagination)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, loans, http.StatusOK)
+}
+
+func (h *collectionHandlers) ValidateQueryDelinquentLoans(c *gin.Context, pagination *utils.Pagination) string {
+	bucket := strings.TrimSpace(c.Query("bucket"))
+	sort := strings.TrimSpace(c.Query("sort"))
+	sortBy := strings.TrimSpace(c.Query("sortBy"))
+	limit := strings.TrimSpace(c.Query("limit"))
+	page := strings.TrimSpace(c.Query("page"))
+
+	var sortFilter string
+	var limitFilter int
+	var pageFilter int
+
+	switch sort {
+	case "asc":
+		sortFilter = sort
+	default:
+		sortFilter = "desc"
+	}
+
+	switch sortBy {
+	case "amount":
+		pagination.Sort = fmt.Sprintf("lendings.amount %s", sortFilter)
+	default:
+		// The longest overdue loan has the oldest unpaid due date.
+		if sortFilter == "desc" {
+			pagination.Sort = "overdue.oldest_due_date asc"
+		} else {
+			pagination.Sort = "overdue.oldest_due_date desc"
+		}
+	}
+
+	limitFilter, err := strconv.Atoi(limit)
+	if err != nil || limitFilter < 1 {
+		limitFilter = 10
+	}
+
+	pageFilter, err = strconv.Atoi(page)
+	if err != nil || pageFilter < 1 {
+		pageFilter = 1
+	}
+
+	pagination.Limit = limitFilter
+	pagination.Page = pageFilter
+
+	return bucket
+}
+
+func (h *collectionHandlers) GetCollectionCase(c *gin.Context) {
+	id := c.Param("id")
+	userID, roleID, exist := h.getUser(c)
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	collectionCase, err := h.collectionUC.GetCollectionCase(c, userID, roleID, id)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, collectionCase, http.StatusOK)
+}
+
+func (h *collectionHandlers) AssignCollector(c *gin.Context) {
+	id := c.Param("id")
+	var requestBody body.AssignCollectorRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	collectionCase, err := h.collectionUC.AssignCollector(c, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, collectionCase, http.StatusOK)
+}
+
+func (h *collectionHandlers) CreateNote(c *gin.Context) {
+	id := c.Param("id")
+	userID, roleID, exist := h.getUser(c)
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.CreateNoteRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	note, err := h.collectionUC.CreateNote(c, userID, roleID, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, note, http.StatusOK)
+}
+
+func (h *collectionHandlers) CreatePromise(c *gin.Context) {
+	id := c.Param("id")
+	userID, roleID, exist := h.getUser(c)
+	if !exist {
+		response.ErrorResponse(c.Writer, response.UnauthorizedMessage, http.StatusUnauthorized)
+		return
+	}
+
+	var requestBody body.CreatePromiseRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	promise, err := h.collectionUC.CreatePromise(c, userID, roleID, id, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, promise, http.StatusOK)
+}
+
+func (h *collectionHandlers) GetCollectors(c *gin.Context) {
+	collectors, err := h.collectionUC.GetCollectors(c)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, collectors, http.StatusOK)
+}
+
+func (h *collectionHandlers) CreateCollector(c *gin.Context) {
+	var requestBody body.CreateCollectorRequest
+	if err := c.ShouldBind(&requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	collector, err := h.collectionUC.CreateCollector(c, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerRegister, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, collector, http.StatusCreated)
+}
+
+func (h *collectionHandlers) getUser(c *gin.Context) (string, int, bool) {
+	userID, exist := c.Get("userID")
+	if !exist {
+		return "", 0, false
+	}
+
+	roleID, exist := c.Get("roleID")
+	if !exist {
+		return "", 0, false
+	}
+
+	return userID.(string), int(roleID.(float64)), true
+}
@@ -0,0 +1,19 @@
+package delivery
+
+import (
+	"final-project-backend/internal/collection"
+	"final-project-backend/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func MapCollectionRoutes(collectionGroup *gin.RouterGroup, h collection.Handlers, mw *middleware.MWManager) {
+	collectionGroup.Use(mw.AuthJWTMiddleware())
+	collectionGroup.Use(mw.CollectorMiddleware())
+	collectionGroup.GET("/", h.GetDelinquentLoans)
+	collectionGroup.GET("/collectors", mw.AdminMiddleware(), h.GetCollectors)
+	collectionGroup.POST("/collectors", mw.AdminMiddleware(), h.CreateCollector)
+	collectionGroup.GET("/:id", h.GetCollectionCase)
+	collectionGroup.PUT("/:id", mw.AdminMiddleware(), h.AssignCollector)
+	collectionGroup.POST("/:id/notes", h.CreateNote)
+	collectionGroup.POST("/:id/promises", h.CreatePromise)
+}
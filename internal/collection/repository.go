@@ -0,0 +1,24 @@
+package collection
+
+import (
+	"context"
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	GetDelinquentLoans(ctx context.Context, collectorID string, dueBefore, dueAfter time.Time, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetLendingByID(ctx context.Context, lendingID string) (*models.Lending, error)
+	GetCollectionCaseByLendingID(ctx context.Context, lendingID string) (*models.CollectionCase, error)
+	GetCollectionCasesByLendingID(ctx context.Context, lendingIDs []uuid.UUID) ([]*models.CollectionCase, error)
+	CreateCollectionCase(ctx context.Context, collectionCase *models.CollectionCase) (*models.CollectionCase, error)
+	UpdateCollectionCase(ctx context.Context, collectionCase *models.CollectionCase) (*models.CollectionCase, error)
+	CreateNote(ctx context.Context, note *models.CollectionNote) (*models.CollectionNote, error)
+	CreatePromise(ctx context.Context, promise *models.PromiseToPay) (*models.PromiseToPay, error)
+	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	GetCollectors(ctx context.Context) ([]*models.User, error)
+	CheckEmailExist(ctx context.Context, email string) (bool, error)
+	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
+}
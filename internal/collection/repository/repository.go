@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"final-project-backend/internal/collection"
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"math"
+	"time"
+)
+
+type collectionRepo struct {
+	db *gorm.DB
+}
+
+func NewCollectionRepository(db *gorm.DB) collection.Repository {
+	return &collectionRepo{db: db}
+}
+
+// delinquentLoans selects approved or on progress lendings whose oldest unpaid
+// installment fell due before dueBefore, and after dueAfter when it is set.
+func (r *collectionRepo) delinquentLoans(ctx context.Context, collectorID string, dueBefore, dueAfter time.Time) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Lending{}).
+		Joins("inner join (select lending_id, min(due_date) as oldest_due_date from installments where installment_status_id = 1 group by lending_id) overdue on overdue.lending_id = lendings.lending_id").
+		Where("lendings.lending_status_id IN ?", []int{2, 3}).
+		Where("overdue.oldest_due_date < ?", dueBefore)
+
+	if !dueAfter.IsZero() {
+		query = query.Where("overdue.oldest_due_date > ?", dueAfter)
+	}
+
+	if collectorID != "" {
+		query = query.
+			Joins("inner join collection_cases on collection_cases.lending_id = lendings.lending_id").
+			Where("collection_cases.collector_id = ?", collectorID)
+	}
+
+	return query
+}
+
+func (r *collectionRepo) GetDelinquentLoans(ctx context.Context, collectorID string, dueBefore, dueAfter time.Time, pagination *utils.Pagination) (*utils.Pagination, error) {
+	var loans []*models.Lending
+
+	var totalRows int64
+	r.delinquentLoans(ctx, collectorID, dueBefore, dueAfter).Count(&totalRows)
+
+	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = totalPages
+
+	if err := r.delinquentLoans(ctx, collectorID, dueBefore, dueAfter).
+		Preload("Debtor.User").
+		Preload("LendingStatus").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
+		Find(&loans).Error; err != nil {
+		return pagination, err
+	}
+
+	pagination.Rows = loans
+	return pagination, nil
+}
+
+func (r *collectionRepo) GetLendingByID(ctx context.Context, lendingID string) (*models.Lending, error) {
+	lending := &models.Lending{}
+	if err := r.db.WithContext(ctx).
+		Preload("Debtor.User").
+		Preload("LendingStatus").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Where("lending_id = ?", lendingID).First(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
+func (r *collectionRepo) GetCollectionCaseByLendingID(ctx context.Context, lendingID string) (*models.CollectionCase, error) {
+	collectionCase := &models.CollectionCase{}
+	if err := r.db.WithContext(ctx).
+		Preload("Collector").
+		Preload("Notes.User").
+		Preload("Notes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("collection_notes.created_at desc")
+		}).
+		Preload("Promises.User").
+		Preload("Promises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("promise_to_pays.promise_date desc")
+		}).
+		Where("lending_id = ?", lendingID).First(collectionCase).Error; err != nil {
+		return collectionCase, err
+	}
+
+	return collectionCase, nil
+}
+
+func (r *collectionRepo) GetCollectionCasesByLendingID(ctx context.Context, lendingIDs []uuid.UUID) ([]*models.CollectionCase, error) {
+	var collectionCases []*models.CollectionCase
+	if len(lendingIDs) == 0 {
+		return collectionCases, nil
+	}
+
+	if err := r.db.WithContext(ctx).Preload("Collector").
+		Where("lending_id IN ?", lendingIDs).Find(&collectionCases).Error; err != nil {
+		return collectionCases, err
+	}
+
+	return collectionCases, nil
+}
+
+func (r *collectionRepo) CreateCollectionCase(ctx context.Context, collectionCase *models.CollectionCase) (*models.CollectionCase, error) {
+	if err := r.db.WithContext(ctx).Create(collectionCase).Error; err != nil {
+		return collectionCase, err
+	}
+
+	return collectionCase, nil
+}
+
+func (r *collectionRepo) UpdateCollectionCase(ctx context.Context, collectionCase *models.CollectionCase) (*models.CollectionCase, error) {
+	if err := r.db.Omit("Collector", "Notes", "Promises").WithContext(ctx).Where("collection_case_id = ?", collectionCase.CollectionCaseID).Save(collectionCase).Error; err != nil {
+		return collectionCase, err
+	}
+
+	collectionCase, err := r.GetCollectionCaseByLendingID(ctx, collectionCase.LendingID.String())
+	if err != nil {
+		return collectionCase, err
+	}
+
+	return collectionCase, nil
+}
+
+func (r *collectionRepo) CreateNote(ctx context.Context, note *models.CollectionNote) (*models.CollectionNote, error) {
+	if err := r.db.WithContext(ctx).Create(note).Error; err != nil {
+		return note, err
+	}
+
+	return note, nil
+}
+
+func (r *collectionRepo) CreatePromise(ctx context.Context, promise *models.PromiseToPay) (*models.PromiseToPay, error) {
+	if err := r.db.WithContext(ctx).Create(promise).Error; err != nil {
+		return promise, err
+	}
+
+	return promise, nil
+}
+
+func (r *collectionRepo) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	user := &models.User{}
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(user).Error; err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (r *collectionRepo) GetCollectors(ctx context.Context) ([]*models.User, error) {
+	var collectors []*models.User
+	if err := r.db.WithContext(ctx).Where("role_id = ?", 3).Order("name asc").Find(&collectors).Error; err != nil {
+		return collectors, err
+	}
+
+	return collectors, nil
+}
+
+func (r *collectionRepo) CheckEmailExist(ctx context.Context, email string) (bool, error) {
+	var total int64
+	if err := r.db.Model(&models.User{}).WithContext(ctx).Where("email ilike ?", email).Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+func (r *collectionRepo) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,18 @@
+package collection
+
+import (
+	"context"
+	"final-project-backend/internal/collection/delivery/body"
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/utils"
+)
+
+type UseCase interface {
+	GetDelinquentLoans(ctx context.Context, userID string, roleID int, bucket string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetCollectionCase(ctx context.Context, userID string, roleID int, lendingID string) (*body.DelinquentLoan, error)
+	AssignCollector(ctx context.Context, lendingID string, body body.AssignCollectorRequest) (*models.CollectionCase, error)
+	CreateNote(ctx context.Context, userID string, roleID int, lendingID string, body body.CreateNoteRequest) (*models.CollectionNote, error)
+	CreatePromise(ctx context.Context, userID string, roleID int, lendingID string, body body.CreatePromiseRequest) (*models.PromiseToPay, error)
+	GetCollectors(ctx context.Context) ([]*models.User, error)
+	CreateCollector(ctx context.Context, body body.CreateCollectorRequest) (*models.User, error)
+}
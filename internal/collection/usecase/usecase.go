@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"final-project-backend/config"
+	"final-project-backend/internal/collection"
+	"final-project-backend/internal/collection/delivery/body"
+	"final-project-backend/internal/models"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+type collectionUC struct {
+	cfg            *config.Config
+	collectionRepo collection.Repository
+}
+
+func NewCollectionUseCase(cfg *config.Config, collectionRepo collection.Repository) collection.UseCase {
+	return &collectionUC{cfg: cfg, collectionRepo: collectionRepo}
+}
+
+func (u *collectionUC) GetDelinquentLoans(ctx context.Context, userID string, roleID int, bucket string, pagination *utils.Pagination) (*utils.Pagination, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+
+	dueBefore := now
+	dueAfter := time.Time{}
+	for _, delinquencyBucket := range models.DelinquencyBuckets {
+		if delinquencyBucket.Name != bucket {
+			continue
+		}
+
+		dueBefore = now.AddDate(0, 0, -(delinquencyBucket.MinDays - 1))
+		if delinquencyBucket.MaxDays > 0 {
+			dueAfter = now.AddDate(0, 0, -delinquencyBucket.MaxDays)
+		}
+	}
+
+	collectorID := ""
+	if roleID == 3 {
+		collectorID = userID
+	}
+
+	pagination, err := u.collectionRepo.GetDelinquentLoans(ctx, collectorID, dueBefore, dueAfter, pagination)
+	if err != nil {
+		return pagination, err
+	}
+
+	loans := pagination.Rows.([]*models.Lending)
+	var lendingIDs []uuid.UUID
+	for _, loan := range loans {
+		lendingIDs = append(lendingIDs, loan.LendingID)
+	}
+
+	collectionCases, err := u.collectionRepo.GetCollectionCasesByLendingID(ctx, lendingIDs)
+	if err != nil {
+		return pagination, err
+	}
+
+	caseByLending := map[uuid.UUID]*models.CollectionCase{}
+	for _, collectionCase := range collectionCases {
+		caseByLending[collectionCase.LendingID] = collectionCase
+	}
+
+	delinquentLoans := []*body.DelinquentLoan{}
+	for _, loan := range loans {
+		daysPastDue := loan.DaysPastDue(now)
+		delinquentLoans = append(delinquentLoans, &body.DelinquentLoan{
+			Lending:        loan,
+			DaysPastDue:    daysPastDue,
+			Bucket:         models.BucketForDays(daysPastDue),
+			CollectionCase: caseByLending[loan.LendingID],
+		})
+	}
+
+	pagination.Rows = delinquentLoans
+	return pagination, nil
+}
+
+func (u *collectionUC) GetCollectionCase(ctx context.Context, userID string, roleID int, lendingID string) (*body.DelinquentLoan, error) {
+	lending, err := u.collectionRepo.GetLendingByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	collectionCase, err := u.collectionRepo.GetCollectionCaseByLendingID(ctx, lendingID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		collectionCase = nil
+	}
+
+	if err := authorizeCase(collectionCase, userID, roleID); err != nil {
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	daysPastDue := lending.DaysPastDue(time.Now().In(loc))
+
+	return &body.DelinquentLoan{
+		Lending:        lending,
+		DaysPastDue:    daysPastDue,
+		Bucket:         models.BucketForDays(daysPastDue),
+		CollectionCase: collectionCase,
+	}, nil
+}
+
+func (u *collectionUC) AssignCollector(ctx context.Context, lendingID string, body body.AssignCollectorRequest) (*models.CollectionCase, error) {
+	lending, err := u.collectionRepo.GetLendingByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now := time.Now().In(loc)
+	if (lending.LendingStatusID != 2 && lending.LendingStatusID != 3) || lending.DaysPastDue(now) == 0 {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingNotDelinquent)
+	}
+
+	collector, err := u.collectionRepo.GetUserByID(ctx, body.CollectorID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.CollectorNotExist)
+		}
+		return nil, err
+	}
+
+	if collector.RoleID != 3 {
+		return nil, httperror.New(http.StatusBadRequest, response.CollectorNotExist)
+	}
+
+	collectionCase, err := u.getOrCreateCase(ctx, lending)
+	if err != nil {
+		return collectionCase, err
+	}
+
+	collectionCase.CollectorID = &collector.UserID
+	collectionCase.AssignedAt = &now
+	collectionCase, err = u.collectionRepo.UpdateCollectionCase(ctx, collectionCase)
+	if err != nil {
+		return collectionCase, err
+	}
+
+	return collectionCase, nil
+}
+
+func (u *collectionUC) CreateNote(ctx context.Context, userID string, roleID int, lendingID string, body body.CreateNoteRequest) (*models.CollectionNote, error) {
+	collectionCase, err := u.getAuthorizedCase(ctx, userID, roleID, lendingID)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &models.CollectionNote{}
+	note.CollectionCaseID = collectionCase.CollectionCaseID
+	note.UserID = authorID
+	note.Channel = body.Channel
+	note.Note = body.Note
+	if err := note.PrepareCreate(); err != nil {
+		return note, err
+	}
+
+	note, err = u.collectionRepo.CreateNote(ctx, note)
+	if err != nil {
+		return note, err
+	}
+
+	return note, nil
+}
+
+func (u *collectionUC) CreatePromise(ctx context.Context, userID string, roleID int, lendingID string, body body.CreatePromiseRequest) (*models.PromiseToPay, error) {
+	collectionCase, err := u.getAuthorizedCase(ctx, userID, roleID, lendingID)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	promise := &models.PromiseToPay{}
+	promise.CollectionCaseID = collectionCase.CollectionCaseID
+	promise.UserID = authorID
+	promise.Amount = body.Amount
+	promise.PromiseDate = body.PromiseDateTime
+	if err := promise.PrepareCreate(); err != nil {
+		return promise, err
+	}
+
+	promise, err = u.collectionRepo.CreatePromise(ctx, promise)
+	if err != nil {
+		return promise, err
+	}
+
+	return promise, nil
+}
+
+func (u *collectionUC) GetCollectors(ctx context.Context) ([]*models.User, error) {
+	collectors, err := u.collectionRepo.GetCollectors(ctx)
+	if err != nil {
+		return collectors, err
+	}
+
+	return collectors, nil
+}
+
+func (u *collectionUC) CreateCollector(ctx context.Context, body body.CreateCollectorRequest) (*models.User, error) {
+	exist, err := u.collectionRepo.CheckEmailExist(ctx, body.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return nil, httperror.New(http.StatusBadRequest, response.EmailAlreadyExistMessage)
+	}
+
+	collector := &models.User{}
+	collector.Name = body.Name
+	collector.PhoneNumber = body.PhoneNumber
+	collector.Address = body.Address
+	collector.Email = body.Email
+	collector.Password = body.Password
+	if err := collector.PrepareCreate(3); err != nil {
+		return nil, err
+	}
+
+	collector, err = u.collectionRepo.CreateUser(ctx, collector)
+	if err != nil {
+		return collector, err
+	}
+	collector.SanitizePassword()
+
+	return collector, nil
+}
+
+// getAuthorizedCase returns the collection case of a lending. Admins may open
+// a case on any lending; collectors only work the cases assigned to them.
+func (u *collectionUC) getAuthorizedCase(ctx context.Context, userID string, roleID int, lendingID string) (*models.CollectionCase, error) {
+	lending, err := u.collectionRepo.GetLendingByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	collectionCase, err := u.collectionRepo.GetCollectionCaseByLendingID(ctx, lendingID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		collectionCase = nil
+	}
+
+	if err := authorizeCase(collectionCase, userID, roleID); err != nil {
+		return nil, err
+	}
+
+	if collectionCase == nil {
+		return u.getOrCreateCase(ctx, lending)
+	}
+
+	return collectionCase, nil
+}
+
+func (u *collectionUC) getOrCreateCase(ctx context.Context, lending *models.Lending) (*models.CollectionCase, error) {
+	collectionCase, err := u.collectionRepo.GetCollectionCaseByLendingID(ctx, lending.LendingID.String())
+	if err == nil {
+		return collectionCase, nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return collectionCase, err
+	}
+
+	collectionCase = &models.CollectionCase{}
+	if err := collectionCase.PrepareCreate(lending.LendingID); err != nil {
+		return collectionCase, err
+	}
+
+	collectionCase, err = u.collectionRepo.CreateCollectionCase(ctx, collectionCase)
+	if err != nil {
+		return collectionCase, err
+	}
+
+	return collectionCase, nil
+}
+
+func authorizeCase(collectionCase *models.CollectionCase, userID string, roleID int) error {
+	if roleID != 3 {
+		return nil
+	}
+
+	if collectionCase == nil || collectionCase.CollectorID == nil || collectionCase.CollectorID.String() != userID {
+		return httperror.New(http.StatusForbidden, response.ForbiddenMessage)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	AdminActionApproveLoan     = "loan.approve"
+	AdminActionRejectLoan      = "loan.reject"
+	AdminActionAdvanceContract = "debtor.contract.advance"
+	AdminActionSetCreditLimit  = "debtor.credit_limit.set"
+)
+
+// AdminAction is an audit record of a change an admin made to a lending or a
+// debtor.
+type AdminAction struct {
+	AdminActionID uuid.UUID `json:"admin_action_id" db:"admin_action_id" binding:"omitempty"`
+	UserID        uuid.UUID `json:"user_id" db:"user_id" binding:"omitempty"`
+	Action        string    `json:"action" db:"action" binding:"omitempty"`
+	TargetID      uuid.UUID `json:"target_id" db:"target_id" binding:"omitempty"`
+	Detail        string    `json:"detail" db:"detail" binding:"omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+func NewAdminAction(userID uuid.UUID, action string, targetID uuid.UUID, detail string) (*AdminAction, error) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminAction{
+		AdminActionID: id,
+		UserID:        userID,
+		Action:        action,
+		TargetID:      targetID,
+		Detail:        detail,
+	}, nil
+}
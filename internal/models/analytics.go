@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// LendingDecisionAggregate summarises the loans approved or rejected within a
+// period. Period is the Jakarta wall time the period starts at.
+type LendingDecisionAggregate struct {
+	Period          time.Time
+	Approved        int64
+	Rejected        int64
+	Disbursed       float64
+	ApprovalSeconds float64
+	TimedApprovals  int64
+}
+
+type PaymentAggregate struct {
+	Period    time.Time
+	Repaid    float64
+	Fines     float64
+	Discounts float64
+}
+
+type DebtorAggregate struct {
+	Period     time.Time
+	NewDebtors int64
+}
+
+// VoucherRedemptionAggregate summarises the standing redemptions of one
+// voucher within a period. Late redemptions paid an installment after its due
+// date.
+type VoucherRedemptionAggregate struct {
+	Period          time.Time
+	Redemptions     int64
+	LateRedemptions int64
+	Discounts       float64
+}
+
+// VoucherRepaymentAggregate counts the installments that fell due during a
+// voucher campaign, split by whether the debtor redeemed the voucher.
+type VoucherRepaymentAggregate struct {
+	UsedVoucher  bool
+	Installments int64
+	OnTime       int64
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CollectionCase struct {
+	CollectionCaseID uuid.UUID         `json:"collection_case_id" db:"collection_case_id" binding:"omitempty"`
+	LendingID        uuid.UUID         `json:"lending_id" db:"lending_id" binding:"omitempty"`
+	CollectorID      *uuid.UUID        `json:"collector_id" db:"collector_id" binding:"omitempty"`
+	AssignedAt       *time.Time        `json:"assigned_at" db:"assigned_at" binding:"omitempty"`
+	CreatedAt        time.Time         `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at,omitempty" db:"updated_at"`
+	Collector        *User             `json:"collector,omitempty" gorm:"foreignKey:CollectorID;references:UserID"`
+	Notes            *[]CollectionNote `json:"notes,omitempty" gorm:"foreignKey:CollectionCaseID;references:CollectionCaseID"`
+	Promises         *[]PromiseToPay   `json:"promises,omitempty" gorm:"foreignKey:CollectionCaseID;references:CollectionCaseID"`
+}
+
+func (c *CollectionCase) PrepareCreate(lendingID uuid.UUID) error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	c.CollectionCaseID = id
+	c.LendingID = lendingID
+
+	return nil
+}
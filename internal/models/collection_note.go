@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CollectionNote struct {
+	CollectionNoteID uuid.UUID `json:"collection_note_id" db:"collection_note_id" binding:"omitempty"`
+	CollectionCaseID uuid.UUID `json:"collection_case_id" db:"collection_case_id" binding:"omitempty"`
+	UserID           uuid.UUID `json:"user_id" db:"user_id" binding:"omitempty"`
+	Channel          string    `json:"channel" db:"channel" binding:"omitempty"`
+	Note             string    `json:"note" db:"note" binding:"omitempty"`
+	CreatedAt        time.Time `json:"created_at,omitempty" db:"created_at"`
+	User             *User     `json:"user,omitempty" gorm:"foreignKey:UserID;references:UserID"`
+}
+
+func (c *CollectionNote) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	c.CollectionNoteID = id
+	return nil
+}
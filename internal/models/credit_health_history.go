@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreditHealthHistory is a change of a debtor's credit health or total
+// delay. Health only moves on a payment, a payment reversal, an admin
+// override or a write off; nothing recalculates it on a schedule, because
+// no rule changes it with the mere passing of time.
+type CreditHealthHistory struct {
+	CreditHealthHistoryID  uuid.UUID              `json:"credit_health_history_id" db:"credit_health_history_id" binding:"omitempty"`
+	DebtorID               uuid.UUID              `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
+	CreditHealthEventID    int                    `json:"credit_health_event_id" db:"credit_health_event_id" binding:"omitempty"`
+	PreviousCreditHealthID int                    `json:"previous_credit_health_id" db:"previous_credit_health_id" binding:"omitempty"`
+	CreditHealthID         int                    `json:"credit_health_id" db:"credit_health_id" binding:"omitempty"`
+	PreviousTotalDelay     int                    `json:"previous_total_delay" db:"previous_total_delay" binding:"omitempty"`
+	TotalDelay             int                    `json:"total_delay" db:"total_delay" binding:"omitempty"`
+	DelayDays              int                    `json:"delay_days" db:"delay_days" binding:"omitempty"`
+	ReferenceID            *uuid.UUID             `json:"reference_id" db:"reference_id" binding:"omitempty"`
+	UserID                 *uuid.UUID             `json:"user_id" db:"user_id" binding:"omitempty"`
+	CreatedAt              time.Time              `json:"created_at,omitempty" db:"created_at"`
+	CreditHealthEvent      *CreditHealthEventType `json:"credit_health_event,omitempty" gorm:"foreignKey:CreditHealthEventID;references:CreditHealthEventID"`
+	PreviousCreditHealth   *CreditHealthType      `json:"previous_credit_health,omitempty" gorm:"foreignKey:PreviousCreditHealthID;references:CreditHealthID"`
+	CreditHealth           *CreditHealthType      `json:"credit_health,omitempty" gorm:"foreignKey:CreditHealthID;references:CreditHealthID"`
+}
+
+// NewCreditHealthHistory records the debtor's current health and delay
+// against the values they had before the event. It returns nil when the
+// event left both unchanged.
+func NewCreditHealthHistory(debtor *Debtor, eventID, previousCreditHealthID, previousTotalDelay int) (*CreditHealthHistory, error) {
+	if debtor.CreditHealthID == previousCreditHealthID && debtor.TotalDelay == previousTotalDelay {
+		return nil, nil
+	}
+
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreditHealthHistory{
+		CreditHealthHistoryID:  id,
+		DebtorID:               debtor.DebtorID,
+		CreditHealthEventID:    eventID,
+		PreviousCreditHealthID: previousCreditHealthID,
+		CreditHealthID:         debtor.CreditHealthID,
+		PreviousTotalDelay:     previousTotalDelay,
+		TotalDelay:             debtor.TotalDelay,
+	}, nil
+}
@@ -10,3 +10,49 @@ type CreditHealthType struct {
 	CreatedAt      time.Time `json:"created_at,omitempty" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at,omitempty" db:"updated_at"`
 }
+
+const (
+	// GoodCreditHealthDelay is the highest total delay still rated good;
+	// above WarningCreditHealthDelay the debtor is blocked.
+	GoodCreditHealthDelay    = 10
+	WarningCreditHealthDelay = 20
+
+	// OnTimePaymentRecovery is the delay forgiven by each on-time payment.
+	OnTimePaymentRecovery = 10
+)
+
+// AccrueDelay adds the days an installment was paid late to the debtor's
+// running delay. An installment paid on time earns back ten days.
+func AccrueDelay(totalDelay, delay int) int {
+	totalDelay = totalDelay + delay
+	if delay == 0 {
+		if totalDelay-OnTimePaymentRecovery < 0 {
+			return 0
+		}
+		return totalDelay - OnTimePaymentRecovery
+	}
+
+	return totalDelay
+}
+
+func CreditHealthForDelay(totalDelay int) int {
+	switch {
+	case totalDelay > WarningCreditHealthDelay:
+		return 3
+	case totalDelay > GoodCreditHealthDelay:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// OnTimePaymentsToRecover is the number of consecutive on-time payments that
+// bring the total delay back within the good range.
+func OnTimePaymentsToRecover(totalDelay int) int {
+	excess := totalDelay - GoodCreditHealthDelay
+	if excess <= 0 {
+		return 0
+	}
+
+	return (excess + OnTimePaymentRecovery - 1) / OnTimePaymentRecovery
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreditLimitProposal struct {
+	CreditLimitProposalID       uuid.UUID                      `json:"credit_limit_proposal_id" db:"credit_limit_proposal_id" binding:"omitempty"`
+	DebtorID                    uuid.UUID                      `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
+	CreditLimitProposalStatusID int                            `json:"credit_limit_proposal_status_id" db:"credit_limit_proposal_status_id" binding:"omitempty"`
+	UserID                      *uuid.UUID                     `json:"user_id" db:"user_id" binding:"omitempty"`
+	RulesVersion                string                         `json:"rules_version" db:"rules_version" binding:"omitempty"`
+	Score                       int                            `json:"score" db:"score" binding:"omitempty"`
+	CurrentLimit                float64                        `json:"current_limit" db:"current_limit" binding:"omitempty"`
+	ProposedLimit               float64                        `json:"proposed_limit" db:"proposed_limit" binding:"omitempty"`
+	RespondedAt                 *time.Time                     `json:"responded_at" db:"responded_at" binding:"omitempty"`
+	CreatedAt                   time.Time                      `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt                   time.Time                      `json:"updated_at,omitempty" db:"updated_at"`
+	CreditLimitProposalStatus   *CreditLimitProposalStatusType `json:"credit_limit_proposal_status,omitempty" gorm:"foreignKey:CreditLimitProposalStatusID;references:CreditLimitProposalStatusID"`
+	Debtor                      *Debtor                        `json:"debtor,omitempty" gorm:"foreignKey:DebtorID;references:DebtorID"`
+}
+
+func (p *CreditLimitProposal) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.CreditLimitProposalID = id
+	p.CreditLimitProposalStatusID = 1
+
+	return nil
+}
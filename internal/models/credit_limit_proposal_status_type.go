@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type CreditLimitProposalStatusType struct {
+	CreditLimitProposalStatusID int       `json:"credit_limit_proposal_status_id" db:"credit_limit_proposal_status_id" binding:"omitempty"`
+	Name                        string    `json:"name" db:"name" binding:"omitempty"`
+	CreatedAt                   time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt                   time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
@@ -8,8 +8,9 @@ import (
 type Debtor struct {
 	DebtorID           uuid.UUID             `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
 	UserID             uuid.UUID             `json:"user_id" db:"user_id" binding:"omitempty"`
-	CreditHealthID     int                   `json:"credit_health_id" db:"user_id" binding:"omitempty"`
-	ContractTrackingID int                   `json:"contract_tracking_id" db:"user_id" binding:"omitempty"`
+	CreditHealthID     int                   `json:"credit_health_id" db:"credit_health_id" binding:"omitempty"`
+	ContractTrackingID int                   `json:"contract_tracking_id" db:"contract_tracking_id" binding:"omitempty"`
+	KycStatusID        int                   `json:"kyc_status_id" db:"kyc_status_id" binding:"omitempty"`
 	CreditLimit        float64               `json:"credit_limit" db:"credit_limit" binding:"omitempty"`
 	CreditUsed         float64               `json:"credit_used" db:"credit_used" binding:"omitempty"`
 	TotalDelay         int                   `json:"total_delay" db:"total_delay" binding:"omitempty"`
@@ -18,6 +19,7 @@ type Debtor struct {
 	User               *User                 `json:"user,omitempty" gorm:"foreignKey:UserID;references:UserID"`
 	CreditHealth       *CreditHealthType     `json:"credit_health,omitempty" gorm:"foreignKey:CreditHealthID;references:CreditHealthID"`
 	ContractTracking   *ContractTrackingType `json:"contract_tracking,omitempty" gorm:"foreignKey:ContractTrackingID;references:ContractTrackingID"`
+	KycStatus          *KycStatusType        `json:"kyc_status,omitempty" gorm:"foreignKey:KycStatusID;references:KycStatusID"`
 }
 
 func (d *Debtor) PrepareCreate(userID uuid.UUID, creditHealthID, contractTrackingID int) error {
@@ -30,6 +32,7 @@ func (d *Debtor) PrepareCreate(userID uuid.UUID, creditHealthID, contractTrackin
 	d.UserID = userID
 	d.CreditHealthID = creditHealthID
 	d.ContractTrackingID = contractTrackingID
+	d.KycStatusID = 1
 
 	return nil
 }
@@ -0,0 +1,26 @@
+package models
+
+// DelinquencyBucket groups delinquent lendings by days past due. A MaxDays of
+// zero leaves the bucket open ended.
+type DelinquencyBucket struct {
+	Name    string `json:"name"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days"`
+}
+
+var DelinquencyBuckets = []DelinquencyBucket{
+	{Name: "1-30", MinDays: 1, MaxDays: 30},
+	{Name: "31-60", MinDays: 31, MaxDays: 60},
+	{Name: "61-90", MinDays: 61, MaxDays: 90},
+	{Name: "90+", MinDays: 91, MaxDays: 0},
+}
+
+func BucketForDays(days int) string {
+	for _, bucket := range DelinquencyBuckets {
+		if days >= bucket.MinDays && (bucket.MaxDays == 0 || days <= bucket.MaxDays) {
+			return bucket.Name
+		}
+	}
+
+	return ""
+}
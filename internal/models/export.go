@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LoanExport is a lending flattened with the names it refers to, read one row
+// at a time when exporting.
+type LoanExport struct {
+	LendingID       uuid.UUID
+	DebtorName      string
+	DebtorEmail     string
+	Name            string
+	LoanProductName string
+	LendingStatus   string
+	Amount          float64
+	Duration        int
+	Percentage      int
+	CreatedAt       time.Time
+}
+
+type PaymentExport struct {
+	PaymentID         uuid.UUID
+	DebtorName        string
+	LendingName       string
+	InstallmentDue    time.Time
+	VoucherName       *string
+	PaymentAmount     float64
+	PaymentDiscount   float64
+	PaymentFine       float64
+	PaymentFineWaiver float64
+	PaymentDate       time.Time
+	ReversedAt        *time.Time
+}
+
+type DebtorExport struct {
+	DebtorID         uuid.UUID
+	Name             string
+	Email            string
+	PhoneNumber      string
+	CreditHealth     string
+	ContractTracking string
+	CreditLimit      float64
+	CreditUsed       float64
+	TotalDelay       int
+	CreatedAt        time.Time
+}
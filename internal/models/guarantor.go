@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Guarantor struct {
+	GuarantorID       uuid.UUID            `json:"guarantor_id" db:"guarantor_id" binding:"omitempty"`
+	LendingID         uuid.UUID            `json:"lending_id" db:"lending_id" binding:"omitempty"`
+	UserID            uuid.UUID            `json:"user_id" db:"user_id" binding:"omitempty"`
+	GuarantorStatusID int                  `json:"guarantor_status_id" db:"guarantor_status_id" binding:"omitempty"`
+	RespondedAt       *time.Time           `json:"responded_at" db:"responded_at" binding:"omitempty"`
+	CreatedAt         time.Time            `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at,omitempty" db:"updated_at"`
+	GuarantorStatus   *GuarantorStatusType `json:"guarantor_status,omitempty" gorm:"foreignKey:GuarantorStatusID;references:GuarantorStatusID"`
+	User              *User                `json:"user,omitempty" gorm:"foreignKey:UserID;references:UserID"`
+	Lending           *Lending             `json:"lending,omitempty" gorm:"foreignKey:LendingID;references:LendingID"`
+}
+
+func (g *Guarantor) PrepareCreate(lendingID, userID uuid.UUID) error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	g.GuarantorID = id
+	g.LendingID = lendingID
+	g.UserID = userID
+	g.GuarantorStatusID = 1
+
+	return nil
+}
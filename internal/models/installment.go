@@ -9,6 +9,8 @@ type Installment struct {
 	InstallmentID       uuid.UUID              `json:"installment_id" db:"installment_id" binding:"omitempty"`
 	LendingID           uuid.UUID              `json:"lending_id" db:"lending_id" binding:"omitempty"`
 	InstallmentStatusID int                    `json:"installment_status_id" db:"installment_status_id" binding:"omitempty"`
+	RestructuringID     *uuid.UUID             `json:"restructuring_id" db:"restructuring_id" binding:"omitempty"`
+	SupersededByID      *uuid.UUID             `json:"superseded_by_id" db:"superseded_by_id" binding:"omitempty"`
 	Amount              float64                `json:"amount" db:"amount" binding:"omitempty"`
 	DueDate             time.Time              `json:"due_date" db:"due_date" binding:"omitempty"`
 	CreatedAt           time.Time              `json:"created_at,omitempty" db:"created_at"`
@@ -28,3 +30,22 @@ func (i *Installment) PrepareCreate() error {
 
 	return nil
 }
+
+func (i *Installment) DelayDays(at time.Time) int {
+	delayTime := at.Sub(i.DueDate)
+	if delayTime.Seconds() > 0 {
+		return int(delayTime.Hours()/24) + 1
+	}
+
+	return 0
+}
+
+func (i *Installment) Fine(at time.Time, finePerDay float64) float64 {
+	return finePerDay * float64(i.DelayDays(at))
+}
+
+// DueDateAfter returns the installment due date the given number of months
+// after at. Installments always fall due on the 25th.
+func DueDateAfter(at time.Time, months int) time.Time {
+	return time.Date(at.Year(), at.Month()+time.Month(months), 25, 23, 59, 59, 0, at.Location())
+}
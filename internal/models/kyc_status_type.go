@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type KycStatusType struct {
+	KycStatusID int       `json:"kyc_status_id" db:"kyc_status_id" binding:"omitempty"`
+	Name        string    `json:"name" db:"name" binding:"omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type KycSubmission struct {
+	KycSubmissionID uuid.UUID      `json:"kyc_submission_id" db:"kyc_submission_id" binding:"omitempty"`
+	DebtorID        uuid.UUID      `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
+	KycStatusID     int            `json:"kyc_status_id" db:"kyc_status_id" binding:"omitempty"`
+	IDCardKey       string         `json:"-" db:"id_card_key"`
+	SelfieKey       string         `json:"-" db:"selfie_key"`
+	RejectReason    string         `json:"reject_reason" db:"reject_reason" binding:"omitempty"`
+	UserID          *uuid.UUID     `json:"user_id" db:"user_id" binding:"omitempty"`
+	ReviewedAt      *time.Time     `json:"reviewed_at" db:"reviewed_at" binding:"omitempty"`
+	CreatedAt       time.Time      `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at,omitempty" db:"updated_at"`
+	KycStatus       *KycStatusType `json:"kyc_status,omitempty" gorm:"foreignKey:KycStatusID;references:KycStatusID"`
+	Debtor          *Debtor        `json:"debtor,omitempty" gorm:"foreignKey:DebtorID;references:DebtorID"`
+}
+
+func (k *KycSubmission) PrepareCreate(debtorID uuid.UUID) error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	k.KycSubmissionID = id
+	k.DebtorID = debtorID
+	k.KycStatusID = 2
+
+	return nil
+}
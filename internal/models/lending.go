@@ -6,18 +6,38 @@ import (
 )
 
 type Lending struct {
-	LendingID       uuid.UUID          `json:"lending_id" db:"lending_id" binding:"omitempty"`
-	DebtorID        uuid.UUID          `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
-	LoanPeriodID    int                `json:"loan_period_id" db:"loan_period_id" binding:"omitempty"`
-	LendingStatusID int                `json:"lending_status_id" db:"lending_status_id" binding:"omitempty"`
-	Name            string             `json:"name" db:"name" binding:"omitempty"`
-	Amount          float64            `json:"amount" db:"amount" binding:"omitempty"`
-	CreatedAt       time.Time          `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at,omitempty" db:"updated_at"`
-	Debtor          *Debtor            `json:"debtor,omitempty" gorm:"foreignKey:DebtorID;references:DebtorID"`
-	LoanPeriod      *LoanPeriod        `json:"loan_period,omitempty" gorm:"foreignKey:LoanPeriodID;references:LoanPeriodID"`
-	LendingStatus   *LendingStatusType `json:"lending_status,omitempty" gorm:"foreignKey:LendingStatusID;references:LendingStatusID"`
-	Installments    *[]Installment     `json:"installments,omitempty" gorm:"foreignKey:LendingID;references:LendingID"`
+	LendingID         uuid.UUID          `json:"lending_id" db:"lending_id" binding:"omitempty"`
+	DebtorID          uuid.UUID          `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
+	LoanProductID     uuid.UUID          `json:"loan_product_id" db:"loan_product_id" binding:"omitempty"`
+	LendingStatusID   int                `json:"lending_status_id" db:"lending_status_id" binding:"omitempty"`
+	Name              string             `json:"name" db:"name" binding:"omitempty"`
+	Amount            float64            `json:"amount" db:"amount" binding:"omitempty"`
+	Duration          int                `json:"duration" db:"duration" binding:"omitempty"`
+	Percentage        int                `json:"percentage" db:"percentage" binding:"omitempty"`
+	FinePerDay        float64            `json:"fine_per_day" db:"fine_per_day" binding:"omitempty"`
+	ApprovedAt        *time.Time         `json:"approved_at" db:"approved_at" binding:"omitempty"`
+	CancelReason      *string            `json:"cancel_reason" db:"cancel_reason" binding:"omitempty"`
+	CancelledAt       *time.Time         `json:"cancelled_at" db:"cancelled_at" binding:"omitempty"`
+	RejectionReasonID *int               `json:"rejection_reason_id" db:"rejection_reason_id" binding:"omitempty"`
+	RejectionNote     *string            `json:"rejection_note" db:"rejection_note" binding:"omitempty"`
+	RejectedAt        *time.Time         `json:"rejected_at" db:"rejected_at" binding:"omitempty"`
+	CreatedAt         time.Time          `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at,omitempty" db:"updated_at"`
+	Debtor            *Debtor            `json:"debtor,omitempty" gorm:"foreignKey:DebtorID;references:DebtorID"`
+	LoanProduct       *LoanProduct       `json:"loan_product,omitempty" gorm:"foreignKey:LoanProductID;references:LoanProductID"`
+	LendingStatus     *LendingStatusType `json:"lending_status,omitempty" gorm:"foreignKey:LendingStatusID;references:LendingStatusID"`
+	Installments      *[]Installment     `json:"installments,omitempty" gorm:"foreignKey:LendingID;references:LendingID"`
+	Guarantors        *[]Guarantor       `json:"guarantors,omitempty" gorm:"foreignKey:LendingID;references:LendingID"`
+	RejectionReason   *RejectionReason   `json:"rejection_reason,omitempty" gorm:"foreignKey:RejectionReasonID;references:RejectionReasonID"`
+}
+
+// ApplyProduct copies the product terms onto the lending, so later changes to
+// the catalog do not affect it.
+func (l *Lending) ApplyProduct(product *LoanProduct) {
+	l.LoanProductID = product.LoanProductID
+	l.Duration = product.Duration
+	l.Percentage = product.Percentage
+	l.FinePerDay = product.FinePerDay
 }
 
 func (l *Lending) PrepareCreate() error {
@@ -31,3 +51,24 @@ func (l *Lending) PrepareCreate() error {
 
 	return nil
 }
+
+// DaysPastDue is the delay of the oldest unpaid installment. Installments must
+// be loaded.
+func (l *Lending) DaysPastDue(at time.Time) int {
+	days := 0
+	if l.Installments == nil {
+		return days
+	}
+
+	for _, installment := range *l.Installments {
+		if installment.InstallmentStatusID != 1 {
+			continue
+		}
+
+		if delay := installment.DelayDays(at); delay > days {
+			days = delay
+		}
+	}
+
+	return days
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type LoanProduct struct {
+	LoanProductID uuid.UUID          `json:"loan_product_id" db:"loan_product_id" binding:"omitempty"`
+	Name          string             `json:"name" db:"name" binding:"omitempty"`
+	Duration      int                `json:"duration" db:"duration" binding:"omitempty"`
+	Percentage    int                `json:"percentage" db:"percentage" binding:"omitempty"`
+	FinePerDay    float64            `json:"fine_per_day" db:"fine_per_day" binding:"omitempty"`
+	MinAmount     float64            `json:"min_amount" db:"min_amount" binding:"omitempty"`
+	MaxAmount     float64            `json:"max_amount" db:"max_amount" binding:"omitempty"`
+	ActiveDate    time.Time          `json:"active_date,omitempty" db:"active_date"`
+	ExpireDate    time.Time          `json:"expire_date,omitempty" db:"expire_date"`
+	CreatedAt     time.Time          `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at,omitempty" db:"updated_at"`
+	DeletedAt     gorm.DeletedAt     `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreditHealths []CreditHealthType `json:"credit_healths,omitempty" gorm:"many2many:loan_product_credit_healths;foreignKey:LoanProductID;joinForeignKey:LoanProductID;references:CreditHealthID;joinReferences:CreditHealthID"`
+}
+
+func (p *LoanProduct) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.LoanProductID = id
+
+	return nil
+}
+
+func (p *LoanProduct) IsActive(at time.Time) bool {
+	return at.Sub(p.ActiveDate).Seconds() >= 0 && at.Sub(p.ExpireDate).Seconds() <= 0
+}
+
+func (p *LoanProduct) IsEligible(creditHealthID int) bool {
+	for _, creditHealth := range p.CreditHealths {
+		if creditHealth.CreditHealthID == creditHealthID {
+			return true
+		}
+	}
+
+	return false
+}
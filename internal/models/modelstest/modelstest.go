@@ -0,0 +1,447 @@
+//go:build integration
+
+// Package modelstest inserts rows for repository integration tests. Every
+// helper fills in valid defaults, applies the given options and creates any
+// parent row left unset, so a test only spells out the columns it checks.
+package modelstest
+
+import (
+	"final-project-backend/internal/models"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"testing"
+	"time"
+)
+
+func CreateUser(t testing.TB, db *gorm.DB, opts ...func(*models.User)) *models.User {
+	t.Helper()
+
+	id := uuid.New()
+	user := &models.User{
+		UserID:      id,
+		RoleID:      2,
+		Name:        "User " + id.String()[:8],
+		PhoneNumber: "081234567890",
+		Address:     "Jl. Sudirman No. 1, Jakarta",
+		Email:       fmt.Sprintf("%s@example.com", id.String()[:8]),
+		Password:    "hashed-password",
+	}
+	for _, opt := range opts {
+		opt(user)
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(user).Error)
+	return user
+}
+
+func CreateDebtor(t testing.TB, db *gorm.DB, opts ...func(*models.Debtor)) *models.Debtor {
+	t.Helper()
+
+	debtor := &models.Debtor{
+		DebtorID:           uuid.New(),
+		CreditHealthID:     1,
+		ContractTrackingID: 5,
+		KycStatusID:        3,
+		CreditLimit:        50000000,
+	}
+	for _, opt := range opts {
+		opt(debtor)
+	}
+	if debtor.UserID == uuid.Nil {
+		debtor.UserID = CreateUser(t, db).UserID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(debtor).Error)
+	return debtor
+}
+
+// CreateLoanProduct also links the product to the credit healths in
+// CreditHealths.
+func CreateLoanProduct(t testing.TB, db *gorm.DB, opts ...func(*models.LoanProduct)) *models.LoanProduct {
+	t.Helper()
+
+	id := uuid.New()
+	loanProduct := &models.LoanProduct{
+		LoanProductID: id,
+		Name:          "Product " + id.String()[:8],
+		Duration:      3,
+		Percentage:    105,
+		FinePerDay:    5000,
+		MinAmount:     1000000,
+		MaxAmount:     10000000,
+		ActiveDate:    time.Now().AddDate(0, -1, 0),
+		ExpireDate:    time.Now().AddDate(1, 0, 0),
+	}
+	for _, opt := range opts {
+		opt(loanProduct)
+	}
+
+	require.NoError(t, db.Omit("CreditHealths.*").Create(loanProduct).Error)
+	return loanProduct
+}
+
+func CreateLending(t testing.TB, db *gorm.DB, opts ...func(*models.Lending)) *models.Lending {
+	t.Helper()
+
+	id := uuid.New()
+	lending := &models.Lending{
+		LendingID:       id,
+		LendingStatusID: 3,
+		Name:            "Loan " + id.String()[:8],
+		Amount:          3000000,
+		Duration:        3,
+		Percentage:      105,
+		FinePerDay:      5000,
+	}
+	for _, opt := range opts {
+		opt(lending)
+	}
+	if lending.DebtorID == uuid.Nil {
+		lending.DebtorID = CreateDebtor(t, db).DebtorID
+	}
+	if lending.LoanProductID == uuid.Nil {
+		lending.LoanProductID = CreateLoanProduct(t, db).LoanProductID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(lending).Error)
+	return lending
+}
+
+func CreateInstallment(t testing.TB, db *gorm.DB, opts ...func(*models.Installment)) *models.Installment {
+	t.Helper()
+
+	installment := &models.Installment{
+		InstallmentID:       uuid.New(),
+		InstallmentStatusID: 1,
+		Amount:              1050000,
+		DueDate:             time.Now().AddDate(0, 1, 0),
+	}
+	for _, opt := range opts {
+		opt(installment)
+	}
+	if installment.LendingID == uuid.Nil {
+		installment.LendingID = CreateLending(t, db).LendingID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(installment).Error)
+	return installment
+}
+
+func CreatePayment(t testing.TB, db *gorm.DB, opts ...func(*models.Payment)) *models.Payment {
+	t.Helper()
+
+	payment := &models.Payment{
+		PaymentID:     uuid.New(),
+		PaymentAmount: 1050000,
+		PaymentDate:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(payment)
+	}
+	if payment.InstallmentID == uuid.Nil {
+		payment.InstallmentID = CreateInstallment(t, db, func(i *models.Installment) {
+			i.InstallmentStatusID = 2
+		}).InstallmentID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(payment).Error)
+	return payment
+}
+
+func CreatePaymentReversal(t testing.TB, db *gorm.DB, payment *models.Payment, opts ...func(*models.PaymentReversal)) *models.PaymentReversal {
+	t.Helper()
+
+	reversal := &models.PaymentReversal{
+		PaymentReversalID:  uuid.New(),
+		PaymentID:          payment.PaymentID,
+		InstallmentID:      payment.InstallmentID,
+		Reason:             "Bounced transfer",
+		ReversedFine:       payment.PaymentFine,
+		ReversedDiscount:   payment.PaymentDiscount,
+		ReversedFineWaiver: payment.PaymentFineWaiver,
+		ReversedAmount:     payment.PaymentAmount,
+	}
+	for _, opt := range opts {
+		opt(reversal)
+	}
+
+	require.NoError(t, db.Create(reversal).Error)
+	return reversal
+}
+
+func CreatePaymentIntent(t testing.TB, db *gorm.DB, opts ...func(*models.PaymentIntent)) *models.PaymentIntent {
+	t.Helper()
+
+	id := uuid.New()
+	intent := &models.PaymentIntent{
+		PaymentIntentID:       id,
+		PaymentIntentStatusID: 1,
+		Provider:              "xendit",
+		Channel:               "BCA",
+		ExternalID:            "ext-" + id.String(),
+		AccountNumber:         "8808" + id.String()[:8],
+		PaymentAmount:         1050000,
+		ExpireDate:            time.Now().Add(24 * time.Hour),
+	}
+	for _, opt := range opts {
+		opt(intent)
+	}
+	if intent.InstallmentID == uuid.Nil {
+		intent.InstallmentID = CreateInstallment(t, db).InstallmentID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(intent).Error)
+	return intent
+}
+
+// CreateVoucher also links the voucher to the credit healths and loan
+// products it lists.
+func CreateVoucher(t testing.TB, db *gorm.DB, opts ...func(*models.Voucher)) *models.Voucher {
+	t.Helper()
+
+	id := uuid.New()
+	voucher := &models.Voucher{
+		VoucherID:             id,
+		Name:                  "Voucher " + id.String()[:8],
+		VoucherStatusID:       2,
+		VoucherDiscountTypeID: 1,
+		DiscountPayment:       10,
+		DiscountQuota:         100,
+		ActiveDate:            time.Now().AddDate(0, 0, -7),
+		ExpireDate:            time.Now().AddDate(0, 1, 0),
+	}
+	for _, opt := range opts {
+		opt(voucher)
+	}
+
+	require.NoError(t, db.Omit("VoucherStatus", "VoucherDiscountType", "CreditHealths.*", "LoanProducts.*").Create(voucher).Error)
+	return voucher
+}
+
+func CreateVoucherCode(t testing.TB, db *gorm.DB, voucher *models.Voucher, code string) *models.VoucherCode {
+	t.Helper()
+
+	voucherCode := &models.VoucherCode{
+		VoucherCodeID: uuid.New(),
+		VoucherID:     voucher.VoucherID,
+		Code:          code,
+		SingleUse:     true,
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(voucherCode).Error)
+	return voucherCode
+}
+
+// CreateVoucherRedemption records payment as a redemption of its voucher by
+// debtor.
+func CreateVoucherRedemption(t testing.TB, db *gorm.DB, payment *models.Payment, debtor *models.Debtor) *models.VoucherRedemption {
+	t.Helper()
+
+	redemption := &models.VoucherRedemption{
+		VoucherRedemptionID: uuid.New(),
+		VoucherID:           *payment.VoucherID,
+		DebtorID:            debtor.DebtorID,
+		PaymentID:           payment.PaymentID,
+	}
+
+	require.NoError(t, db.Create(redemption).Error)
+	return redemption
+}
+
+func CreateRestructuring(t testing.TB, db *gorm.DB, opts ...func(*models.Restructuring)) *models.Restructuring {
+	t.Helper()
+
+	restructuring := &models.Restructuring{
+		RestructuringID:       uuid.New(),
+		RestructuringStatusID: 1,
+		Version:               1,
+		Tenor:                 6,
+		OutstandingAmount:     2100000,
+		TotalAmount:           2100000,
+		InstallmentAmount:     350000,
+		FirstDueDate:          time.Now().AddDate(0, 1, 0),
+	}
+	for _, opt := range opts {
+		opt(restructuring)
+	}
+	if restructuring.LendingID == uuid.Nil {
+		restructuring.LendingID = CreateLending(t, db).LendingID
+	}
+	if restructuring.UserID == uuid.Nil {
+		restructuring.UserID = CreateUser(t, db, func(u *models.User) { u.RoleID = 1 }).UserID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(restructuring).Error)
+	return restructuring
+}
+
+func CreateWriteOff(t testing.TB, db *gorm.DB, opts ...func(*models.WriteOff)) *models.WriteOff {
+	t.Helper()
+
+	writeOff := &models.WriteOff{
+		WriteOffID:    uuid.New(),
+		Reason:        "Debtor unreachable",
+		PrincipalLoss: 2000000,
+	}
+	for _, opt := range opts {
+		opt(writeOff)
+	}
+	if writeOff.LendingID == uuid.Nil {
+		writeOff.LendingID = CreateLending(t, db, func(l *models.Lending) { l.LendingStatusID = 6 }).LendingID
+	}
+	if writeOff.UserID == uuid.Nil {
+		writeOff.UserID = CreateUser(t, db, func(u *models.User) { u.RoleID = 1 }).UserID
+	}
+
+	require.NoError(t, db.Create(writeOff).Error)
+	return writeOff
+}
+
+func CreateCreditLimitProposal(t testing.TB, db *gorm.DB, opts ...func(*models.CreditLimitProposal)) *models.CreditLimitProposal {
+	t.Helper()
+
+	proposal := &models.CreditLimitProposal{
+		CreditLimitProposalID:       uuid.New(),
+		CreditLimitProposalStatusID: 1,
+		RulesVersion:                "v1",
+		Score:                       720,
+		CurrentLimit:                50000000,
+		ProposedLimit:               60000000,
+	}
+	for _, opt := range opts {
+		opt(proposal)
+	}
+	if proposal.DebtorID == uuid.Nil {
+		proposal.DebtorID = CreateDebtor(t, db).DebtorID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(proposal).Error)
+	return proposal
+}
+
+func CreateCreditHealthHistory(t testing.TB, db *gorm.DB, opts ...func(*models.CreditHealthHistory)) *models.CreditHealthHistory {
+	t.Helper()
+
+	history := &models.CreditHealthHistory{
+		CreditHealthHistoryID:  uuid.New(),
+		CreditHealthEventID:    1,
+		PreviousCreditHealthID: 1,
+		CreditHealthID:         2,
+		TotalDelay:             12,
+		DelayDays:              12,
+	}
+	for _, opt := range opts {
+		opt(history)
+	}
+	if history.DebtorID == uuid.Nil {
+		history.DebtorID = CreateDebtor(t, db).DebtorID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(history).Error)
+	return history
+}
+
+func CreateKycSubmission(t testing.TB, db *gorm.DB, opts ...func(*models.KycSubmission)) *models.KycSubmission {
+	t.Helper()
+
+	id := uuid.New()
+	submission := &models.KycSubmission{
+		KycSubmissionID: id,
+		KycStatusID:     2,
+		IDCardKey:       "kyc/" + id.String() + "/id-card.jpg",
+		SelfieKey:       "kyc/" + id.String() + "/selfie.jpg",
+	}
+	for _, opt := range opts {
+		opt(submission)
+	}
+	if submission.DebtorID == uuid.Nil {
+		submission.DebtorID = CreateDebtor(t, db).DebtorID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(submission).Error)
+	return submission
+}
+
+func CreateGuarantor(t testing.TB, db *gorm.DB, opts ...func(*models.Guarantor)) *models.Guarantor {
+	t.Helper()
+
+	guarantor := &models.Guarantor{
+		GuarantorID:       uuid.New(),
+		GuarantorStatusID: 1,
+	}
+	for _, opt := range opts {
+		opt(guarantor)
+	}
+	if guarantor.LendingID == uuid.Nil {
+		guarantor.LendingID = CreateLending(t, db).LendingID
+	}
+	if guarantor.UserID == uuid.Nil {
+		guarantor.UserID = CreateUser(t, db).UserID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(guarantor).Error)
+	return guarantor
+}
+
+func CreateNotification(t testing.TB, db *gorm.DB, opts ...func(*models.Notification)) *models.Notification {
+	t.Helper()
+
+	notification := &models.Notification{
+		NotificationID: uuid.New(),
+		Title:          "Installment overdue",
+		Message:        "Your installment is past its due date.",
+	}
+	for _, opt := range opts {
+		opt(notification)
+	}
+	if notification.UserID == uuid.Nil {
+		notification.UserID = CreateUser(t, db).UserID
+	}
+
+	require.NoError(t, db.Create(notification).Error)
+	return notification
+}
+
+func CreateStatementImport(t testing.TB, db *gorm.DB, opts ...func(*models.StatementImport)) *models.StatementImport {
+	t.Helper()
+
+	statementImport := &models.StatementImport{
+		StatementImportID: uuid.New(),
+		FileName:          "statement.csv",
+		Format:            "csv",
+	}
+	for _, opt := range opts {
+		opt(statementImport)
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(statementImport).Error)
+	return statementImport
+}
+
+func CreateReconciliationItem(t testing.TB, db *gorm.DB, opts ...func(*models.ReconciliationItem)) *models.ReconciliationItem {
+	t.Helper()
+
+	id := uuid.New()
+	item := &models.ReconciliationItem{
+		ReconciliationItemID:   id,
+		ReconciliationStatusID: 1,
+		LineNumber:             1,
+		LineHash:               id.String(),
+		TransactionDate:        time.Now(),
+		Reference:              "REF-" + id.String()[:8],
+		Description:            "Transfer",
+		Amount:                 1050000,
+	}
+	for _, opt := range opts {
+		opt(item)
+	}
+	if item.StatementImportID == uuid.Nil {
+		item.StatementImportID = CreateStatementImport(t, db).StatementImportID
+	}
+
+	require.NoError(t, db.Omit(clause.Associations).Create(item).Error)
+	return item
+}
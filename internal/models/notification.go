@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Notification struct {
+	NotificationID uuid.UUID  `json:"notification_id" db:"notification_id" binding:"omitempty"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id" binding:"omitempty"`
+	Title          string     `json:"title" db:"title" binding:"omitempty"`
+	Message        string     `json:"message" db:"message" binding:"omitempty"`
+	ReferenceID    *uuid.UUID `json:"reference_id" db:"reference_id" binding:"omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" db:"created_at"`
+}
+
+func NewNotification(userID uuid.UUID, title, message string, referenceID *uuid.UUID) (*Notification, error) {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		NotificationID: id,
+		UserID:         userID,
+		Title:          title,
+		Message:        message,
+		ReferenceID:    referenceID,
+	}, nil
+}
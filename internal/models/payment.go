@@ -6,15 +6,19 @@ import (
 )
 
 type Payment struct {
-	PaymentID       uuid.UUID    `json:"payment_id" db:"payment_id" binding:"omitempty"`
-	InstallmentID   uuid.UUID    `json:"installment_id" db:"installment_id" binding:"omitempty"`
-	VoucherID       *uuid.UUID   `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
-	PaymentFine     float64      `json:"payment_fine" db:"payment_fine" binding:"omitempty"`
-	PaymentDiscount float64      `json:"payment_discount" db:"payment_discount" binding:"omitempty"`
-	PaymentAmount   float64      `json:"payment_amount" db:"payment_amount" binding:"omitempty"`
-	PaymentDate     time.Time    `json:"payment_date" db:"payment_date" binding:"omitempty"`
-	Installment     *Installment `json:"installment,omitempty" gorm:"foreignKey:InstallmentID;references:InstallmentID"`
-	Voucher         *Voucher     `json:"voucher,omitempty" gorm:"foreignKey:VoucherID;references:VoucherID"`
+	PaymentID         uuid.UUID        `json:"payment_id" db:"payment_id" binding:"omitempty"`
+	InstallmentID     uuid.UUID        `json:"installment_id" db:"installment_id" binding:"omitempty"`
+	VoucherID         *uuid.UUID       `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
+	PaymentIntentID   *uuid.UUID       `json:"payment_intent_id" db:"payment_intent_id" binding:"omitempty"`
+	PaymentFine       float64          `json:"payment_fine" db:"payment_fine" binding:"omitempty"`
+	PaymentDiscount   float64          `json:"payment_discount" db:"payment_discount" binding:"omitempty"`
+	PaymentFineWaiver float64          `json:"payment_fine_waiver" db:"payment_fine_waiver" binding:"omitempty"`
+	PaymentAmount     float64          `json:"payment_amount" db:"payment_amount" binding:"omitempty"`
+	CreditReleased    float64          `json:"credit_released" db:"credit_released" binding:"omitempty"`
+	PaymentDate       time.Time        `json:"payment_date" db:"payment_date" binding:"omitempty"`
+	Installment       *Installment     `json:"installment,omitempty" gorm:"foreignKey:InstallmentID;references:InstallmentID"`
+	Voucher           *Voucher         `json:"voucher,omitempty" gorm:"foreignKey:VoucherID;references:VoucherID"`
+	Reversal          *PaymentReversal `json:"reversal,omitempty" gorm:"foreignKey:PaymentID;references:PaymentID"`
 }
 
 func (p *Payment) PrepareCreate() error {
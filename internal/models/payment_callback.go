@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PaymentCallback struct {
+	PaymentCallbackID uuid.UUID  `json:"payment_callback_id" db:"payment_callback_id" binding:"omitempty"`
+	PaymentIntentID   *uuid.UUID `json:"payment_intent_id" db:"payment_intent_id" binding:"omitempty"`
+	Provider          string     `json:"provider" db:"provider" binding:"omitempty"`
+	EventID           string     `json:"event_id" db:"event_id" binding:"omitempty"`
+	Payload           string     `json:"payload" db:"payload" binding:"omitempty"`
+	CreatedAt         time.Time  `json:"created_at,omitempty" db:"created_at"`
+}
+
+func (p *PaymentCallback) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.PaymentCallbackID = id
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PaymentIntent struct {
+	PaymentIntentID       uuid.UUID                `json:"payment_intent_id" db:"payment_intent_id" binding:"omitempty"`
+	InstallmentID         uuid.UUID                `json:"installment_id" db:"installment_id" binding:"omitempty"`
+	VoucherID             *uuid.UUID               `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
+	VoucherCodeID         *uuid.UUID               `json:"voucher_code_id" db:"voucher_code_id" binding:"omitempty"`
+	PaymentIntentStatusID int                      `json:"payment_intent_status_id" db:"payment_intent_status_id" binding:"omitempty"`
+	Provider              string                   `json:"provider" db:"provider" binding:"omitempty"`
+	Channel               string                   `json:"channel" db:"channel" binding:"omitempty"`
+	ExternalID            string                   `json:"external_id" db:"external_id" binding:"omitempty"`
+	AccountNumber         string                   `json:"account_number" db:"account_number" binding:"omitempty"`
+	PaymentFine           float64                  `json:"payment_fine" db:"payment_fine" binding:"omitempty"`
+	PaymentDiscount       float64                  `json:"payment_discount" db:"payment_discount" binding:"omitempty"`
+	PaymentFineWaiver     float64                  `json:"payment_fine_waiver" db:"payment_fine_waiver" binding:"omitempty"`
+	PaymentAmount         float64                  `json:"payment_amount" db:"payment_amount" binding:"omitempty"`
+	ExpireDate            time.Time                `json:"expire_date" db:"expire_date" binding:"omitempty"`
+	PaidAt                *time.Time               `json:"paid_at" db:"paid_at" binding:"omitempty"`
+	CreatedAt             time.Time                `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt             time.Time                `json:"updated_at,omitempty" db:"updated_at"`
+	PaymentIntentStatus   *PaymentIntentStatusType `json:"payment_intent_status,omitempty" gorm:"foreignKey:PaymentIntentStatusID;references:PaymentIntentStatusID"`
+	Installment           *Installment             `json:"installment,omitempty" gorm:"foreignKey:InstallmentID;references:InstallmentID"`
+	Voucher               *Voucher                 `json:"voucher,omitempty" gorm:"foreignKey:VoucherID;references:VoucherID"`
+}
+
+func (p *PaymentIntent) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.PaymentIntentID = id
+	p.PaymentIntentStatusID = 1
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PaymentReversal struct {
+	PaymentReversalID  uuid.UUID `json:"payment_reversal_id" db:"payment_reversal_id" binding:"omitempty"`
+	PaymentID          uuid.UUID `json:"payment_id" db:"payment_id" binding:"omitempty"`
+	InstallmentID      uuid.UUID `json:"installment_id" db:"installment_id" binding:"omitempty"`
+	Reason             string    `json:"reason" db:"reason" binding:"omitempty"`
+	ReversedFine       float64   `json:"reversed_fine" db:"reversed_fine" binding:"omitempty"`
+	ReversedDiscount   float64   `json:"reversed_discount" db:"reversed_discount" binding:"omitempty"`
+	ReversedFineWaiver float64   `json:"reversed_fine_waiver" db:"reversed_fine_waiver" binding:"omitempty"`
+	ReversedAmount     float64   `json:"reversed_amount" db:"reversed_amount" binding:"omitempty"`
+	CreatedAt          time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+func (p *PaymentReversal) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.PaymentReversalID = id
+	return nil
+}
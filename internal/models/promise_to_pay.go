@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PromiseToPay struct {
+	PromiseToPayID   uuid.UUID `json:"promise_to_pay_id" db:"promise_to_pay_id" binding:"omitempty"`
+	CollectionCaseID uuid.UUID `json:"collection_case_id" db:"collection_case_id" binding:"omitempty"`
+	UserID           uuid.UUID `json:"user_id" db:"user_id" binding:"omitempty"`
+	Amount           float64   `json:"amount" db:"amount" binding:"omitempty"`
+	PromiseDate      time.Time `json:"promise_date" db:"promise_date" binding:"omitempty"`
+	CreatedAt        time.Time `json:"created_at,omitempty" db:"created_at"`
+	User             *User     `json:"user,omitempty" gorm:"foreignKey:UserID;references:UserID"`
+}
+
+func (p *PromiseToPay) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	p.PromiseToPayID = id
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ReconciliationItem struct {
+	ReconciliationItemID   uuid.UUID                 `json:"reconciliation_item_id" db:"reconciliation_item_id" binding:"omitempty"`
+	StatementImportID      uuid.UUID                 `json:"statement_import_id" db:"statement_import_id" binding:"omitempty"`
+	ReconciliationStatusID int                       `json:"reconciliation_status_id" db:"reconciliation_status_id" binding:"omitempty"`
+	PaymentIntentID        *uuid.UUID                `json:"payment_intent_id" db:"payment_intent_id" binding:"omitempty"`
+	PaymentID              *uuid.UUID                `json:"payment_id" db:"payment_id" binding:"omitempty"`
+	LineNumber             int                       `json:"line_number" db:"line_number" binding:"omitempty"`
+	LineHash               string                    `json:"-" db:"line_hash" binding:"omitempty"`
+	TransactionDate        time.Time                 `json:"transaction_date" db:"transaction_date" binding:"omitempty"`
+	Reference              string                    `json:"reference" db:"reference" binding:"omitempty"`
+	Description            string                    `json:"description" db:"description" binding:"omitempty"`
+	Amount                 float64                   `json:"amount" db:"amount" binding:"omitempty"`
+	Note                   string                    `json:"note" db:"note" binding:"omitempty"`
+	ResolvedAt             *time.Time                `json:"resolved_at" db:"resolved_at" binding:"omitempty"`
+	CreatedAt              time.Time                 `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt              time.Time                 `json:"updated_at,omitempty" db:"updated_at"`
+	ReconciliationStatus   *ReconciliationStatusType `json:"reconciliation_status,omitempty" gorm:"foreignKey:ReconciliationStatusID;references:ReconciliationStatusID"`
+	PaymentIntent          *PaymentIntent            `json:"payment_intent,omitempty" gorm:"foreignKey:PaymentIntentID;references:PaymentIntentID"`
+	Payment                *Payment                  `json:"payment,omitempty" gorm:"foreignKey:PaymentID;references:PaymentID"`
+}
+
+func (r *ReconciliationItem) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	r.ReconciliationItemID = id
+	r.ReconciliationStatusID = 1
+
+	return nil
+}
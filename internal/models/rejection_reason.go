@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type RejectionReason struct {
+	RejectionReasonID int       `json:"rejection_reason_id" db:"rejection_reason_id" binding:"omitempty"`
+	Code              string    `json:"code" db:"code" binding:"omitempty"`
+	Name              string    `json:"name" db:"name" binding:"omitempty"`
+	IsActive          bool      `json:"is_active" db:"is_active" binding:"omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// RejectionReasonCount is one row of the rejection breakdown: how many loans
+// were rejected for a reason within a period.
+type RejectionReasonCount struct {
+	Period            time.Time `json:"period"`
+	RejectionReasonID int       `json:"rejection_reason_id"`
+	Code              string    `json:"code"`
+	Name              string    `json:"name"`
+	Total             int64     `json:"total"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const MaxRestructuringTenor = 36
+
+type Restructuring struct {
+	RestructuringID        uuid.UUID                `json:"restructuring_id" db:"restructuring_id" binding:"omitempty"`
+	LendingID              uuid.UUID                `json:"lending_id" db:"lending_id" binding:"omitempty"`
+	RestructuringStatusID  int                      `json:"restructuring_status_id" db:"restructuring_status_id" binding:"omitempty"`
+	UserID                 uuid.UUID                `json:"user_id" db:"user_id" binding:"omitempty"`
+	Version                int                      `json:"version" db:"version" binding:"omitempty"`
+	Tenor                  int                      `json:"tenor" db:"tenor" binding:"omitempty"`
+	CapitalizeFines        bool                     `json:"capitalize_fines" db:"capitalize_fines" binding:"omitempty"`
+	OutstandingAmount      float64                  `json:"outstanding_amount" db:"outstanding_amount" binding:"omitempty"`
+	CapitalizedFine        float64                  `json:"capitalized_fine" db:"capitalized_fine" binding:"omitempty"`
+	TotalAmount            float64                  `json:"total_amount" db:"total_amount" binding:"omitempty"`
+	InstallmentAmount      float64                  `json:"installment_amount" db:"installment_amount" binding:"omitempty"`
+	FirstDueDate           time.Time                `json:"first_due_date" db:"first_due_date" binding:"omitempty"`
+	RespondedAt            *time.Time               `json:"responded_at" db:"responded_at" binding:"omitempty"`
+	CreatedAt              time.Time                `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt              time.Time                `json:"updated_at,omitempty" db:"updated_at"`
+	RestructuringStatus    *RestructuringStatusType `json:"restructuring_status,omitempty" gorm:"foreignKey:RestructuringStatusID;references:RestructuringStatusID"`
+	Lending                *Lending                 `json:"lending,omitempty" gorm:"foreignKey:LendingID;references:LendingID"`
+	Installments           *[]Installment           `json:"installments,omitempty" gorm:"foreignKey:RestructuringID;references:RestructuringID"`
+	SupersededInstallments *[]Installment           `json:"superseded_installments,omitempty" gorm:"foreignKey:SupersededByID;references:RestructuringID"`
+}
+
+func (r *Restructuring) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	r.RestructuringID = id
+	r.RestructuringStatusID = 1
+
+	return nil
+}
+
+// BuildInstallments generates the new schedule. Every installment is charged
+// InstallmentAmount except the last one, which takes the remainder.
+func (r *Restructuring) BuildInstallments() ([]*Installment, error) {
+	var installments []*Installment
+	for i := 0; i < r.Tenor; i++ {
+		installment := &Installment{}
+		if err := installment.PrepareCreate(); err != nil {
+			return installments, err
+		}
+
+		installment.LendingID = r.LendingID
+		installment.RestructuringID = &r.RestructuringID
+		installment.Amount = r.InstallmentAmount
+		if i == r.Tenor-1 {
+			installment.Amount = r.TotalAmount - r.InstallmentAmount*float64(r.Tenor-1)
+		}
+		installment.DueDate = DueDateAfter(r.FirstDueDate, i)
+		installments = append(installments, installment)
+	}
+
+	return installments, nil
+}
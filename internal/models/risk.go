@@ -0,0 +1,59 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	RiskBucketCurrent    = "current"
+	RiskBucketWrittenOff = "written off"
+	RiskBucketClosed     = "closed"
+)
+
+// LoanPosition is a loan's unpaid principal at a point in time. Cohort is the
+// Jakarta month the loan was originated in.
+type LoanPosition struct {
+	LendingID     uuid.UUID
+	Cohort        time.Time
+	Outstanding   float64
+	OldestDueDate time.Time
+	WrittenOff    bool
+}
+
+func (p *LoanPosition) DaysPastDue(at time.Time) int {
+	installment := &Installment{DueDate: p.OldestDueDate}
+	return installment.DelayDays(at)
+}
+
+// Bucket places the position in a delinquency bucket, with current and
+// written off loans kept apart.
+func (p *LoanPosition) Bucket(at time.Time) string {
+	if p.WrittenOff {
+		return RiskBucketWrittenOff
+	}
+
+	days := p.DaysPastDue(at)
+	if days == 0 {
+		return RiskBucketCurrent
+	}
+
+	return BucketForDays(days)
+}
+
+// RiskBuckets lists every bucket a loan on the book can fall in, in order.
+func RiskBuckets() []string {
+	buckets := []string{RiskBucketCurrent}
+	for _, bucket := range DelinquencyBuckets {
+		buckets = append(buckets, bucket.Name)
+	}
+
+	return buckets
+}
+
+// LoanCohort sums the loans originated in a month.
+type LoanCohort struct {
+	Cohort time.Time
+	Loans  int64
+	Amount float64
+}
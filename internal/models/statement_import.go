@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type StatementImport struct {
+	StatementImportID uuid.UUID             `json:"statement_import_id" db:"statement_import_id" binding:"omitempty"`
+	FileName          string                `json:"file_name" db:"file_name" binding:"omitempty"`
+	Format            string                `json:"format" db:"format" binding:"omitempty"`
+	TotalLines        int                   `json:"total_lines" db:"total_lines" binding:"omitempty"`
+	MatchedLines      int                   `json:"matched_lines" db:"matched_lines" binding:"omitempty"`
+	UnmatchedLines    int                   `json:"unmatched_lines" db:"unmatched_lines" binding:"omitempty"`
+	DuplicateLines    int                   `json:"duplicate_lines" db:"duplicate_lines" binding:"omitempty"`
+	CreatedAt         time.Time             `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at,omitempty" db:"updated_at"`
+	Items             *[]ReconciliationItem `json:"items,omitempty" gorm:"foreignKey:StatementImportID;references:StatementImportID"`
+}
+
+func (s *StatementImport) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	s.StatementImportID = id
+
+	return nil
+}
@@ -2,20 +2,31 @@ package models
 
 import (
 	"github.com/google/uuid"
-	"gorm.io/gorm"
+	"math"
 	"time"
 )
 
 type Voucher struct {
-	VoucherID       uuid.UUID      `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
-	Name            string         `json:"name" db:"name" binding:"omitempty"`
-	DiscountPayment int            `json:"discount_payment" db:"discount_payment" binding:"omitempty"`
-	DiscountQuota   int            `json:"discount_quota" db:"discount_quota" binding:"omitempty"`
-	ActiveDate      time.Time      `json:"active_date,omitempty" db:"active_date"`
-	ExpireDate      time.Time      `json:"expire_date,omitempty" db:"expire_date"`
-	CreatedAt       time.Time      `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at,omitempty" db:"updated_at"`
-	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty" db:"deleted_at"`
+	VoucherID             uuid.UUID            `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
+	Name                  string               `json:"name" db:"name" binding:"omitempty"`
+	VoucherStatusID       int                  `json:"voucher_status_id" db:"voucher_status_id" binding:"omitempty"`
+	VoucherDiscountTypeID int                  `json:"voucher_discount_type_id" db:"voucher_discount_type_id" binding:"omitempty"`
+	DiscountPayment       int                  `json:"discount_payment" db:"discount_payment" binding:"omitempty"`
+	DiscountAmount        float64              `json:"discount_amount" db:"discount_amount" binding:"omitempty"`
+	MaxDiscount           float64              `json:"max_discount" db:"max_discount" binding:"omitempty"`
+	DiscountQuota         int                  `json:"discount_quota" db:"discount_quota" binding:"omitempty"`
+	PerUserLimit          int                  `json:"per_user_limit" db:"per_user_limit" binding:"omitempty"`
+	MinInstallmentAmount  float64              `json:"min_installment_amount" db:"min_installment_amount" binding:"omitempty"`
+	CodeRequired          bool                 `json:"code_required" db:"code_required" binding:"omitempty"`
+	ActiveDate            time.Time            `json:"active_date,omitempty" db:"active_date"`
+	ExpireDate            time.Time            `json:"expire_date,omitempty" db:"expire_date"`
+	CreatedAt             time.Time            `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt             time.Time            `json:"updated_at,omitempty" db:"updated_at"`
+	ArchivedAt            *time.Time           `json:"archived_at" db:"archived_at"`
+	CreditHealths         []CreditHealthType   `json:"credit_healths,omitempty" gorm:"many2many:voucher_credit_healths;foreignKey:VoucherID;joinForeignKey:VoucherID;references:CreditHealthID;joinReferences:CreditHealthID"`
+	LoanProducts          []LoanProduct        `json:"loan_products,omitempty" gorm:"many2many:voucher_loan_products;foreignKey:VoucherID;joinForeignKey:VoucherID;references:LoanProductID;joinReferences:LoanProductID"`
+	VoucherStatus         *VoucherStatusType   `json:"voucher_status,omitempty" gorm:"foreignKey:VoucherStatusID;references:VoucherStatusID"`
+	VoucherDiscountType   *VoucherDiscountType `json:"voucher_discount_type,omitempty" gorm:"foreignKey:VoucherDiscountTypeID;references:VoucherDiscountTypeID"`
 }
 
 func (v *Voucher) PrepareCreate() error {
@@ -28,3 +39,104 @@ func (v *Voucher) PrepareCreate() error {
 
 	return nil
 }
+
+// Discount works out what the voucher takes off an installment. Percentage
+// and fine waiver vouchers use DiscountPayment as the percentage, of the
+// installment or of the late fine, capped at MaxDiscount when it is set.
+// Fixed amount vouchers take off DiscountAmount, but never more than the
+// installment.
+func (v *Voucher) Discount(installmentAmount, fine float64) (discount, fineWaiver float64) {
+	switch v.VoucherDiscountTypeID {
+	case 2:
+		discount = math.Min(v.DiscountAmount, installmentAmount)
+	case 3:
+		fineWaiver = v.capDiscount(math.Floor(fine * (float64(v.DiscountPayment) / 100.0)))
+	default:
+		discount = v.capDiscount(math.Floor(installmentAmount * (float64(v.DiscountPayment) / 100.0)))
+	}
+
+	return discount, fineWaiver
+}
+
+func (v *Voucher) capDiscount(discount float64) float64 {
+	if v.MaxDiscount > 0 && discount > v.MaxDiscount {
+		return v.MaxDiscount
+	}
+
+	return discount
+}
+
+// StatusAt is the lifecycle status of the voucher at the given time. It is
+// the only place the lifecycle rules live; the stored status is refreshed
+// from it whenever a voucher changes and by the lifecycle job as time passes.
+// Archiving is an admin decision and is never undone here.
+func (v *Voucher) StatusAt(at time.Time) int {
+	switch {
+	case v.VoucherStatusID == 5:
+		return 5
+	case v.DiscountQuota <= 0:
+		return 3
+	case at.Sub(v.ExpireDate).Seconds() > 0:
+		return 4
+	case at.Sub(v.ActiveDate).Seconds() < 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (v *Voucher) IsActive(at time.Time) bool {
+	return v.StatusAt(at) == 2
+}
+
+func (v *Voucher) IsArchived() bool {
+	return v.VoucherStatusID == 5
+}
+
+// RefreshStatus stores the status at the given time, dropping a preloaded
+// status that no longer matches.
+func (v *Voucher) RefreshStatus(at time.Time) bool {
+	status := v.StatusAt(at)
+	if status == v.VoucherStatusID {
+		return false
+	}
+
+	v.VoucherStatusID = status
+	v.VoucherStatus = nil
+	return true
+}
+
+// IsEligible checks the voucher segment against an installment. Empty credit
+// health and loan product lists leave the voucher open to every debtor.
+// Associations must be loaded.
+func (v *Voucher) IsEligible(creditHealthID int, loanProductID uuid.UUID, installmentAmount float64) bool {
+	if installmentAmount < v.MinInstallmentAmount {
+		return false
+	}
+
+	if len(v.CreditHealths) > 0 {
+		eligible := false
+		for _, creditHealth := range v.CreditHealths {
+			if creditHealth.CreditHealthID == creditHealthID {
+				eligible = true
+			}
+		}
+		if !eligible {
+			return false
+		}
+	}
+
+	if len(v.LoanProducts) > 0 {
+		eligible := false
+		for _, loanProduct := range v.LoanProducts {
+			if loanProduct.LoanProductID == loanProductID {
+				eligible = true
+			}
+		}
+		if !eligible {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"crypto/rand"
+	"github.com/google/uuid"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// voucherCodeAlphabet leaves out characters that are easily misread, such as
+// 0 and O or 1 and I.
+const voucherCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const voucherCodeLength = 8
+
+type VoucherCode struct {
+	VoucherCodeID uuid.UUID  `json:"voucher_code_id" db:"voucher_code_id" binding:"omitempty"`
+	VoucherID     uuid.UUID  `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
+	Code          string     `json:"code" db:"code" binding:"omitempty"`
+	SingleUse     bool       `json:"single_use" db:"single_use" binding:"omitempty"`
+	RedeemedAt    *time.Time `json:"redeemed_at" db:"redeemed_at" binding:"omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty" db:"created_at"`
+	Voucher       *Voucher   `json:"voucher,omitempty" gorm:"foreignKey:VoucherID;references:VoucherID"`
+}
+
+func (c *VoucherCode) PrepareCreate(voucherID uuid.UUID, code string, singleUse bool) error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	c.VoucherCodeID = id
+	c.VoucherID = voucherID
+	c.Code = NormalizeVoucherCode(code)
+	c.SingleUse = singleUse
+
+	return nil
+}
+
+func (c *VoucherCode) IsRedeemed() bool {
+	return c.SingleUse && c.RedeemedAt != nil
+}
+
+func NormalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateVoucherCode returns a random code, joined to the prefix with a dash
+// when one is given.
+func GenerateVoucherCode(prefix string) (string, error) {
+	code := make([]byte, voucherCodeLength)
+	max := big.NewInt(int64(len(voucherCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = voucherCodeAlphabet[n.Int64()]
+	}
+
+	if prefix == "" {
+		return string(code), nil
+	}
+
+	return NormalizeVoucherCode(prefix) + "-" + string(code), nil
+}
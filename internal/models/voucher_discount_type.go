@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type VoucherDiscountType struct {
+	VoucherDiscountTypeID int       `json:"voucher_discount_type_id" db:"voucher_discount_type_id" binding:"omitempty"`
+	Name                  string    `json:"name" db:"name" binding:"omitempty"`
+	CreatedAt             time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
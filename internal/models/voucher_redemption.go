@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// VoucherRedemption records a voucher used by a settled payment. It is removed
+// when the payment is reversed, so it only counts redemptions that stand.
+type VoucherRedemption struct {
+	VoucherRedemptionID uuid.UUID  `json:"voucher_redemption_id" db:"voucher_redemption_id" binding:"omitempty"`
+	VoucherID           uuid.UUID  `json:"voucher_id" db:"voucher_id" binding:"omitempty"`
+	VoucherCodeID       *uuid.UUID `json:"voucher_code_id" db:"voucher_code_id" binding:"omitempty"`
+	DebtorID            uuid.UUID  `json:"debtor_id" db:"debtor_id" binding:"omitempty"`
+	PaymentID           uuid.UUID  `json:"payment_id" db:"payment_id" binding:"omitempty"`
+	CreatedAt           time.Time  `json:"created_at,omitempty" db:"created_at"`
+}
+
+func (r *VoucherRedemption) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	r.VoucherRedemptionID = id
+	return nil
+}
+
+// VoucherRedemptionStats sums up the redemptions of one voucher.
+type VoucherRedemptionStats struct {
+	VoucherID     uuid.UUID `json:"voucher_id" db:"voucher_id"`
+	Redemptions   int64     `json:"redemptions" db:"redemptions"`
+	DiscountGiven float64   `json:"discount_given" db:"discount_given"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type WriteOff struct {
+	WriteOffID    uuid.UUID `json:"write_off_id" db:"write_off_id" binding:"omitempty"`
+	LendingID     uuid.UUID `json:"lending_id" db:"lending_id" binding:"omitempty"`
+	UserID        uuid.UUID `json:"user_id" db:"user_id" binding:"omitempty"`
+	Reason        string    `json:"reason" db:"reason" binding:"omitempty"`
+	PrincipalLoss float64   `json:"principal_loss" db:"principal_loss" binding:"omitempty"`
+	FineLoss      float64   `json:"fine_loss" db:"fine_loss" binding:"omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+func (w *WriteOff) PrepareCreate() error {
+	id, err := uuid.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	w.WriteOffID = id
+	return nil
+}
@@ -0,0 +1,7 @@
+package payment
+
+import "github.com/gin-gonic/gin"
+
+type Handlers interface {
+	Callback(c *gin.Context)
+}
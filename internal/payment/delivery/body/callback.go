@@ -0,0 +1,75 @@
+package body
+
+import (
+	"final-project-backend/pkg/gateway"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type CallbackRequest struct {
+	EventID       string    `json:"event_id"`
+	ExternalID    string    `json:"external_id"`
+	AccountNumber string    `json:"account_number"`
+	Status        string    `json:"status"`
+	Amount        float64   `json:"amount"`
+	PaidAt        string    `json:"paid_at"`
+	PaidAtTime    time.Time `json:"-"`
+}
+
+func (r *CallbackRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"event_id":    "",
+			"external_id": "",
+			"status":      "",
+			"amount":      "",
+			"paid_at":     "",
+		},
+	}
+
+	r.EventID = strings.TrimSpace(r.EventID)
+	if r.EventID == "" {
+		unprocessableEntity = true
+		entity.Fields["event_id"] = InvalidEventIDFormatMessage
+	}
+
+	r.ExternalID = strings.TrimSpace(r.ExternalID)
+	if r.ExternalID == "" {
+		unprocessableEntity = true
+		entity.Fields["external_id"] = InvalidExternalIDFormatMessage
+	}
+
+	r.Status = strings.ToLower(strings.TrimSpace(r.Status))
+	switch r.Status {
+	case gateway.StatusPaid:
+		if r.Amount <= 0 {
+			unprocessableEntity = true
+			entity.Fields["amount"] = InvalidAmountFormatMessage
+		}
+
+		r.PaidAt = strings.TrimSpace(r.PaidAt)
+		t, err := time.Parse(time.RFC3339, r.PaidAt)
+		if err != nil {
+			unprocessableEntity = true
+			entity.Fields["paid_at"] = InvalidDateFormatMessage
+		}
+		r.PaidAtTime = t
+	case gateway.StatusExpired, gateway.StatusFailed:
+	default:
+		unprocessableEntity = true
+		entity.Fields["status"] = InvalidStatusFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
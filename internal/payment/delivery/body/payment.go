@@ -0,0 +1,13 @@
+package body
+
+const (
+	InvalidEventIDFormatMessage    = "Invalid event id format."
+	InvalidExternalIDFormatMessage = "Invalid external id format."
+	InvalidStatusFormatMessage     = "Invalid status format."
+	InvalidAmountFormatMessage     = "Invalid amount format."
+	InvalidDateFormatMessage       = "Invalid paid at format."
+)
+
+type UnprocessableEntity struct {
+	Fields map[string]string `json:"fields"`
+}
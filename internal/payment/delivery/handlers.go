@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"final-project-backend/config"
+	"final-project-backend/internal/payment"
+	"final-project-backend/internal/payment/delivery/body"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/logger"
+	"final-project-backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type paymentHandlers struct {
+	cfg       *config.Config
+	paymentUC payment.UseCase
+	logger    logger.Logger
+}
+
+func NewPaymentHandlers(cfg *config.Config, paymentUC payment.UseCase, log logger.Logger) payment.Handlers {
+	return &paymentHandlers{cfg: cfg, paymentUC: paymentUC, logger: log}
+}
+
+func (h *paymentHandlers) Callback(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	var requestBody body.CallbackRequest
+	if err := json.Unmarshal(payload, &requestBody); err != nil {
+		response.ErrorResponse(c.Writer, response.BadRequestMessage, http.StatusBadRequest)
+		return
+	}
+
+	invalidFields, err := requestBody.Validate()
+	if err != nil {
+		response.ErrorResponseData(c.Writer, invalidFields, response.UnprocessableEntityMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	intent, err := h.paymentUC.HandleCallback(c, c.Request.Header, payload, requestBody)
+	if err != nil {
+		var e *httperror.Error
+		if !errors.As(err, &e) {
+			h.logger.Errorf("HandlerCallback, Error: %s", err)
+			response.ErrorResponse(c.Writer, response.InternalServerErrorMessage, http.StatusInternalServerError)
+			return
+		}
+
+		response.ErrorResponse(c.Writer, e.Err.Error(), e.Status)
+		return
+	}
+
+	response.SuccessResponse(c.Writer, intent, http.StatusOK)
+}
@@ -0,0 +1,11 @@
+package delivery
+
+import (
+	"final-project-backend/internal/middleware"
+	"final-project-backend/internal/payment"
+	"github.com/gin-gonic/gin"
+)
+
+func MapPaymentRoutes(paymentGroup *gin.RouterGroup, h payment.Handlers, mw *middleware.MWManager) {
+	paymentGroup.POST("/callback", h.Callback)
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"context"
+	"final-project-backend/internal/models"
+)
+
+type Repository interface {
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
+	GetPaymentIntentByExternalID(ctx context.Context, provider, externalID string) (*models.PaymentIntent, error)
+	GetPaymentIntentForUpdate(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error)
+	UpdatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error)
+	GetCallbackByEventID(ctx context.Context, provider, eventID string) (*models.PaymentCallback, error)
+	CreateCallback(ctx context.Context, callback *models.PaymentCallback) (*models.PaymentCallback, error)
+	GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error)
+	GetLendingByID(ctx context.Context, lendingID string) (*models.Lending, error)
+	GetDebtorByID(ctx context.Context, debtorID string) (*models.Debtor, error)
+	GetVoucherForUpdate(ctx context.Context, voucherID string) (*models.Voucher, error)
+	CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	UpdateInstallment(ctx context.Context, installment *models.Installment) (*models.Installment, error)
+	UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error)
+	UpdateDebtor(ctx context.Context, debtor *models.Debtor) (*models.Debtor, error)
+	UpdateVoucher(ctx context.Context, voucher *models.Voucher) error
+	GetVoucherCodeForUpdate(ctx context.Context, voucherCodeID string) (*models.VoucherCode, error)
+	UpdateVoucherCode(ctx context.Context, voucherCode *models.VoucherCode) (*models.VoucherCode, error)
+	CreateVoucherRedemption(ctx context.Context, redemption *models.VoucherRedemption) (*models.VoucherRedemption, error)
+	GetVoucherRedemptionByPaymentID(ctx context.Context, paymentID string) (*models.VoucherRedemption, error)
+	DeleteVoucherRedemption(ctx context.Context, redemption *models.VoucherRedemption) error
+	GetPaymentForUpdate(ctx context.Context, paymentID string) (*models.Payment, error)
+	GetReversalByPaymentID(ctx context.Context, paymentID string) (*models.PaymentReversal, error)
+	CreateReversal(ctx context.Context, reversal *models.PaymentReversal) (*models.PaymentReversal, error)
+	GetSettledPaymentsByDebtorID(ctx context.Context, debtorID string) ([]*models.Payment, error)
+	GetPaymentByID(ctx context.Context, paymentID string) (*models.Payment, error)
+	CreateCreditHealthHistory(ctx context.Context, history *models.CreditHealthHistory) (*models.CreditHealthHistory, error)
+}
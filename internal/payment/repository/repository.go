@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type paymentRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) payment.Repository {
+	return &paymentRepo{db: db}
+}
+
+func (r *paymentRepo) Transaction(ctx context.Context, fn func(repo payment.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&paymentRepo{db: tx})
+	})
+}
+
+func (r *paymentRepo) GetPaymentIntentByExternalID(ctx context.Context, provider, externalID string) (*models.PaymentIntent, error) {
+	intent := &models.PaymentIntent{}
+	if err := r.db.WithContext(ctx).Preload("PaymentIntentStatus").
+		Where("provider = ? AND external_id = ?", provider, externalID).First(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *paymentRepo) GetPaymentIntentForUpdate(ctx context.Context, paymentIntentID string) (*models.PaymentIntent, error) {
+	intent := &models.PaymentIntent{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("payment_intent_id = ?", paymentIntentID).First(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *paymentRepo) UpdatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error) {
+	if err := r.db.Omit("PaymentIntentStatus", "Installment", "Voucher").WithContext(ctx).
+		Where("payment_intent_id = ?", intent.PaymentIntentID).Save(intent).Error; err != nil {
+		return intent, err
+	}
+
+	if err := r.db.Preload("PaymentIntentStatus").WithContext(ctx).
+		Where("payment_intent_id = ?", intent.PaymentIntentID).First(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *paymentRepo) GetCallbackByEventID(ctx context.Context, provider, eventID string) (*models.PaymentCallback, error) {
+	callback := &models.PaymentCallback{}
+	if err := r.db.WithContext(ctx).Where("provider = ? AND event_id = ?", provider, eventID).First(callback).Error; err != nil {
+		return callback, err
+	}
+
+	return callback, nil
+}
+
+func (r *paymentRepo) CreateCallback(ctx context.Context, callback *models.PaymentCallback) (*models.PaymentCallback, error) {
+	if err := r.db.WithContext(ctx).Create(callback).Error; err != nil {
+		return callback, err
+	}
+
+	return callback, nil
+}
+
+func (r *paymentRepo) GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error) {
+	installment := &models.Installment{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("installment_id = ?", installmentID).First(installment).Error; err != nil {
+		return installment, err
+	}
+
+	return installment, nil
+}
+
+func (r *paymentRepo) GetLendingByID(ctx context.Context, lendingID string) (*models.Lending, error) {
+	lending := &models.Lending{}
+	if err := r.db.WithContext(ctx).Preload("Installments").
+		Where("lending_id = ?", lendingID).First(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
+func (r *paymentRepo) GetDebtorByID(ctx context.Context, debtorID string) (*models.Debtor, error) {
+	debtor := &models.Debtor{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("debtor_id = ?", debtorID).First(debtor).Error; err != nil {
+		return debtor, err
+	}
+
+	return debtor, nil
+}
+
+func (r *paymentRepo) GetVoucherForUpdate(ctx context.Context, voucherID string) (*models.Voucher, error) {
+	voucher := &models.Voucher{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("voucher_id = ?", voucherID).First(voucher).Error; err != nil {
+		return voucher, err
+	}
+
+	return voucher, nil
+}
+
+func (r *paymentRepo) CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	if err := r.db.WithContext(ctx).Create(payment).Error; err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepo) UpdateInstallment(ctx context.Context, installment *models.Installment) (*models.Installment, error) {
+	if err := r.db.Omit("InstallmentStatus", "Lending").WithContext(ctx).Where("installment_id = ?", installment.InstallmentID).Save(installment).Error; err != nil {
+		return installment, err
+	}
+
+	return installment, nil
+}
+
+func (r *paymentRepo) UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error) {
+	if err := r.db.Omit("Debtor", "LoanProduct", "LendingStatus", "Installments", "Guarantors", "RejectionReason").WithContext(ctx).Where("lending_id = ?", lending.LendingID).Save(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
+func (r *paymentRepo) UpdateDebtor(ctx context.Context, debtor *models.Debtor) (*models.Debtor, error) {
+	if err := r.db.Omit("ContractTracking", "CreditHealth", "KycStatus", "User").WithContext(ctx).Where("debtor_id = ?", debtor.DebtorID).Save(debtor).Error; err != nil {
+		return debtor, err
+	}
+
+	return debtor, nil
+}
+
+func (r *paymentRepo) UpdateVoucher(ctx context.Context, voucher *models.Voucher) error {
+	if err := r.db.WithContext(ctx).Omit("VoucherStatus", "VoucherDiscountType", "CreditHealths", "LoanProducts").Where("voucher_id = ?", voucher.VoucherID).Save(voucher).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *paymentRepo) GetPaymentForUpdate(ctx context.Context, paymentID string) (*models.Payment, error) {
+	payment := &models.Payment{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("payment_id = ?", paymentID).First(payment).Error; err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepo) GetReversalByPaymentID(ctx context.Context, paymentID string) (*models.PaymentReversal, error) {
+	reversal := &models.PaymentReversal{}
+	if err := r.db.WithContext(ctx).Where("payment_id = ?", paymentID).First(reversal).Error; err != nil {
+		return reversal, err
+	}
+
+	return reversal, nil
+}
+
+func (r *paymentRepo) CreateReversal(ctx context.Context, reversal *models.PaymentReversal) (*models.PaymentReversal, error) {
+	if err := r.db.WithContext(ctx).Create(reversal).Error; err != nil {
+		return reversal, err
+	}
+
+	return reversal, nil
+}
+
+func (r *paymentRepo) GetSettledPaymentsByDebtorID(ctx context.Context, debtorID string) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	if err := r.db.WithContext(ctx).
+		Joins("inner join installments on installments.installment_id = payments.installment_id").
+		Joins("inner join lendings on installments.lending_id = lendings.lending_id").
+		Where("lendings.debtor_id = ?", debtorID).
+		Where("NOT EXISTS (SELECT 1 FROM payment_reversals WHERE payment_reversals.payment_id = payments.payment_id)").
+		Preload("Installment").
+		Order("payments.payment_date asc").
+		Find(&payments).Error; err != nil {
+		return payments, err
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepo) GetPaymentByID(ctx context.Context, paymentID string) (*models.Payment, error) {
+	payment := &models.Payment{}
+	if err := r.db.WithContext(ctx).
+		Preload("Voucher").
+		Preload("Installment.InstallmentStatus").
+		Preload("Reversal").
+		Where("payment_id = ?", paymentID).First(payment).Error; err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepo) CreateCreditHealthHistory(ctx context.Context, history *models.CreditHealthHistory) (*models.CreditHealthHistory, error) {
+	if err := r.db.WithContext(ctx).Create(history).Error; err != nil {
+		return history, err
+	}
+
+	return history, nil
+}
+
+func (r *paymentRepo) GetVoucherCodeForUpdate(ctx context.Context, voucherCodeID string) (*models.VoucherCode, error) {
+	voucherCode := &models.VoucherCode{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("voucher_code_id = ?", voucherCodeID).First(voucherCode).Error; err != nil {
+		return voucherCode, err
+	}
+
+	return voucherCode, nil
+}
+
+func (r *paymentRepo) UpdateVoucherCode(ctx context.Context, voucherCode *models.VoucherCode) (*models.VoucherCode, error) {
+	if err := r.db.WithContext(ctx).Omit("Voucher").Where("voucher_code_id = ?", voucherCode.VoucherCodeID).Save(voucherCode).Error; err != nil {
+		return voucherCode, err
+	}
+
+	return voucherCode, nil
+}
+
+func (r *paymentRepo) CreateVoucherRedemption(ctx context.Context, redemption *models.VoucherRedemption) (*models.VoucherRedemption, error) {
+	if err := r.db.WithContext(ctx).Create(redemption).Error; err != nil {
+		return redemption, err
+	}
+
+	return redemption, nil
+}
+
+func (r *paymentRepo) GetVoucherRedemptionByPaymentID(ctx context.Context, paymentID string) (*models.VoucherRedemption, error) {
+	redemption := &models.VoucherRedemption{}
+	if err := r.db.WithContext(ctx).Where("payment_id = ?", paymentID).First(redemption).Error; err != nil {
+		return redemption, err
+	}
+
+	return redemption, nil
+}
+
+func (r *paymentRepo) DeleteVoucherRedemption(ctx context.Context, redemption *models.VoucherRedemption) error {
+	if err := r.db.WithContext(ctx).Where("voucher_redemption_id = ?", redemption.VoucherRedemptionID).Delete(redemption).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package payment
+
+import (
+	"context"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment/delivery/body"
+	"net/http"
+	"time"
+)
+
+type UseCase interface {
+	HandleCallback(ctx context.Context, header http.Header, payload []byte, body body.CallbackRequest) (*models.PaymentIntent, error)
+	SettleIntent(ctx context.Context, paymentIntentID string, paidAt time.Time) (*models.Payment, error)
+	ReversePayment(ctx context.Context, paymentID string, reason string) (*models.Payment, error)
+	WithRepository(repo Repository) UseCase
+}
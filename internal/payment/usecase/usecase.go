@@ -0,0 +1,513 @@
+package usecase
+
+import (
+	"context"
+	"final-project-backend/config"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
+	"final-project-backend/internal/payment/delivery/body"
+	"final-project-backend/pkg/gateway"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/metrics"
+	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+type paymentUC struct {
+	cfg         *config.Config
+	paymentRepo payment.Repository
+	provider    gateway.Provider
+}
+
+func NewPaymentUseCase(cfg *config.Config, paymentRepo payment.Repository, provider gateway.Provider) payment.UseCase {
+	return &paymentUC{cfg: cfg, paymentRepo: paymentRepo, provider: provider}
+}
+
+// WithRepository returns the use case working on repo, so a caller can
+// settle payments inside a transaction of its own.
+func (u *paymentUC) WithRepository(repo payment.Repository) payment.UseCase {
+	return &paymentUC{cfg: u.cfg, paymentRepo: repo, provider: u.provider}
+}
+
+func (u *paymentUC) HandleCallback(ctx context.Context, header http.Header, payload []byte, body body.CallbackRequest) (*models.PaymentIntent, error) {
+	var settled *models.Payment
+	if err := u.provider.VerifyCallback(header, payload); err != nil {
+		return nil, httperror.New(http.StatusUnauthorized, response.InvalidCallbackSignature)
+	}
+
+	intent, err := u.paymentRepo.GetPaymentIntentByExternalID(ctx, u.provider.Name(), body.ExternalID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.PaymentIntentNotExist)
+		}
+		return nil, err
+	}
+
+	err = u.paymentRepo.Transaction(ctx, func(repo payment.Repository) error {
+		intent, err = repo.GetPaymentIntentForUpdate(ctx, intent.PaymentIntentID.String())
+		if err != nil {
+			return err
+		}
+
+		// Checked under the intent lock, so a retry racing the first delivery
+		// waits for it and is then seen as a duplicate.
+		_, err = repo.GetCallbackByEventID(ctx, u.provider.Name(), body.EventID)
+		if err == nil {
+			return nil
+		}
+
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		callback := &models.PaymentCallback{}
+		callback.PaymentIntentID = &intent.PaymentIntentID
+		callback.Provider = u.provider.Name()
+		callback.EventID = body.EventID
+		callback.Payload = string(payload)
+		if err := callback.PrepareCreate(); err != nil {
+			return err
+		}
+
+		if _, err := repo.CreateCallback(ctx, callback); err != nil {
+			return err
+		}
+
+		if intent.PaymentIntentStatusID == 2 || intent.PaymentIntentStatusID == 4 || intent.PaymentIntentStatusID == 5 {
+			return nil
+		}
+
+		switch body.Status {
+		case gateway.StatusPaid:
+			installment, err := repo.GetInstallmentByID(ctx, intent.InstallmentID.String())
+			if err != nil {
+				return err
+			}
+
+			usedUp := false
+			if intent.VoucherID != nil {
+				voucher, err := repo.GetVoucherForUpdate(ctx, intent.VoucherID.String())
+				if err != nil {
+					return err
+				}
+				usedUp = voucherUsedUp(voucher)
+			}
+
+			// The gateway has already taken the money, so a payment that
+			// cannot settle the installment, or was discounted by a voucher
+			// with no quota left, is kept for a refund instead of being
+			// refused, which would only make the gateway retry.
+			if installment.InstallmentStatusID != 1 || body.Amount < intent.PaymentAmount || usedUp {
+				intent.PaymentIntentStatusID = 5
+				_, err := repo.UpdatePaymentIntent(ctx, intent)
+				return err
+			}
+
+			settled, err = u.settle(ctx, repo, intent, body.PaidAtTime)
+			if err != nil {
+				return err
+			}
+		default:
+			intent.PaymentIntentStatusID = 3
+			if _, err := repo.UpdatePaymentIntent(ctx, intent); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if settled != nil {
+		recordSettled(settled)
+	}
+
+	return intent, nil
+}
+
+func (u *paymentUC) SettleIntent(ctx context.Context, paymentIntentID string, paidAt time.Time) (*models.Payment, error) {
+	var settled *models.Payment
+	err := u.paymentRepo.Transaction(ctx, func(repo payment.Repository) error {
+		intent, err := repo.GetPaymentIntentForUpdate(ctx, paymentIntentID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return httperror.New(http.StatusBadRequest, response.PaymentIntentNotExist)
+			}
+			return err
+		}
+
+		if intent.PaymentIntentStatusID == 2 {
+			return httperror.New(http.StatusBadRequest, response.PaymentIntentAlreadyPaid)
+		}
+
+		if intent.PaymentIntentStatusID == 4 {
+			return httperror.New(http.StatusBadRequest, response.PaymentIntentReversed)
+		}
+
+		if intent.PaymentIntentStatusID == 5 {
+			return httperror.New(http.StatusBadRequest, response.PaymentIntentRefundRequired)
+		}
+
+		settled, err = u.settle(ctx, repo, intent, paidAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recordSettled(settled)
+	return settled, nil
+}
+
+// voucherUsedUp tells whether the voucher has no quota left for another
+// discount. Pending intents reserve the quota, so this only happens when an
+// admin lowers it after an intent was created. An archived voucher still
+// honours the intents made before it was archived.
+func voucherUsedUp(voucher *models.Voucher) bool {
+	return !voucher.IsArchived() && voucher.DiscountQuota <= 0
+}
+
+func recordSettled(settled *models.Payment) {
+	metrics.PaymentSettled(settled.PaymentAmount, settled.PaymentFine-settled.PaymentFineWaiver, settled.VoucherID != nil)
+}
+
+// redeemVoucher records the voucher used by a settled payment and uses up a
+// single use code. The gateway has already taken the money, so a code redeemed
+// by another payment in the meantime does not stop the settlement.
+func (u *paymentUC) redeemVoucher(ctx context.Context, repo payment.Repository, intent *models.PaymentIntent, debtorID uuid.UUID, settled *models.Payment, paidAt time.Time) error {
+	if intent.VoucherCodeID != nil {
+		voucherCode, err := repo.GetVoucherCodeForUpdate(ctx, intent.VoucherCodeID.String())
+		if err != nil {
+			return err
+		}
+
+		if voucherCode.SingleUse && voucherCode.RedeemedAt == nil {
+			voucherCode.RedeemedAt = &paidAt
+			if _, err := repo.UpdateVoucherCode(ctx, voucherCode); err != nil {
+				return err
+			}
+		}
+	}
+
+	redemption := &models.VoucherRedemption{}
+	redemption.VoucherID = *intent.VoucherID
+	redemption.VoucherCodeID = intent.VoucherCodeID
+	redemption.DebtorID = debtorID
+	redemption.PaymentID = settled.PaymentID
+	if err := redemption.PrepareCreate(); err != nil {
+		return err
+	}
+
+	_, err := repo.CreateVoucherRedemption(ctx, redemption)
+	return err
+}
+
+// releaseVoucher undoes redeemVoucher for a reversed payment, so the debtor
+// can use the voucher and a single use code again.
+func (u *paymentUC) releaseVoucher(ctx context.Context, repo payment.Repository, reversed *models.Payment) error {
+	redemption, err := repo.GetVoucherRedemptionByPaymentID(ctx, reversed.PaymentID.String())
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if redemption.VoucherCodeID != nil {
+		voucherCode, err := repo.GetVoucherCodeForUpdate(ctx, redemption.VoucherCodeID.String())
+		if err != nil {
+			return err
+		}
+
+		if voucherCode.SingleUse {
+			voucherCode.RedeemedAt = nil
+			if _, err := repo.UpdateVoucherCode(ctx, voucherCode); err != nil {
+				return err
+			}
+		}
+	}
+
+	return repo.DeleteVoucherRedemption(ctx, redemption)
+}
+
+func (u *paymentUC) settle(ctx context.Context, repo payment.Repository, intent *models.PaymentIntent, paidAt time.Time) (*models.Payment, error) {
+	payment := &models.Payment{}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	paidAt = paidAt.In(loc)
+
+	installment, err := repo.GetInstallmentByID(ctx, intent.InstallmentID.String())
+	if err != nil {
+		return payment, err
+	}
+
+	if installment.InstallmentStatusID != 1 {
+		return payment, httperror.New(http.StatusBadRequest, response.InstallmentAlreadyPaid)
+	}
+
+	lending, err := repo.GetLendingByID(ctx, installment.LendingID.String())
+	if err != nil {
+		return payment, err
+	}
+
+	debtor, err := repo.GetDebtorByID(ctx, lending.DebtorID.String())
+	if err != nil {
+		return payment, err
+	}
+
+	if intent.VoucherID != nil {
+		voucher, err := repo.GetVoucherForUpdate(ctx, intent.VoucherID.String())
+		if err != nil {
+			return payment, err
+		}
+
+		if voucherUsedUp(voucher) {
+			return payment, httperror.New(http.StatusBadRequest, response.VoucherQuotaUsedUp)
+		}
+
+		if !voucher.IsArchived() {
+			voucher.DiscountQuota -= 1
+			voucher.RefreshStatus(paidAt)
+			if err := repo.UpdateVoucher(ctx, voucher); err != nil {
+				return payment, err
+			}
+		}
+	}
+
+	// Credit used never goes below zero, so the payment keeps what it
+	// actually released for a reversal to restore.
+	released := installment.Amount
+	if debtor.CreditUsed < released {
+		released = debtor.CreditUsed
+	}
+
+	delay := installment.DelayDays(paidAt)
+	payment.InstallmentID = installment.InstallmentID
+	payment.VoucherID = intent.VoucherID
+	payment.PaymentIntentID = &intent.PaymentIntentID
+	payment.PaymentDate = paidAt
+	payment.PaymentFine = intent.PaymentFine
+	payment.PaymentDiscount = intent.PaymentDiscount
+	payment.PaymentFineWaiver = intent.PaymentFineWaiver
+	payment.PaymentAmount = intent.PaymentAmount
+	payment.CreditReleased = released
+	if err := payment.PrepareCreate(); err != nil {
+		return payment, err
+	}
+
+	payment, err = repo.CreatePayment(ctx, payment)
+	if err != nil {
+		return payment, err
+	}
+
+	if intent.VoucherID != nil {
+		if err := u.redeemVoucher(ctx, repo, intent, lending.DebtorID, payment, paidAt); err != nil {
+			return payment, err
+		}
+	}
+
+	debtor.CreditUsed -= released
+
+	previousCreditHealthID, previousTotalDelay := debtor.CreditHealthID, debtor.TotalDelay
+	debtor.TotalDelay = models.AccrueDelay(debtor.TotalDelay, delay)
+	debtor.CreditHealthID = models.CreditHealthForDelay(debtor.TotalDelay)
+
+	if _, err := repo.UpdateDebtor(ctx, debtor); err != nil {
+		return payment, err
+	}
+
+	history, err := models.NewCreditHealthHistory(debtor, 1, previousCreditHealthID, previousTotalDelay)
+	if err != nil {
+		return payment, err
+	}
+	if history != nil {
+		history.DelayDays = delay
+		history.ReferenceID = &payment.PaymentID
+		if _, err := repo.CreateCreditHealthHistory(ctx, history); err != nil {
+			return payment, err
+		}
+	}
+
+	installment.InstallmentStatusID = 2
+	if _, err := repo.UpdateInstallment(ctx, installment); err != nil {
+		return payment, err
+	}
+
+	// Installments superseded by a restructuring are kept for history only.
+	totalPaid := 0
+	totalInstallments := 0
+	for _, installment := range *lending.Installments {
+		if installment.InstallmentStatusID == 3 {
+			continue
+		}
+
+		totalInstallments++
+		if installment.InstallmentStatusID == 2 {
+			totalPaid++
+		}
+	}
+
+	// Payments on a written off lending are recoveries and leave it written off.
+	if lending.LendingStatusID != 6 {
+		if totalPaid == totalInstallments-1 {
+			lending.LendingStatusID = 4
+		} else {
+			lending.LendingStatusID = 3
+		}
+
+		if _, err := repo.UpdateLending(ctx, lending); err != nil {
+			return payment, err
+		}
+	}
+
+	intent.PaymentIntentStatusID = 2
+	intent.PaidAt = &paidAt
+	if _, err := repo.UpdatePaymentIntent(ctx, intent); err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+func (u *paymentUC) ReversePayment(ctx context.Context, paymentID string, reason string) (*models.Payment, error) {
+	err := u.paymentRepo.Transaction(ctx, func(repo payment.Repository) error {
+		original, err := repo.GetPaymentForUpdate(ctx, paymentID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return httperror.New(http.StatusBadRequest, response.PaymentNotExist)
+			}
+			return err
+		}
+
+		_, err = repo.GetReversalByPaymentID(ctx, paymentID)
+		if err == nil {
+			return httperror.New(http.StatusBadRequest, response.PaymentAlreadyReversed)
+		}
+
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		installment, err := repo.GetInstallmentByID(ctx, original.InstallmentID.String())
+		if err != nil {
+			return err
+		}
+
+		lending, err := repo.GetLendingByID(ctx, installment.LendingID.String())
+		if err != nil {
+			return err
+		}
+
+		debtor, err := repo.GetDebtorByID(ctx, lending.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		if original.VoucherID != nil {
+			voucher, err := repo.GetVoucherForUpdate(ctx, original.VoucherID.String())
+			if err != nil {
+				return err
+			}
+
+			// Giving the quota back makes an exhausted voucher usable again.
+			// One archived by an admin stays archived.
+			if !voucher.IsArchived() {
+				voucher.DiscountQuota += 1
+				voucher.RefreshStatus(time.Now())
+				if err := repo.UpdateVoucher(ctx, voucher); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := u.releaseVoucher(ctx, repo, original); err != nil {
+			return err
+		}
+
+		reversal := &models.PaymentReversal{}
+		reversal.PaymentID = original.PaymentID
+		reversal.InstallmentID = original.InstallmentID
+		reversal.Reason = reason
+		reversal.ReversedFine = original.PaymentFine
+		reversal.ReversedDiscount = original.PaymentDiscount
+		reversal.ReversedFineWaiver = original.PaymentFineWaiver
+		reversal.ReversedAmount = original.PaymentAmount
+		if err := reversal.PrepareCreate(); err != nil {
+			return err
+		}
+
+		if _, err := repo.CreateReversal(ctx, reversal); err != nil {
+			return err
+		}
+
+		installment.InstallmentStatusID = 1
+		if _, err := repo.UpdateInstallment(ctx, installment); err != nil {
+			return err
+		}
+
+		if lending.LendingStatusID != 6 {
+			lending.LendingStatusID = 3
+			if _, err := repo.UpdateLending(ctx, lending); err != nil {
+				return err
+			}
+		}
+
+		payments, err := repo.GetSettledPaymentsByDebtorID(ctx, debtor.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		totalDelay := 0
+		for _, settled := range payments {
+			totalDelay = models.AccrueDelay(totalDelay, settled.Installment.DelayDays(settled.PaymentDate))
+		}
+
+		previousCreditHealthID, previousTotalDelay := debtor.CreditHealthID, debtor.TotalDelay
+		debtor.CreditUsed = debtor.CreditUsed + original.CreditReleased
+		debtor.TotalDelay = totalDelay
+		debtor.CreditHealthID = models.CreditHealthForDelay(totalDelay)
+		if _, err := repo.UpdateDebtor(ctx, debtor); err != nil {
+			return err
+		}
+
+		history, err := models.NewCreditHealthHistory(debtor, 2, previousCreditHealthID, previousTotalDelay)
+		if err != nil {
+			return err
+		}
+		if history != nil {
+			history.ReferenceID = &original.PaymentID
+			if _, err := repo.CreateCreditHealthHistory(ctx, history); err != nil {
+				return err
+			}
+		}
+
+		if original.PaymentIntentID != nil {
+			intent, err := repo.GetPaymentIntentForUpdate(ctx, original.PaymentIntentID.String())
+			if err != nil {
+				return err
+			}
+
+			intent.PaymentIntentStatusID = 4
+			if _, err := repo.UpdatePaymentIntent(ctx, intent); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reversed, err := u.paymentRepo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return reversed, err
+	}
+
+	return reversed, nil
+}
@@ -0,0 +1,350 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"final-project-backend/config"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/payment"
+	"final-project-backend/internal/payment/delivery/body"
+	"final-project-backend/internal/payment/mocks"
+	"final-project-backend/internal/payment/usecase"
+	"final-project-backend/pkg/gateway"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type callbackFixture struct {
+	intent      *models.PaymentIntent
+	installment *models.Installment
+	lending     *models.Lending
+	debtor      *models.Debtor
+}
+
+func newCallbackFixture() *callbackFixture {
+	lending := &models.Lending{LendingID: uuid.New(), DebtorID: uuid.New(), LendingStatusID: 3}
+	installment := &models.Installment{
+		InstallmentID:       uuid.New(),
+		LendingID:           lending.LendingID,
+		InstallmentStatusID: 1,
+		Amount:              1000000,
+		DueDate:             time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC),
+	}
+	second := models.Installment{InstallmentID: uuid.New(), LendingID: lending.LendingID, InstallmentStatusID: 1}
+	lending.Installments = &[]models.Installment{*installment, second}
+
+	return &callbackFixture{
+		intent: &models.PaymentIntent{
+			PaymentIntentID:       uuid.New(),
+			InstallmentID:         installment.InstallmentID,
+			PaymentIntentStatusID: 1,
+			Provider:              "fake",
+			ExternalID:            "fake-ref-1",
+			PaymentAmount:         1000000,
+		},
+		installment: installment,
+		lending:     lending,
+		debtor:      &models.Debtor{DebtorID: lending.DebtorID, CreditHealthID: 1, CreditLimit: 5000000, CreditUsed: 2000000},
+	}
+}
+
+// withVoucher discounts the fixture's intent with a voucher that has quota
+// uses left.
+func withVoucher(f *callbackFixture, quota int) *models.Voucher {
+	voucher := &models.Voucher{
+		VoucherID:       uuid.New(),
+		VoucherStatusID: 2,
+		DiscountQuota:   quota,
+		ExpireDate:      time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if quota <= 0 {
+		voucher.VoucherStatusID = 3
+	}
+
+	f.intent.VoucherID = &voucher.VoucherID
+	f.intent.PaymentDiscount = 50000
+	return voucher
+}
+
+// expectLockedIntent sets up the lookup of the intent and the transaction
+// that every callback passing the signature check goes through.
+func expectLockedIntent(repo *mocks.Repository, f *callbackFixture) {
+	repo.On("GetPaymentIntentByExternalID", mock.Anything, "fake", "fake-ref-1").Return(f.intent, nil)
+	repo.On("Transaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(repo payment.Repository) error) error {
+		return fn(repo)
+	})
+	repo.On("GetPaymentIntentForUpdate", mock.Anything, f.intent.PaymentIntentID.String()).Return(f.intent, nil)
+}
+
+func TestHandleCallback(t *testing.T) {
+	cfg := &config.Config{Gateway: config.GatewayConfig{Provider: "fake", CallbackSecret: "callbacksecret", CallbackTolerance: 300}}
+	provider := gateway.NewFakeProvider(cfg)
+	paidAt := time.Date(2026, 3, 20, 3, 0, 0, 0, time.UTC)
+	payload := []byte(`{"event_id":"evt-1"}`)
+
+	paid := body.CallbackRequest{EventID: "evt-1", ExternalID: "fake-ref-1", Status: gateway.StatusPaid, Amount: 1000000, PaidAtTime: paidAt}
+	expired := body.CallbackRequest{EventID: "evt-1", ExternalID: "fake-ref-1", Status: gateway.StatusExpired}
+
+	updatedStatus := func(status int) interface{} {
+		return mock.MatchedBy(func(intent *models.PaymentIntent) bool { return intent.PaymentIntentStatusID == status })
+	}
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		body       body.CallbackRequest
+		setup      func(repo *mocks.Repository, f *callbackFixture)
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			name:    "bad signature",
+			header:  http.Header{gateway.SignatureHeader: {"bad"}, gateway.TimestampHeader: {"1"}},
+			body:    paid,
+			setup:   func(repo *mocks.Repository, f *callbackFixture) {},
+			wantErr: httperror.New(http.StatusUnauthorized, response.InvalidCallbackSignature),
+		},
+		{
+			name:   "unknown intent",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				repo.On("GetPaymentIntentByExternalID", mock.Anything, "fake", "fake-ref-1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: httperror.New(http.StatusBadRequest, response.PaymentIntentNotExist),
+		},
+		{
+			name:   "duplicate event is acknowledged without changes",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(&models.PaymentCallback{}, nil)
+			},
+			wantStatus: 1,
+		},
+		{
+			name:   "callback for a paid intent is only recorded",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				f.intent.PaymentIntentStatusID = 2
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+			},
+			wantStatus: 2,
+		},
+		{
+			name:   "paid callback settles the installment",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("GetLendingByID", mock.Anything, f.lending.LendingID.String()).Return(f.lending, nil)
+				repo.On("GetDebtorByID", mock.Anything, f.debtor.DebtorID.String()).Return(f.debtor, nil)
+				repo.On("CreatePayment", mock.Anything, mock.MatchedBy(func(payment *models.Payment) bool { return payment.CreditReleased == 1000000 })).
+					Return(func(ctx context.Context, payment *models.Payment) *models.Payment { return payment }, nil)
+				repo.On("UpdateDebtor", mock.Anything, mock.MatchedBy(func(debtor *models.Debtor) bool { return debtor.CreditUsed == 1000000 })).Return(f.debtor, nil)
+				repo.On("UpdateInstallment", mock.Anything, mock.MatchedBy(func(installment *models.Installment) bool { return installment.InstallmentStatusID == 2 })).Return(f.installment, nil)
+				repo.On("UpdateLending", mock.Anything, mock.Anything).Return(f.lending, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(2)).Return(f.intent, nil)
+			},
+			wantStatus: 2,
+		},
+		{
+			name:   "paid callback releases no more credit than is used",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				f.debtor.CreditUsed = 400000
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("GetLendingByID", mock.Anything, f.lending.LendingID.String()).Return(f.lending, nil)
+				repo.On("GetDebtorByID", mock.Anything, f.debtor.DebtorID.String()).Return(f.debtor, nil)
+				repo.On("CreatePayment", mock.Anything, mock.MatchedBy(func(payment *models.Payment) bool { return payment.CreditReleased == 400000 })).
+					Return(func(ctx context.Context, payment *models.Payment) *models.Payment { return payment }, nil)
+				repo.On("UpdateDebtor", mock.Anything, mock.MatchedBy(func(debtor *models.Debtor) bool { return debtor.CreditUsed == 0 })).Return(f.debtor, nil)
+				repo.On("UpdateInstallment", mock.Anything, mock.Anything).Return(f.installment, nil)
+				repo.On("UpdateLending", mock.Anything, mock.Anything).Return(f.lending, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(2)).Return(f.intent, nil)
+			},
+			wantStatus: 2,
+		},
+		{
+			name:   "paid callback for a closed installment is kept for refund",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				f.installment.InstallmentStatusID = 2
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(5)).Return(f.intent, nil)
+			},
+			wantStatus: 5,
+		},
+		{
+			name:   "underpaid callback is kept for refund",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   body.CallbackRequest{EventID: "evt-1", ExternalID: "fake-ref-1", Status: gateway.StatusPaid, Amount: 500000, PaidAtTime: paidAt},
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(5)).Return(f.intent, nil)
+			},
+			wantStatus: 5,
+		},
+		{
+			name:   "paid callback uses up the voucher quota",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				voucher := withVoucher(f, 1)
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("GetVoucherForUpdate", mock.Anything, voucher.VoucherID.String()).Return(voucher, nil)
+				repo.On("GetLendingByID", mock.Anything, f.lending.LendingID.String()).Return(f.lending, nil)
+				repo.On("GetDebtorByID", mock.Anything, f.debtor.DebtorID.String()).Return(f.debtor, nil)
+				repo.On("UpdateVoucher", mock.Anything, mock.MatchedBy(func(voucher *models.Voucher) bool { return voucher.DiscountQuota == 0 })).Return(nil)
+				repo.On("CreatePayment", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, payment *models.Payment) *models.Payment { return payment }, nil)
+				repo.On("CreateVoucherRedemption", mock.Anything, mock.Anything).Return(&models.VoucherRedemption{}, nil)
+				repo.On("UpdateDebtor", mock.Anything, mock.Anything).Return(f.debtor, nil)
+				repo.On("UpdateInstallment", mock.Anything, mock.Anything).Return(f.installment, nil)
+				repo.On("UpdateLending", mock.Anything, mock.Anything).Return(f.lending, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(2)).Return(f.intent, nil)
+			},
+			wantStatus: 2,
+		},
+		{
+			name:   "paid callback discounted by a used up voucher is kept for refund",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   paid,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				voucher := withVoucher(f, 0)
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+				repo.On("GetVoucherForUpdate", mock.Anything, voucher.VoucherID.String()).Return(voucher, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(5)).Return(f.intent, nil)
+			},
+			wantStatus: 5,
+		},
+		{
+			name:   "expired callback expires the intent",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   expired,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(&models.PaymentCallback{}, nil)
+				repo.On("UpdatePaymentIntent", mock.Anything, updatedStatus(3)).Return(f.intent, nil)
+			},
+			wantStatus: 3,
+		},
+		{
+			name:   "failed write rolls back",
+			header: provider.SignCallback(payload, time.Now()),
+			body:   expired,
+			setup: func(repo *mocks.Repository, f *callbackFixture) {
+				expectLockedIntent(repo, f)
+				repo.On("GetCallbackByEventID", mock.Anything, "fake", "evt-1").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("CreateCallback", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+			},
+			wantErr: errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCallbackFixture()
+			repo := mocks.NewRepository(t)
+			tt.setup(repo, f)
+
+			uc := usecase.NewPaymentUseCase(cfg, repo, provider)
+			intent, err := uc.HandleCallback(context.Background(), tt.header, payload, tt.body)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, intent.PaymentIntentStatusID)
+		})
+	}
+}
+
+func TestReversePaymentRestoresReleasedCredit(t *testing.T) {
+	f := newCallbackFixture()
+	f.installment.InstallmentStatusID = 2
+	f.debtor.CreditUsed = 0
+	original := &models.Payment{
+		PaymentID:       uuid.New(),
+		InstallmentID:   f.installment.InstallmentID,
+		PaymentIntentID: &f.intent.PaymentIntentID,
+		PaymentAmount:   1000000,
+		CreditReleased:  400000,
+	}
+
+	repo := mocks.NewRepository(t)
+	repo.On("Transaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(repo payment.Repository) error) error {
+		return fn(repo)
+	})
+	repo.On("GetPaymentForUpdate", mock.Anything, original.PaymentID.String()).Return(original, nil)
+	repo.On("GetReversalByPaymentID", mock.Anything, original.PaymentID.String()).Return(nil, gorm.ErrRecordNotFound)
+	repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+	repo.On("GetLendingByID", mock.Anything, f.lending.LendingID.String()).Return(f.lending, nil)
+	repo.On("GetDebtorByID", mock.Anything, f.debtor.DebtorID.String()).Return(f.debtor, nil)
+	repo.On("GetVoucherRedemptionByPaymentID", mock.Anything, original.PaymentID.String()).Return(nil, gorm.ErrRecordNotFound)
+	repo.On("CreateReversal", mock.Anything, mock.Anything).Return(&models.PaymentReversal{}, nil)
+	repo.On("UpdateInstallment", mock.Anything, mock.MatchedBy(func(installment *models.Installment) bool { return installment.InstallmentStatusID == 1 })).Return(f.installment, nil)
+	repo.On("UpdateLending", mock.Anything, mock.Anything).Return(f.lending, nil)
+	repo.On("GetSettledPaymentsByDebtorID", mock.Anything, f.debtor.DebtorID.String()).Return([]*models.Payment{}, nil)
+	repo.On("UpdateDebtor", mock.Anything, mock.MatchedBy(func(debtor *models.Debtor) bool { return debtor.CreditUsed == 400000 })).Return(f.debtor, nil)
+	repo.On("GetPaymentIntentForUpdate", mock.Anything, f.intent.PaymentIntentID.String()).Return(f.intent, nil)
+	repo.On("UpdatePaymentIntent", mock.Anything, mock.MatchedBy(func(intent *models.PaymentIntent) bool { return intent.PaymentIntentStatusID == 4 })).Return(f.intent, nil)
+	repo.On("GetPaymentByID", mock.Anything, original.PaymentID.String()).Return(original, nil)
+
+	uc := usecase.NewPaymentUseCase(&config.Config{}, repo, gateway.NewFakeProvider(&config.Config{}))
+	_, err := uc.ReversePayment(context.Background(), original.PaymentID.String(), "Bounced transfer")
+	assert.NoError(t, err)
+}
+
+func TestSettleIntentRefusesUsedUpVoucher(t *testing.T) {
+	f := newCallbackFixture()
+	voucher := withVoucher(f, 0)
+
+	repo := mocks.NewRepository(t)
+	repo.On("Transaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(repo payment.Repository) error) error {
+		return fn(repo)
+	})
+	repo.On("GetPaymentIntentForUpdate", mock.Anything, f.intent.PaymentIntentID.String()).Return(f.intent, nil)
+	repo.On("GetInstallmentByID", mock.Anything, f.installment.InstallmentID.String()).Return(f.installment, nil)
+	repo.On("GetLendingByID", mock.Anything, f.lending.LendingID.String()).Return(f.lending, nil)
+	repo.On("GetDebtorByID", mock.Anything, f.debtor.DebtorID.String()).Return(f.debtor, nil)
+	repo.On("GetVoucherForUpdate", mock.Anything, voucher.VoucherID.String()).Return(voucher, nil)
+
+	uc := usecase.NewPaymentUseCase(&config.Config{}, repo, gateway.NewFakeProvider(&config.Config{}))
+	_, err := uc.SettleIntent(context.Background(), f.intent.PaymentIntentID.String(), time.Now())
+	assert.Equal(t, httperror.New(http.StatusBadRequest, response.VoucherQuotaUsedUp), err)
+}
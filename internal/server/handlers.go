@@ -1,17 +1,26 @@
 package server
 
 import (
+	"context"
 	"final-project-backend/internal/admin/delivery"
 	"final-project-backend/internal/admin/repository"
 	"final-project-backend/internal/admin/usecase"
 	authDelivery "final-project-backend/internal/auth/delivery"
 	authRepository "final-project-backend/internal/auth/repository"
 	authUseCase "final-project-backend/internal/auth/usecase"
+	collectionDelivery "final-project-backend/internal/collection/delivery"
+	collectionRepository "final-project-backend/internal/collection/repository"
+	collectionUseCase "final-project-backend/internal/collection/usecase"
 	"final-project-backend/internal/middleware"
+	paymentDelivery "final-project-backend/internal/payment/delivery"
+	paymentRepository "final-project-backend/internal/payment/repository"
+	paymentUseCase "final-project-backend/internal/payment/usecase"
 	userDelivery "final-project-backend/internal/user/delivery"
 	userRepository "final-project-backend/internal/user/repository"
 	userUseCase "final-project-backend/internal/user/usecase"
+	"final-project-backend/pkg/gateway"
 	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/storage"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -19,19 +28,88 @@ import (
 )
 
 func (s *Server) MapHandlers() error {
+	provider, err := gateway.NewProvider(s.cfg)
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage(s.cfg)
+	if err != nil {
+		return err
+	}
+
 	aRepo := authRepository.NewAuthRepository(s.db)
 	authUC := authUseCase.NewAuthUseCase(s.cfg, aRepo)
 	authHandlers := authDelivery.NewAuthHandlers(s.cfg, authUC, s.logger)
 
 	userRepo := userRepository.NewUserRepository(s.db)
-	userUC := userUseCase.NewUserUseCase(s.cfg, userRepo)
+	userUC := userUseCase.NewUserUseCase(s.cfg, userRepo, provider, store)
 	userHandlers := userDelivery.NewUserHandlers(s.cfg, userUC, s.logger)
 
+	paymentRepo := paymentRepository.NewPaymentRepository(s.db)
+	paymentUC := paymentUseCase.NewPaymentUseCase(s.cfg, paymentRepo, provider)
+	paymentHandlers := paymentDelivery.NewPaymentHandlers(s.cfg, paymentUC, s.logger)
+
 	adminRepo := repository.NewAdminRepository(s.db)
-	adminUC := usecase.NewAdminUseCase(s.cfg, adminRepo)
+	adminUC := usecase.NewAdminUseCase(s.cfg, adminRepo, paymentUC, store)
 	adminHandlers := delivery.NewAdminHandlers(s.cfg, adminUC, s.logger)
 
+	if s.cfg.Scoring.ProposalJob {
+		s.jobs = append(s.jobs, &job{
+			name:     "credit-limit-proposals",
+			interval: time.Second * s.cfg.Scoring.ProposalInterval,
+			run: func(ctx context.Context) error {
+				total, err := adminUC.ProposeCreditLimits(ctx)
+				if err != nil {
+					return err
+				}
+				s.logger.Infof("Job credit-limit-proposals, proposed: %d", total)
+				return nil
+			},
+		})
+	}
+
+	if s.cfg.Overdue.NotifyJob {
+		s.jobs = append(s.jobs, &job{
+			name:     "overdue-guarantor-notifications",
+			interval: time.Second * s.cfg.Overdue.NotifyInterval,
+			run: func(ctx context.Context) error {
+				total, err := userUC.NotifyOverdueGuarantors(ctx)
+				if err != nil {
+					return err
+				}
+				s.logger.Infof("Job overdue-guarantor-notifications, sent: %d", total)
+				return nil
+			},
+		})
+	}
+
+	if s.cfg.Voucher.LifecycleJob {
+		s.jobs = append(s.jobs, &job{
+			name:     "voucher-lifecycle",
+			interval: time.Second * s.cfg.Voucher.LifecycleInterval,
+			run: func(ctx context.Context) error {
+				total, err := adminUC.UpdateVoucherLifecycles(ctx)
+				if err != nil {
+					return err
+				}
+				s.logger.Infof("Job voucher-lifecycle, updated: %d", total)
+				return nil
+			},
+		})
+	}
+
+	collectionRepo := collectionRepository.NewCollectionRepository(s.db)
+	collectionUC := collectionUseCase.NewCollectionUseCase(s.cfg, collectionRepo)
+	collectionHandlers := collectionDelivery.NewCollectionHandlers(s.cfg, collectionUC, s.logger)
+
 	mw := middleware.NewMiddlewareManager(s.cfg, []string{"*"}, s.logger)
+	if s.cfg.Metrics.Enabled {
+		if err := s.mapMetrics(); err != nil {
+			return err
+		}
+	}
+
 	s.gin.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
@@ -44,6 +122,10 @@ func (s *Server) MapHandlers() error {
 		MaxAge: 12 * time.Hour,
 	}))
 
+	if err := s.mapHealthRoutes(); err != nil {
+		return err
+	}
+
 	s.gin.Static("/docs", "dist/")
 	s.gin.NoRoute(func(c *gin.Context) {
 		response.ErrorResponse(c.Writer, response.NotFoundMessage, http.StatusNotFound)
@@ -53,10 +135,14 @@ func (s *Server) MapHandlers() error {
 	authGroup := v1.Group("/auth")
 	userGroup := v1.Group("/user")
 	adminGroup := v1.Group("/admin")
+	paymentGroup := v1.Group("/payments")
+	collectionGroup := v1.Group("/collections")
 
 	authDelivery.MapAuthRoutes(authGroup, authHandlers, mw)
 	userDelivery.MapUserRoutes(userGroup, userHandlers, mw)
 	delivery.MapAdminRoutes(adminGroup, adminHandlers, mw)
+	paymentDelivery.MapPaymentRoutes(paymentGroup, paymentHandlers, mw)
+	collectionDelivery.MapCollectionRoutes(collectionGroup, collectionHandlers, mw)
 
 	return nil
 }
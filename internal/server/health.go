@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"final-project-backend/pkg/migrate"
+	"final-project-backend/pkg/response"
+	"final-project-backend/sql/migrations"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+const readyTimeout = 2 * time.Second
+
+// GitCommit and BuildTime are set at build time, e.g.
+// go build -ldflags "-X final-project-backend/internal/server.GitCommit=$(git rev-parse --short HEAD)".
+var (
+	GitCommit = "unknown"
+	BuildTime = "unknown"
+)
+
+type readiness struct {
+	Database   string          `json:"database"`
+	Migrations string          `json:"migrations"`
+	Jobs       []*jobHeartbeat `json:"jobs"`
+}
+
+type version struct {
+	AppVersion string `json:"app_version"`
+	GitCommit  string `json:"git_commit"`
+	BuildTime  string `json:"build_time"`
+}
+
+func (s *Server) mapHealthRoutes() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	s.gin.GET("/healthz", func(c *gin.Context) {
+		response.SuccessResponse(c.Writer, nil)
+	})
+
+	s.gin.GET("/readyz", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+		defer cancel()
+
+		ready := !s.shuttingDown.Load()
+		result := &readiness{Database: "ok", Migrations: "ok", Jobs: []*jobHeartbeat{}}
+
+		if err := sqlDB.PingContext(ctx); err != nil {
+			ready = false
+			result.Database = err.Error()
+		}
+
+		if pending, err := migrator.Pending(ctx); err != nil {
+			ready = false
+			result.Migrations = err.Error()
+		} else if len(pending) > 0 {
+			ready = false
+			result.Migrations = fmt.Sprintf("pending up to version %06d", pending[len(pending)-1].Version)
+		}
+
+		now := time.Now()
+		for _, j := range s.jobs {
+			heartbeat := j.heartbeat(now)
+			if heartbeat.Stale {
+				ready = false
+			}
+			result.Jobs = append(result.Jobs, heartbeat)
+		}
+
+		if !ready {
+			response.ErrorResponseData(c.Writer, result, response.NotReadyMessage, http.StatusServiceUnavailable)
+			return
+		}
+
+		response.SuccessResponse(c.Writer, result)
+	})
+
+	s.gin.GET("/version", func(c *gin.Context) {
+		response.SuccessResponse(c.Writer, &version{AppVersion: s.cfg.Server.AppVersion, GitCommit: GitCommit, BuildTime: BuildTime})
+	})
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// job is a background task run on a fixed interval while the server is up.
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+
+	mu        sync.Mutex
+	startedAt time.Time
+	lastRunAt *time.Time
+	lastError error
+}
+
+// jobHeartbeat is what readiness reports about a job. A job is stale once it
+// has missed two runs in a row, which means a run is stuck.
+type jobHeartbeat struct {
+	Name      string     `json:"name"`
+	Interval  string     `json:"interval"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	LastError string     `json:"last_error,omitempty"`
+	Stale     bool       `json:"stale"`
+}
+
+func (s *Server) startJobs(ctx context.Context) {
+	for _, j := range s.jobs {
+		go s.runJob(ctx, j)
+	}
+}
+
+func (s *Server) runJob(ctx context.Context, j *job) {
+	s.logger.Infof("Job %s scheduled every %s", j.name, j.interval)
+
+	j.mu.Lock()
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+
+	// Run once right away so a restart does not leave the work waiting for a
+	// full interval.
+	s.runJobOnce(ctx, j)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runJobOnce(ctx, j)
+		}
+	}
+}
+
+func (s *Server) runJobOnce(ctx context.Context, j *job) {
+	err := j.run(ctx)
+	if err != nil {
+		s.logger.Errorf("Job %s, Error: %s", j.name, err)
+	}
+
+	now := time.Now()
+	j.mu.Lock()
+	j.lastRunAt = &now
+	j.lastError = err
+	j.mu.Unlock()
+}
+
+func (j *job) heartbeat(now time.Time) *jobHeartbeat {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	heartbeat := &jobHeartbeat{Name: j.name, Interval: j.interval.String(), LastRunAt: j.lastRunAt}
+	if j.lastError != nil {
+		heartbeat.LastError = j.lastError.Error()
+	}
+
+	last := j.startedAt
+	if j.lastRunAt != nil {
+		last = *j.lastRunAt
+	}
+	heartbeat.Stale = !last.IsZero() && now.Sub(last) > 2*j.interval
+
+	return heartbeat
+}
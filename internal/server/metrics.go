@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"final-project-backend/pkg/metrics"
+)
+
+const defaultMetricsPath = "/metrics"
+
+// mapMetrics times requests and queries and serves them with the business
+// counters. It must run before any route is added, so the middleware sees
+// every route.
+func (s *Server) mapMetrics() error {
+	if s.cfg.Metrics.Token == "" {
+		return errors.New("metrics enabled without a token")
+	}
+
+	if err := metrics.RegisterDB(s.db); err != nil {
+		return err
+	}
+
+	path := s.cfg.Metrics.Path
+	if path == "" {
+		path = defaultMetricsPath
+	}
+
+	s.gin.Use(metrics.Middleware())
+	s.gin.GET(path, metrics.Handler(s.cfg.Metrics.Token))
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -24,6 +25,11 @@ type Server struct {
 	cfg    *config.Config
 	db     *gorm.DB
 	logger logger.Logger
+	jobs   []*job
+
+	// shuttingDown turns readiness off as soon as a stop signal arrives, so
+	// the load balancer drains the instance before it exits.
+	shuttingDown atomic.Bool
 }
 
 func NewServer(cfg *config.Config, db *gorm.DB, logger logger.Logger) *Server {
@@ -50,11 +56,28 @@ func (s *Server) Run() error {
 		return err
 	}
 
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	s.startJobs(jobCtx)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	<-quit
+	s.shuttingDown.Store(true)
 	s.logger.Info("Shutdown Server ...")
+	stopJobs()
+
+	// Keep serving while readiness reports the shutdown, so the probes see it
+	// and the load balancer stops routing here before the listener closes. A
+	// second signal skips the wait.
+	delay := time.Second * s.cfg.Server.ShutdownDelay
+	if delay > 0 {
+		s.logger.Infof("Draining for %s before closing the listener", delay)
+		select {
+		case <-time.After(delay):
+		case <-quit:
+		}
+	}
 
 	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
 	defer shutdown()
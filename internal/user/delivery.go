@@ -4,13 +4,24 @@ import "github.com/gin-gonic/gin"
 
 type Handlers interface {
 	DebtorDetails(c *gin.Context)
+	GetCreditHealth(c *gin.Context)
+	SubmitKyc(c *gin.Context)
+	GetKycSubmissions(c *gin.Context)
 	ContractConfirm(c *gin.Context)
+	GetLoanProducts(c *gin.Context)
 	CreateLoan(c *gin.Context)
 	GetLoans(c *gin.Context)
 	GetLoanByID(c *gin.Context)
+	CancelLoan(c *gin.Context)
 	CreatePayment(c *gin.Context)
 	GetInstallmentByID(c *gin.Context)
 	GetVouchers(c *gin.Context)
 	GetPayments(c *gin.Context)
 	UpdateUser(c *gin.Context)
+	GetRestructurings(c *gin.Context)
+	RespondRestructuring(c *gin.Context)
+	InviteGuarantor(c *gin.Context)
+	GetGuarantees(c *gin.Context)
+	RespondGuarantee(c *gin.Context)
+	GetNotifications(c *gin.Context)
 }
@@ -3,29 +3,31 @@ package body
 import (
 	"final-project-backend/pkg/httperror"
 	"final-project-backend/pkg/response"
+	"github.com/google/uuid"
 	"net/http"
 	"strings"
 )
 
 type CreateLoan struct {
-	LoadPeriodID int     `json:"loan_period_id"`
-	Name         string  `json:"name"`
-	Amount       float64 `json:"amount"`
+	LoanProductID string  `json:"loan_product_id"`
+	Name          string  `json:"name"`
+	Amount        float64 `json:"amount"`
 }
 
 func (r *CreateLoan) Validate() (UnprocessableEntity, error) {
 	unprocessableEntity := false
 	entity := UnprocessableEntity{
 		Fields: map[string]string{
-			"loan_period_id": "",
-			"name":           "",
-			"amount":         "",
+			"loan_product_id": "",
+			"name":            "",
+			"amount":          "",
 		},
 	}
 
-	if r.LoadPeriodID == 0 {
+	r.LoanProductID = strings.TrimSpace(r.LoanProductID)
+	if _, err := uuid.Parse(r.LoanProductID); err != nil {
 		unprocessableEntity = true
-		entity.Fields["loan_period_id"] = InvalidLoanPeriodIDFormatMessage
+		entity.Fields["loan_product_id"] = InvalidLoanProductIDFormatMessage
 	}
 
 	r.Name = strings.TrimSpace(r.Name)
@@ -34,7 +36,7 @@ func (r *CreateLoan) Validate() (UnprocessableEntity, error) {
 		entity.Fields["name"] = InvalidNameFormatMessage
 	}
 
-	if r.Amount < 1000000 {
+	if r.Amount <= 0 {
 		unprocessableEntity = true
 		entity.Fields["amount"] = InvalidAmountFormatMessage
 	}
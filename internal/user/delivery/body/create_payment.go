@@ -1,6 +1,7 @@
 package body
 
 import (
+	"final-project-backend/pkg/gateway"
 	"final-project-backend/pkg/httperror"
 	"final-project-backend/pkg/response"
 	"net/http"
@@ -8,20 +9,29 @@ import (
 )
 
 type CreatePayment struct {
-	LendingID string `json:"lending_id"`
-	VoucherID string `json:"voucher_id"`
+	LendingID   string `json:"lending_id"`
+	VoucherID   string `json:"voucher_id"`
+	VoucherCode string `json:"voucher_code"`
+	Channel     string `json:"channel"`
 }
 
 func (r *CreatePayment) Validate() (UnprocessableEntity, error) {
 	unprocessableEntity := false
 	entity := UnprocessableEntity{
 		Fields: map[string]string{
-			"lending_id": "",
-			"voucher_id": "",
+			"lending_id":   "",
+			"voucher_id":   "",
+			"voucher_code": "",
+			"channel":      "",
 		},
 	}
 
 	r.VoucherID = strings.TrimSpace(r.VoucherID)
+	r.VoucherCode = strings.ToUpper(strings.TrimSpace(r.VoucherCode))
+	if r.VoucherID != "" && r.VoucherCode != "" {
+		unprocessableEntity = true
+		entity.Fields["voucher_code"] = InvalidVoucherCodeFormatMessage
+	}
 
 	r.LendingID = strings.TrimSpace(r.LendingID)
 	if r.LendingID == "" {
@@ -29,6 +39,16 @@ func (r *CreatePayment) Validate() (UnprocessableEntity, error) {
 		entity.Fields["lending_id"] = InvalidLoanIDFormatMessage
 	}
 
+	r.Channel = strings.TrimSpace(r.Channel)
+	switch r.Channel {
+	case "":
+		r.Channel = gateway.ChannelVirtualAccount
+	case gateway.ChannelVirtualAccount, gateway.ChannelPaymentCode:
+	default:
+		unprocessableEntity = true
+		entity.Fields["channel"] = InvalidChannelFormatMessage
+	}
+
 	if unprocessableEntity {
 		return entity, httperror.New(
 			http.StatusUnprocessableEntity,
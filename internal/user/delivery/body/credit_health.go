@@ -0,0 +1,11 @@
+package body
+
+import "final-project-backend/internal/models"
+
+type CreditHealthResponse struct {
+	CreditHealth            *models.CreditHealthType      `json:"credit_health"`
+	TotalDelay              int                           `json:"total_delay"`
+	GoodDelayThreshold      int                           `json:"good_delay_threshold"`
+	OnTimePaymentsToRecover int                           `json:"on_time_payments_to_recover"`
+	Histories               []*models.CreditHealthHistory `json:"histories"`
+}
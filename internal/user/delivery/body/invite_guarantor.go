@@ -0,0 +1,37 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+type InviteGuarantorRequest struct {
+	Email string `json:"email"`
+}
+
+func (r *InviteGuarantorRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"email": "",
+		},
+	}
+
+	r.Email = strings.TrimSpace(r.Email)
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		unprocessableEntity = true
+		entity.Fields["email"] = InvalidEmailFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,36 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"net/http"
+	"strings"
+)
+
+type RespondGuaranteeRequest struct {
+	Action string `json:"action"`
+}
+
+func (r *RespondGuaranteeRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"action": "",
+		},
+	}
+
+	r.Action = strings.ToLower(strings.TrimSpace(r.Action))
+	if r.Action != "accept" && r.Action != "decline" {
+		unprocessableEntity = true
+		entity.Fields["action"] = InvalidActionFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
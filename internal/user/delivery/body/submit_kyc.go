@@ -0,0 +1,78 @@
+package body
+
+import (
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const maxKycFileSize = 5 << 20
+
+type SubmitKycRequest struct {
+	IDCard *multipart.FileHeader `form:"id_card"`
+	Selfie *multipart.FileHeader `form:"selfie"`
+}
+
+func (r *SubmitKycRequest) Validate() (UnprocessableEntity, error) {
+	unprocessableEntity := false
+	entity := UnprocessableEntity{
+		Fields: map[string]string{
+			"id_card": "",
+			"selfie":  "",
+		},
+	}
+
+	if !validKycFile(r.IDCard) {
+		unprocessableEntity = true
+		entity.Fields["id_card"] = InvalidFileFormatMessage
+	}
+
+	if !validKycFile(r.Selfie) {
+		unprocessableEntity = true
+		entity.Fields["selfie"] = InvalidFileFormatMessage
+	}
+
+	if unprocessableEntity {
+		return entity, httperror.New(
+			http.StatusUnprocessableEntity,
+			response.UnprocessableEntityMessage,
+		)
+	}
+
+	return entity, nil
+}
+
+// validKycFile accepts JPEG and PNG images only. The extension must agree
+// with the sniffed content so a renamed file is not stored as an image.
+func validKycFile(file *multipart.FileHeader) bool {
+	if file == nil || file.Size == 0 || file.Size > maxKycFileSize {
+		return false
+	}
+
+	var contentType string
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".jpg", ".jpeg":
+		contentType = "image/jpeg"
+	case ".png":
+		contentType = "image/png"
+	default:
+		return false
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil {
+		return false
+	}
+
+	return http.DetectContentType(head[:n]) == contentType
+}
@@ -1,7 +1,7 @@
 package body
 
 const (
-	InvalidLoanPeriodIDFormatMessage  = "Invalid loan period id format."
+	InvalidLoanProductIDFormatMessage = "Invalid loan product id format."
 	InvalidInstallmentIDFormatMessage = "Invalid installment id format."
 	InvalidLoanIDFormatMessage        = "Invalid loan id format."
 	InvalidAmountFormatMessage        = "Invalid amount format."
@@ -9,6 +9,11 @@ const (
 	InvalidPhoneNumberFormatMessage   = "Invalid phone number format."
 	InvalidAddressFormatMessage       = "Invalid address format."
 	InvalidEmailFormatMessage         = "Invalid email format."
+	InvalidChannelFormatMessage       = "Invalid channel format."
+	InvalidVoucherCodeFormatMessage   = "Invalid voucher code format."
+	InvalidActionFormatMessage        = "Invalid action format."
+	InvalidFileFormatMessage          = "Invalid file format."
+	InvalidReasonFormatMessage        = "Invalid reason format."
 )
 
 type UnprocessableEntity struct {
@@ -12,11 +12,22 @@ func MapUserRoutes(userGroup *gin.RouterGroup, h user.Handlers, mw *middleware.M
 	userGroup.GET("/details", h.DebtorDetails)
 	userGroup.PUT("/details", h.UpdateUser)
 	userGroup.PATCH("/details", h.ContractConfirm)
+	userGroup.GET("/credit-health", h.GetCreditHealth)
+	userGroup.GET("/kyc", h.GetKycSubmissions)
+	userGroup.POST("/kyc", h.SubmitKyc)
+	userGroup.GET("/loan-products", h.GetLoanProducts)
 	userGroup.GET("/loans", h.GetLoans)
 	userGroup.POST("/loans", h.CreateLoan)
 	userGroup.GET("/loans/:id", h.GetLoanByID)
+	userGroup.DELETE("/loans/:id", h.CancelLoan)
+	userGroup.POST("/loans/:id/guarantors", h.InviteGuarantor)
 	userGroup.GET("/loans/installments/:id", h.GetInstallmentByID)
 	userGroup.POST("/loans/installments/:id", h.CreatePayment)
 	userGroup.GET("/vouchers", h.GetVouchers)
 	userGroup.GET("/payments", h.GetPayments)
+	userGroup.GET("/restructurings", h.GetRestructurings)
+	userGroup.PUT("/restructurings/:id", h.RespondRestructuring)
+	userGroup.GET("/guarantees", h.GetGuarantees)
+	userGroup.PUT("/guarantees/:id", h.RespondGuarantee)
+	userGroup.GET("/notifications", h.GetNotifications)
 }
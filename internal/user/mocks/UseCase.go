@@ -18,6 +18,29 @@ type UseCase struct {
 	mock.Mock
 }
 
+// CancelLoan provides a mock function with given fields: ctx, userID, lendingID, _a3
+func (_m *UseCase) CancelLoan(ctx context.Context, userID string, lendingID string, _a3 body.CancelLoanRequest) (*models.Lending, error) {
+	ret := _m.Called(ctx, userID, lendingID, _a3)
+
+	var r0 *models.Lending
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.CancelLoanRequest) *models.Lending); ok {
+		r0 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Lending)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.CancelLoanRequest) error); ok {
+		r1 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConfirmContract provides a mock function with given fields: ctx, userID
 func (_m *UseCase) ConfirmContract(ctx context.Context, userID string) (*models.Debtor, error) {
 	ret := _m.Called(ctx, userID)
@@ -65,15 +88,15 @@ func (_m *UseCase) CreateLoan(ctx context.Context, userID string, _a2 body.Creat
 }
 
 // CreatePayment provides a mock function with given fields: ctx, userID, installmentID, _a3
-func (_m *UseCase) CreatePayment(ctx context.Context, userID string, installmentID string, _a3 body.CreatePayment) (*models.Payment, error) {
+func (_m *UseCase) CreatePayment(ctx context.Context, userID string, installmentID string, _a3 body.CreatePayment) (*models.PaymentIntent, error) {
 	ret := _m.Called(ctx, userID, installmentID, _a3)
 
-	var r0 *models.Payment
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.CreatePayment) *models.Payment); ok {
+	var r0 *models.PaymentIntent
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.CreatePayment) *models.PaymentIntent); ok {
 		r0 = rf(ctx, userID, installmentID, _a3)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Payment)
+			r0 = ret.Get(0).(*models.PaymentIntent)
 		}
 	}
 
@@ -87,6 +110,29 @@ func (_m *UseCase) CreatePayment(ctx context.Context, userID string, installment
 	return r0, r1
 }
 
+// GetCreditHealth provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetCreditHealth(ctx context.Context, userID string) (*body.CreditHealthResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *body.CreditHealthResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *body.CreditHealthResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*body.CreditHealthResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDebtorDetails provides a mock function with given fields: ctx, userID
 func (_m *UseCase) GetDebtorDetails(ctx context.Context, userID string) (*models.Debtor, error) {
 	ret := _m.Called(ctx, userID)
@@ -110,6 +156,29 @@ func (_m *UseCase) GetDebtorDetails(ctx context.Context, userID string) (*models
 	return r0, r1
 }
 
+// GetGuarantees provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetGuarantees(ctx context.Context, userID string) ([]*models.Guarantor, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Guarantor
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Guarantor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Guarantor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInstallmentByID provides a mock function with given fields: ctx, installmentID
 func (_m *UseCase) GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error) {
 	ret := _m.Called(ctx, installmentID)
@@ -133,6 +202,29 @@ func (_m *UseCase) GetInstallmentByID(ctx context.Context, installmentID string)
 	return r0, r1
 }
 
+// GetKycSubmissions provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetKycSubmissions(ctx context.Context, userID string) ([]*models.KycSubmission, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.KycSubmission
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.KycSubmission); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoanByID provides a mock function with given fields: ctx, lendingID
 func (_m *UseCase) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
 	ret := _m.Called(ctx, lendingID)
@@ -156,6 +248,29 @@ func (_m *UseCase) GetLoanByID(ctx context.Context, lendingID string) (*models.L
 	return r0, r1
 }
 
+// GetLoanProducts provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetLoanProducts(ctx context.Context, userID string) ([]*models.LoanProduct, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.LoanProduct
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.LoanProduct); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.LoanProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLoans provides a mock function with given fields: ctx, userID, name, status, pagination
 func (_m *UseCase) GetLoans(ctx context.Context, userID string, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	ret := _m.Called(ctx, userID, name, status, pagination)
@@ -179,6 +294,29 @@ func (_m *UseCase) GetLoans(ctx context.Context, userID string, name string, sta
 	return r0, r1
 }
 
+// GetNotifications provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetNotifications(ctx context.Context, userID string) ([]*models.Notification, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Notification
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Notification); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPayments provides a mock function with given fields: ctx, userID, name, pagination
 func (_m *UseCase) GetPayments(ctx context.Context, userID string, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
 	ret := _m.Called(ctx, userID, name, pagination)
@@ -202,6 +340,29 @@ func (_m *UseCase) GetPayments(ctx context.Context, userID string, name string,
 	return r0, r1
 }
 
+// GetRestructurings provides a mock function with given fields: ctx, userID
+func (_m *UseCase) GetRestructurings(ctx context.Context, userID string) ([]*models.Restructuring, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Restructuring
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Restructuring); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Restructuring)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVouchers provides a mock function with given fields: ctx, name, pagination
 func (_m *UseCase) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
 	ret := _m.Called(ctx, name, pagination)
@@ -225,6 +386,119 @@ func (_m *UseCase) GetVouchers(ctx context.Context, name string, pagination *uti
 	return r0, r1
 }
 
+// InviteGuarantor provides a mock function with given fields: ctx, userID, lendingID, _a3
+func (_m *UseCase) InviteGuarantor(ctx context.Context, userID string, lendingID string, _a3 body.InviteGuarantorRequest) (*models.Guarantor, error) {
+	ret := _m.Called(ctx, userID, lendingID, _a3)
+
+	var r0 *models.Guarantor
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.InviteGuarantorRequest) *models.Guarantor); ok {
+		r0 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Guarantor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.InviteGuarantorRequest) error); ok {
+		r1 = rf(ctx, userID, lendingID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyOverdueGuarantors provides a mock function with given fields: ctx
+func (_m *UseCase) NotifyOverdueGuarantors(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondGuarantee provides a mock function with given fields: ctx, userID, guarantorID, _a3
+func (_m *UseCase) RespondGuarantee(ctx context.Context, userID string, guarantorID string, _a3 body.RespondGuaranteeRequest) (*models.Guarantor, error) {
+	ret := _m.Called(ctx, userID, guarantorID, _a3)
+
+	var r0 *models.Guarantor
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.RespondGuaranteeRequest) *models.Guarantor); ok {
+		r0 = rf(ctx, userID, guarantorID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Guarantor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.RespondGuaranteeRequest) error); ok {
+		r1 = rf(ctx, userID, guarantorID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RespondRestructuring provides a mock function with given fields: ctx, userID, restructuringID, _a3
+func (_m *UseCase) RespondRestructuring(ctx context.Context, userID string, restructuringID string, _a3 body.RespondRestructuringRequest) (*models.Restructuring, error) {
+	ret := _m.Called(ctx, userID, restructuringID, _a3)
+
+	var r0 *models.Restructuring
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, body.RespondRestructuringRequest) *models.Restructuring); ok {
+		r0 = rf(ctx, userID, restructuringID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Restructuring)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, body.RespondRestructuringRequest) error); ok {
+		r1 = rf(ctx, userID, restructuringID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitKyc provides a mock function with given fields: ctx, userID, _a2
+func (_m *UseCase) SubmitKyc(ctx context.Context, userID string, _a2 body.SubmitKycRequest) (*models.KycSubmission, error) {
+	ret := _m.Called(ctx, userID, _a2)
+
+	var r0 *models.KycSubmission
+	if rf, ok := ret.Get(0).(func(context.Context, string, body.SubmitKycRequest) *models.KycSubmission); ok {
+		r0 = rf(ctx, userID, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, body.SubmitKycRequest) error); ok {
+		r1 = rf(ctx, userID, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUserByID provides a mock function with given fields: ctx, userID, _a2
 func (_m *UseCase) UpdateUserByID(ctx context.Context, userID string, _a2 body.UpdateUserRequest) (*models.User, error) {
 	ret := _m.Called(ctx, userID, _a2)
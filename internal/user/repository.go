@@ -4,25 +4,53 @@ import (
 	"context"
 	"final-project-backend/internal/models"
 	"final-project-backend/pkg/utils"
+	"time"
 )
 
 type Repository interface {
+	Transaction(ctx context.Context, fn func(repo Repository) error) error
 	GetLoans(ctx context.Context, debtorID, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetPayments(ctx context.Context, debtorID string, name string, pagination *utils.Pagination) (*utils.Pagination, error)
-	GetLoanPeriodByID(ctx context.Context, periodID int) (*models.LoanPeriod, error)
+	GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error)
+	GetActiveLoanProducts(ctx context.Context, creditHealthID int, at time.Time) ([]*models.LoanProduct, error)
 	GetDebtorDetailsByID(ctx context.Context, userID string) (*models.Debtor, error)
+	GetDebtorForUpdate(ctx context.Context, debtorID string) (*models.Debtor, error)
 	UpdateDebtorByID(ctx context.Context, debtor *models.Debtor) (*models.Debtor, error)
+	UpdateDebtorKycStatus(ctx context.Context, debtor *models.Debtor) error
 	CreateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error)
 	GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error)
+	GetLoanForUpdate(ctx context.Context, lendingID string) (*models.Lending, error)
+	UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error)
 	GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error)
 	GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error)
-	CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error)
-	UpdateInstallment(ctx context.Context, installment *models.Installment) (*models.Installment, error)
-	UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error)
-	UpdateVoucher(ctx context.Context, voucher *models.Voucher) error
-	DeleteVoucher(ctx context.Context, voucher *models.Voucher) error
+	GetVoucherForUpdate(ctx context.Context, voucherID string) (*models.Voucher, error)
+	GetVoucherCodeByCode(ctx context.Context, code string) (*models.VoucherCode, error)
+	CheckVoucherCodeTaken(ctx context.Context, voucherCodeID string, at time.Time) (bool, error)
+	CountVoucherRedemptions(ctx context.Context, voucherID, debtorID string) (int64, error)
+	CountPendingVoucherIntents(ctx context.Context, voucherID, debtorID string, at time.Time) (int64, error)
+	CreatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error)
+	ExpirePaymentIntents(ctx context.Context, installmentID string) error
 	CheckEmailExist(ctx context.Context, email string) (*models.User, error)
 	GetUserDetailsByID(ctx context.Context, userId string) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) (*models.User, error)
+	GetRestructurings(ctx context.Context, debtorID string) ([]*models.Restructuring, error)
+	GetRestructuringByID(ctx context.Context, restructuringID string) (*models.Restructuring, error)
+	GetRestructuringForUpdate(ctx context.Context, restructuringID string) (*models.Restructuring, error)
+	UpdateRestructuring(ctx context.Context, restructuring *models.Restructuring) (*models.Restructuring, error)
+	SupersedeInstallments(ctx context.Context, lendingID string, restructuringID string) error
+	CreateInstallments(ctx context.Context, installments []*models.Installment) error
+	GetCreditHealthHistories(ctx context.Context, debtorID string) ([]*models.CreditHealthHistory, error)
+	CreateKycSubmission(ctx context.Context, submission *models.KycSubmission) (*models.KycSubmission, error)
+	GetKycSubmissions(ctx context.Context, debtorID string) ([]*models.KycSubmission, error)
+	CheckActiveGuarantor(ctx context.Context, lendingID, userID string) (*models.Guarantor, error)
+	CreateGuarantor(ctx context.Context, guarantor *models.Guarantor) (*models.Guarantor, error)
+	GetGuarantees(ctx context.Context, userID string) ([]*models.Guarantor, error)
+	GetGuaranteeByID(ctx context.Context, guarantorID string) (*models.Guarantor, error)
+	GetGuaranteeForUpdate(ctx context.Context, guarantorID string) (*models.Guarantor, error)
+	UpdateGuarantor(ctx context.Context, guarantor *models.Guarantor) (*models.Guarantor, error)
+	GetOverdueInstallments(ctx context.Context, at time.Time) ([]*models.Installment, error)
+	CheckNotificationExist(ctx context.Context, userID, referenceID string) (bool, error)
+	CreateNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error)
+	GetNotifications(ctx context.Context, userID string) ([]*models.Notification, error)
 }
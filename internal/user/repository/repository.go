@@ -20,6 +20,12 @@ func NewUserRepository(db *gorm.DB) user.Repository {
 	return &userRepo{db: db}
 }
 
+func (r *userRepo) Transaction(ctx context.Context, fn func(repo user.Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&userRepo{db: tx})
+	})
+}
+
 func (r *userRepo) CreateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error) {
 	if err := r.db.WithContext(ctx).Create(lending).Error; err != nil {
 		return lending, err
@@ -33,17 +39,30 @@ func (r *userRepo) CreateLending(ctx context.Context, lending *models.Lending) (
 	return lending, nil
 }
 
-func (r *userRepo) GetLoanPeriodByID(ctx context.Context, periodID int) (*models.LoanPeriod, error) {
-	loanPeriod := &models.LoanPeriod{}
-	if err := r.db.WithContext(ctx).Where("loan_period_id = ?", periodID).First(loanPeriod).Error; err != nil {
-		return loanPeriod, err
+func (r *userRepo) GetLoanProductByID(ctx context.Context, loanProductID string) (*models.LoanProduct, error) {
+	loanProduct := &models.LoanProduct{}
+	if err := r.db.WithContext(ctx).Preload("CreditHealths").Where("loan_product_id = ?", loanProductID).First(loanProduct).Error; err != nil {
+		return loanProduct, err
+	}
+
+	return loanProduct, nil
+}
+
+func (r *userRepo) GetActiveLoanProducts(ctx context.Context, creditHealthID int, at time.Time) ([]*models.LoanProduct, error) {
+	var loanProducts []*models.LoanProduct
+	if err := r.db.WithContext(ctx).Preload("CreditHealths").
+		Joins("JOIN loan_product_credit_healths ON loan_product_credit_healths.loan_product_id = loan_products.loan_product_id").
+		Where("loan_product_credit_healths.credit_health_id = ? AND loan_products.active_date <= ? AND loan_products.expire_date >= ?", creditHealthID, at, at).
+		Order("loan_products.duration asc").
+		Find(&loanProducts).Error; err != nil {
+		return loanProducts, err
 	}
 
-	return loanPeriod, nil
+	return loanProducts, nil
 }
 
 func (r *userRepo) UpdateDebtorByID(ctx context.Context, debtor *models.Debtor) (*models.Debtor, error) {
-	if err := r.db.Omit("ContractTracking", "CreditHealth", "User").WithContext(ctx).Where("debtor_id = ?", debtor.DebtorID).Save(debtor).Error; err != nil {
+	if err := r.db.Omit("ContractTracking", "CreditHealth", "KycStatus", "User").WithContext(ctx).Where("debtor_id = ?", debtor.DebtorID).Save(debtor).Error; err != nil {
 		return debtor, err
 	}
 
@@ -54,6 +73,15 @@ func (r *userRepo) UpdateDebtorByID(ctx context.Context, debtor *models.Debtor)
 	return debtor, nil
 }
 
+func (r *userRepo) UpdateDebtorKycStatus(ctx context.Context, debtor *models.Debtor) error {
+	if err := r.db.WithContext(ctx).Model(&models.Debtor{}).Where("debtor_id = ?", debtor.DebtorID).
+		Updates(map[string]interface{}{"kyc_status_id": debtor.KycStatusID, "updated_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *userRepo) GetDebtorDetailsByID(ctx context.Context, userID string) (*models.Debtor, error) {
 	userDebtor := &models.Debtor{}
 	if err := r.db.Preload(clause.Associations).WithContext(ctx).
@@ -64,13 +92,26 @@ func (r *userRepo) GetDebtorDetailsByID(ctx context.Context, userID string) (*mo
 	return userDebtor, nil
 }
 
+func (r *userRepo) GetDebtorForUpdate(ctx context.Context, debtorID string) (*models.Debtor, error) {
+	debtor := &models.Debtor{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("debtor_id = ?", debtorID).First(debtor).Error; err != nil {
+		return debtor, err
+	}
+
+	return debtor, nil
+}
+
 func (r *userRepo) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
 	lending := &models.Lending{}
 	if err := r.db.WithContext(ctx).
 		Preload("Debtor."+clause.Associations).
 		Preload("Installments."+clause.Associations).
 		Preload("LendingStatus").
-		Preload("LoanPeriod").
+		Preload("RejectionReason").
+		Preload("LoanProduct", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
 		Preload("Debtor").
 		Preload("Installments", func(db *gorm.DB) *gorm.DB {
 			return db.Order("installments.due_date asc")
@@ -81,6 +122,25 @@ func (r *userRepo) GetLoanByID(ctx context.Context, lendingID string) (*models.L
 	return lending, nil
 }
 
+func (r *userRepo) GetLoanForUpdate(ctx context.Context, lendingID string) (*models.Lending, error) {
+	lending := &models.Lending{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("lending_id = ?", lendingID).First(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
+func (r *userRepo) UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error) {
+	if err := r.db.Omit(clause.Associations).WithContext(ctx).
+		Where("lending_id = ?", lending.LendingID).Save(lending).Error; err != nil {
+		return lending, err
+	}
+
+	return lending, nil
+}
+
 func (r *userRepo) GetLoans(ctx context.Context, debtorID, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	var loans []*models.Lending
 
@@ -119,7 +179,7 @@ func (r *userRepo) GetInstallmentByID(ctx context.Context, installmentID string)
 
 func (r *userRepo) GetVoucherByID(ctx context.Context, voucherID string) (*models.Voucher, error) {
 	voucher := &models.Voucher{}
-	if err := r.db.WithContext(ctx).
+	if err := r.db.WithContext(ctx).Preload("VoucherDiscountType").Preload("CreditHealths").Preload("LoanProducts").
 		Where("voucher_id = ?", voucherID).First(voucher).Error; err != nil {
 		return voucher, err
 	}
@@ -127,45 +187,93 @@ func (r *userRepo) GetVoucherByID(ctx context.Context, voucherID string) (*model
 	return voucher, nil
 }
 
-func (r *userRepo) CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
-	if err := r.db.WithContext(ctx).Create(payment).Error; err != nil {
-		return payment, err
+func (r *userRepo) GetVoucherForUpdate(ctx context.Context, voucherID string) (*models.Voucher, error) {
+	voucher := &models.Voucher{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("voucher_id = ?", voucherID).First(voucher).Error; err != nil {
+		return voucher, err
 	}
 
-	return payment, nil
+	return voucher, nil
 }
 
-func (r *userRepo) UpdateInstallment(ctx context.Context, installment *models.Installment) (*models.Installment, error) {
-	if err := r.db.Omit("InstallmentStatus", "Lending").WithContext(ctx).Where("installment_id = ?", installment.InstallmentID).Save(installment).Error; err != nil {
-		return installment, err
+func (r *userRepo) GetVoucherCodeByCode(ctx context.Context, code string) (*models.VoucherCode, error) {
+	voucherCode := &models.VoucherCode{}
+	if err := r.db.WithContext(ctx).
+		Preload("Voucher").Preload("Voucher.VoucherDiscountType").Preload("Voucher.CreditHealths").Preload("Voucher.LoanProducts").
+		Where("code = ?", code).First(voucherCode).Error; err != nil {
+		return voucherCode, err
 	}
 
-	installment, err := r.GetInstallmentByID(ctx, installment.InstallmentID.String())
-	if err != nil {
-		return installment, nil
+	return voucherCode, nil
+}
+
+func (r *userRepo) CountVoucherRedemptions(ctx context.Context, voucherID, debtorID string) (int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.VoucherRedemption{}).
+		Where("voucher_id = ? AND debtor_id = ?", voucherID, debtorID).Count(&total).Error; err != nil {
+		return total, err
 	}
 
-	return installment, nil
+	return total, nil
 }
 
-func (r *userRepo) UpdateLending(ctx context.Context, lending *models.Lending) (*models.Lending, error) {
-	if err := r.db.Omit("Debtor", "LoanPeriod", "LendingStatus", "Installments").WithContext(ctx).Where("lending_id = ?", lending.LendingID).Save(lending).Error; err != nil {
-		return lending, err
+// CheckVoucherCodeTaken reports whether a single use code is redeemed or
+// held by a pending payment intent that has not expired at the given time.
+func (r *userRepo) CheckVoucherCodeTaken(ctx context.Context, voucherCodeID string, at time.Time) (bool, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.VoucherCode{}).
+		Where("voucher_code_id = ? AND single_use", voucherCodeID).
+		Where("redeemed_at IS NOT NULL OR EXISTS (SELECT 1 FROM payment_intents "+
+			"WHERE payment_intents.voucher_code_id = voucher_codes.voucher_code_id "+
+			"AND payment_intents.payment_intent_status_id = 1 AND payment_intents.expire_date > ?)", at).
+		Count(&total).Error; err != nil {
+		return false, err
 	}
 
-	return lending, nil
+	return total > 0, nil
 }
 
-func (r *userRepo) UpdateVoucher(ctx context.Context, voucher *models.Voucher) error {
-	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucher.VoucherID).Save(voucher).Error; err != nil {
-		return err
+// CountPendingVoucherIntents counts the pending payment intents of a debtor
+// that use the voucher and have not expired at the given time. An empty
+// debtorID counts the intents of every debtor.
+func (r *userRepo) CountPendingVoucherIntents(ctx context.Context, voucherID, debtorID string, at time.Time) (int64, error) {
+	var total int64
+	query := r.db.WithContext(ctx).Model(&models.PaymentIntent{}).
+		Where("payment_intents.voucher_id = ?", voucherID).
+		Where("payment_intents.payment_intent_status_id = 1 AND payment_intents.expire_date > ?", at)
+
+	if debtorID != "" {
+		query = query.
+			Joins("inner join installments on installments.installment_id = payment_intents.installment_id").
+			Joins("inner join lendings on lendings.lending_id = installments.lending_id").
+			Where("lendings.debtor_id = ?", debtorID)
 	}
 
-	return nil
+	if err := query.Count(&total).Error; err != nil {
+		return total, err
+	}
+
+	return total, nil
 }
 
-func (r *userRepo) DeleteVoucher(ctx context.Context, voucher *models.Voucher) error {
-	if err := r.db.WithContext(ctx).Where("voucher_id = ?", voucher.VoucherID).Delete(voucher).Error; err != nil {
+func (r *userRepo) CreatePaymentIntent(ctx context.Context, intent *models.PaymentIntent) (*models.PaymentIntent, error) {
+	if err := r.db.WithContext(ctx).Create(intent).Error; err != nil {
+		return intent, err
+	}
+
+	if err := r.db.WithContext(ctx).Preload("PaymentIntentStatus").
+		Where("payment_intent_id = ?", intent.PaymentIntentID).First(intent).Error; err != nil {
+		return intent, err
+	}
+
+	return intent, nil
+}
+
+func (r *userRepo) ExpirePaymentIntents(ctx context.Context, installmentID string) error {
+	if err := r.db.WithContext(ctx).Model(&models.PaymentIntent{}).
+		Where("installment_id = ? AND payment_intent_status_id = ?", installmentID, 1).
+		Updates(map[string]interface{}{"payment_intent_status_id": 3, "updated_at": time.Now()}).Error; err != nil {
 		return err
 	}
 
@@ -198,7 +306,6 @@ func (r *userRepo) GetUserDetailsByID(ctx context.Context, userId string) (*mode
 }
 
 func (r *userRepo) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
-	var rows []*models.Voucher
 	var vouchers []*models.Voucher
 
 	loc, _ := time.LoadLocation("Asia/Jakarta")
@@ -206,31 +313,21 @@ func (r *userRepo) GetVouchers(ctx context.Context, name string, pagination *uti
 
 	var totalRows int64
 	r.db.Model(vouchers).WithContext(ctx).
-		Where("name ILIKE ? AND (? BETWEEN active_date AND expire_date)", fmt.Sprintf("%%%s%%", name), timeNow).
+		Where("name ILIKE ? AND voucher_status_id <> ? AND discount_quota > 0 AND (? BETWEEN active_date AND expire_date) AND code_required = false", fmt.Sprintf("%%%s%%", name), 5, timeNow).
 		Count(&totalRows)
 
 	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
 	pagination.TotalRows = totalRows
 	pagination.TotalPages = totalPages
 
-	if err := r.db.WithContext(ctx).
-		Where("name ILIKE ? AND (? BETWEEN active_date AND expire_date)", fmt.Sprintf("%%%s%%", name), timeNow).
+	if err := r.db.WithContext(ctx).Preload("VoucherDiscountType").Preload("CreditHealths").Preload("LoanProducts").
+		Where("name ILIKE ? AND voucher_status_id <> ? AND discount_quota > 0 AND (? BETWEEN active_date AND expire_date) AND code_required = false", fmt.Sprintf("%%%s%%", name), 5, timeNow).
 		Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort()).
 		Find(&vouchers).Error; err != nil {
 		return nil, err
 	}
 
-	for _, voucher := range vouchers {
-		if timeNow.Sub(voucher.ExpireDate) > 0 {
-			if err := r.DeleteVoucher(ctx, voucher); err != nil {
-				return nil, err
-			}
-		} else {
-			rows = append(rows, voucher)
-		}
-	}
-
-	pagination.Rows = rows
+	pagination.Rows = vouchers
 	return pagination, nil
 }
 
@@ -253,6 +350,7 @@ func (r *userRepo) GetPayments(ctx context.Context, debtorID string, name string
 		Joins("inner join lendings on installments.lending_id = lendings.lending_id").
 		Where("lendings.name ILIKE ? AND lendings.debtor_id = ?", fmt.Sprintf("%%%s%%", name), debtorID).
 		Preload("Voucher").
+		Preload("Voucher.VoucherDiscountType").
 		Preload("Installment").
 		Preload("Installment.Lending").
 		Preload("Installment.InstallmentStatus").
@@ -264,3 +362,228 @@ func (r *userRepo) GetPayments(ctx context.Context, debtorID string, name string
 	pagination.Rows = payments
 	return pagination, nil
 }
+
+func (r *userRepo) GetRestructurings(ctx context.Context, debtorID string) ([]*models.Restructuring, error) {
+	var restructurings []*models.Restructuring
+	if err := r.db.WithContext(ctx).
+		Joins("inner join lendings on lendings.lending_id = restructurings.lending_id").
+		Where("lendings.debtor_id = ?", debtorID).
+		Preload("RestructuringStatus").
+		Preload("Lending").
+		Order("restructurings.created_at desc").
+		Find(&restructurings).Error; err != nil {
+		return restructurings, err
+	}
+
+	return restructurings, nil
+}
+
+func (r *userRepo) GetRestructuringByID(ctx context.Context, restructuringID string) (*models.Restructuring, error) {
+	restructuring := &models.Restructuring{}
+	if err := r.db.WithContext(ctx).
+		Preload("RestructuringStatus").
+		Preload("Lending").
+		Preload("Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Preload("SupersededInstallments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Where("restructuring_id = ?", restructuringID).First(restructuring).Error; err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (r *userRepo) GetRestructuringForUpdate(ctx context.Context, restructuringID string) (*models.Restructuring, error) {
+	restructuring := &models.Restructuring{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("restructuring_id = ?", restructuringID).First(restructuring).Error; err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (r *userRepo) UpdateRestructuring(ctx context.Context, restructuring *models.Restructuring) (*models.Restructuring, error) {
+	if err := r.db.Omit("RestructuringStatus", "Lending", "Installments", "SupersededInstallments").WithContext(ctx).
+		Where("restructuring_id = ?", restructuring.RestructuringID).Save(restructuring).Error; err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (r *userRepo) SupersedeInstallments(ctx context.Context, lendingID string, restructuringID string) error {
+	if err := r.db.WithContext(ctx).Model(&models.Installment{}).
+		Where("lending_id = ? AND installment_status_id = ?", lendingID, 1).
+		Updates(map[string]interface{}{"installment_status_id": 3, "superseded_by_id": restructuringID, "updated_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) CreateInstallments(ctx context.Context, installments []*models.Installment) error {
+	if err := r.db.WithContext(ctx).Create(installments).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepo) GetCreditHealthHistories(ctx context.Context, debtorID string) ([]*models.CreditHealthHistory, error) {
+	var histories []*models.CreditHealthHistory
+	if err := r.db.WithContext(ctx).
+		Preload(clause.Associations).
+		Where("debtor_id = ?", debtorID).Order("created_at desc").
+		Find(&histories).Error; err != nil {
+		return histories, err
+	}
+
+	return histories, nil
+}
+
+func (r *userRepo) CreateKycSubmission(ctx context.Context, submission *models.KycSubmission) (*models.KycSubmission, error) {
+	if err := r.db.WithContext(ctx).Create(submission).Error; err != nil {
+		return submission, err
+	}
+
+	return submission, nil
+}
+
+func (r *userRepo) GetKycSubmissions(ctx context.Context, debtorID string) ([]*models.KycSubmission, error) {
+	var submissions []*models.KycSubmission
+	if err := r.db.WithContext(ctx).
+		Preload("KycStatus").
+		Where("debtor_id = ?", debtorID).Order("created_at desc").
+		Find(&submissions).Error; err != nil {
+		return submissions, err
+	}
+
+	return submissions, nil
+}
+
+func (r *userRepo) CheckActiveGuarantor(ctx context.Context, lendingID, userID string) (*models.Guarantor, error) {
+	guarantor := &models.Guarantor{}
+	if err := r.db.WithContext(ctx).
+		Where("lending_id = ? AND user_id = ? AND guarantor_status_id IN ?", lendingID, userID, []int{1, 2}).
+		First(guarantor).Error; err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (r *userRepo) CreateGuarantor(ctx context.Context, guarantor *models.Guarantor) (*models.Guarantor, error) {
+	if err := r.db.WithContext(ctx).Create(guarantor).Error; err != nil {
+		return guarantor, err
+	}
+
+	guarantor, err := r.GetGuaranteeByID(ctx, guarantor.GuarantorID.String())
+	if err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (r *userRepo) GetGuarantees(ctx context.Context, userID string) ([]*models.Guarantor, error) {
+	var guarantees []*models.Guarantor
+	if err := r.db.WithContext(ctx).
+		Preload("GuarantorStatus").
+		Preload("User").
+		Preload("Lending.LendingStatus").
+		Preload("Lending.Debtor.User").
+		Preload("Lending.Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Preload("Lending.Installments.InstallmentStatus").
+		Where("user_id = ?", userID).Order("created_at desc").
+		Find(&guarantees).Error; err != nil {
+		return guarantees, err
+	}
+
+	return guarantees, nil
+}
+
+func (r *userRepo) GetGuaranteeByID(ctx context.Context, guarantorID string) (*models.Guarantor, error) {
+	guarantor := &models.Guarantor{}
+	if err := r.db.WithContext(ctx).
+		Preload("GuarantorStatus").
+		Preload("User").
+		Preload("Lending.LendingStatus").
+		Preload("Lending.Debtor.User").
+		Preload("Lending.Installments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("installments.due_date asc")
+		}).
+		Preload("Lending.Installments.InstallmentStatus").
+		Where("guarantor_id = ?", guarantorID).First(guarantor).Error; err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (r *userRepo) GetGuaranteeForUpdate(ctx context.Context, guarantorID string) (*models.Guarantor, error) {
+	guarantor := &models.Guarantor{}
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("guarantor_id = ?", guarantorID).First(guarantor).Error; err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (r *userRepo) UpdateGuarantor(ctx context.Context, guarantor *models.Guarantor) (*models.Guarantor, error) {
+	if err := r.db.Omit(clause.Associations).WithContext(ctx).
+		Where("guarantor_id = ?", guarantor.GuarantorID).Save(guarantor).Error; err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (r *userRepo) GetOverdueInstallments(ctx context.Context, at time.Time) ([]*models.Installment, error) {
+	var installments []*models.Installment
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN lendings ON lendings.lending_id = installments.lending_id").
+		Preload("Lending.Guarantors", "guarantor_status_id = ?", 2).
+		Where("installments.installment_status_id = ? AND installments.due_date < ? AND lendings.lending_status_id IN ?", 1, at, []int{2, 3}).
+		Find(&installments).Error; err != nil {
+		return installments, err
+	}
+
+	return installments, nil
+}
+
+func (r *userRepo) CheckNotificationExist(ctx context.Context, userID, referenceID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND reference_id = ?", userID, referenceID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *userRepo) CreateNotification(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (r *userRepo) GetNotifications(ctx context.Context, userID string) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).Order("created_at desc").
+		Find(&notifications).Error; err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,1236 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/models/modelstest"
+	"final-project-backend/internal/user"
+	"final-project-backend/pkg/postgres/postgrestest"
+	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+	"time"
+)
+
+var testDB *gorm.DB
+
+func TestMain(m *testing.M) {
+	db, stop, err := postgrestest.Open()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	testDB = db
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func newRepo(t *testing.T) user.Repository {
+	t.Helper()
+	require.NoError(t, postgrestest.Reset(testDB))
+
+	return NewUserRepository(testDB)
+}
+
+func page(limit, page int, sort string) *utils.Pagination {
+	return &utils.Pagination{Limit: limit, Page: page, Sort: sort}
+}
+
+func TestTransaction(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	owner := modelstest.CreateUser(t, testDB)
+	errRollback := errors.New("rollback")
+
+	err := repo.Transaction(ctx, func(tx user.Repository) error {
+		_, err := tx.CreateNotification(ctx, &models.Notification{NotificationID: uuid.New(), UserID: owner.UserID, Title: "Rolled back"})
+		require.NoError(t, err)
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	err = repo.Transaction(ctx, func(tx user.Repository) error {
+		_, err := tx.CreateNotification(ctx, &models.Notification{NotificationID: uuid.New(), UserID: owner.UserID, Title: "Committed"})
+		return err
+	})
+	require.NoError(t, err)
+
+	notifications, err := repo.GetNotifications(ctx, owner.UserID.String())
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "Committed", notifications[0].Title)
+}
+
+func TestCreateLending(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	loanProduct := modelstest.CreateLoanProduct(t, testDB)
+
+	lending, err := repo.CreateLending(ctx, &models.Lending{
+		LendingID:       uuid.New(),
+		DebtorID:        debtor.DebtorID,
+		LoanProductID:   loanProduct.LoanProductID,
+		LendingStatusID: 1,
+		Name:            "Renovasi Rumah",
+		Amount:          5000000,
+		Duration:        loanProduct.Duration,
+		Percentage:      loanProduct.Percentage,
+		FinePerDay:      loanProduct.FinePerDay,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, lending.LendingStatus)
+	assert.Equal(t, "new", lending.LendingStatus.Name)
+	require.NotNil(t, lending.Debtor)
+	require.NotNil(t, lending.Debtor.User)
+	assert.Equal(t, debtor.UserID, lending.Debtor.User.UserID)
+	require.NotNil(t, lending.LoanProduct)
+	assert.Equal(t, loanProduct.Name, lending.LoanProduct.Name)
+
+	_, err = repo.CreateLending(ctx, &models.Lending{
+		LendingID:       uuid.New(),
+		DebtorID:        debtor.DebtorID,
+		LoanProductID:   loanProduct.LoanProductID,
+		LendingStatusID: 1,
+		Name:            "Zero",
+		Duration:        3,
+	})
+	assert.EqualError(t, err, response.DataConstraintViolation)
+}
+
+func TestGetLoanProductByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	loanProduct := modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+		l.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}, {CreditHealthID: 2}}
+	})
+
+	found, err := repo.GetLoanProductByID(ctx, loanProduct.LoanProductID.String())
+	require.NoError(t, err)
+	assert.Equal(t, loanProduct.Name, found.Name)
+	assert.Len(t, found.CreditHealths, 2)
+
+	_, err = repo.GetLoanProductByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetActiveLoanProducts(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	blocked := []models.CreditHealthType{{CreditHealthID: 3}}
+
+	long := modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+		l.Duration = 12
+		l.CreditHealths = blocked
+	})
+	short := modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+		l.Duration = 1
+		l.CreditHealths = []models.CreditHealthType{{CreditHealthID: 2}, {CreditHealthID: 3}}
+	})
+	modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+		l.ActiveDate = now.AddDate(0, 1, 0)
+		l.CreditHealths = blocked
+	})
+	modelstest.CreateLoanProduct(t, testDB, func(l *models.LoanProduct) {
+		l.ActiveDate = now.AddDate(0, -2, 0)
+		l.ExpireDate = now.AddDate(0, -1, 0)
+		l.CreditHealths = blocked
+	})
+
+	loanProducts, err := repo.GetActiveLoanProducts(ctx, 3, now)
+	require.NoError(t, err)
+	require.Len(t, loanProducts, 2)
+	assert.Equal(t, short.LoanProductID, loanProducts[0].LoanProductID)
+	assert.Equal(t, long.LoanProductID, loanProducts[1].LoanProductID)
+	assert.Len(t, loanProducts[0].CreditHealths, 2)
+}
+
+func TestUpdateDebtorByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+
+	debtor.CreditUsed = 1500000
+	debtor.TotalDelay = 4
+	updated, err := repo.UpdateDebtorByID(ctx, debtor)
+	require.NoError(t, err)
+	assert.Equal(t, 1500000.0, updated.CreditUsed)
+	assert.Equal(t, 4, updated.TotalDelay)
+	require.NotNil(t, updated.User)
+	require.NotNil(t, updated.CreditHealth)
+	require.NotNil(t, updated.ContractTracking)
+	require.NotNil(t, updated.KycStatus)
+	assert.Equal(t, "approved", updated.KycStatus.Name)
+
+	debtor.CreditUsed = debtor.CreditLimit + 1
+	_, err = repo.UpdateDebtorByID(ctx, debtor)
+	require.NoError(t, err)
+
+	debtor.CreditUsed = -1
+	_, err = repo.UpdateDebtorByID(ctx, debtor)
+	assert.EqualError(t, err, response.DataConstraintViolation)
+}
+
+func TestUpdateDebtorKycStatus(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+
+	stale := *debtor
+	require.NoError(t, testDB.Model(&models.Debtor{}).Where("debtor_id = ?", debtor.DebtorID).
+		Update("credit_used", 2500000).Error)
+
+	stale.KycStatusID = 2
+	require.NoError(t, repo.UpdateDebtorKycStatus(ctx, &stale))
+
+	updated, err := repo.GetDebtorForUpdate(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.KycStatusID)
+	assert.Equal(t, 2500000.0, updated.CreditUsed)
+}
+
+func TestGetDebtorDetailsByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) {
+		d.CreditHealthID = 2
+		d.ContractTrackingID = 3
+	})
+
+	found, err := repo.GetDebtorDetailsByID(ctx, debtor.UserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, debtor.DebtorID, found.DebtorID)
+	assert.Equal(t, 2, found.CreditHealthID)
+	assert.Equal(t, 3, found.ContractTrackingID)
+	require.NotNil(t, found.CreditHealth)
+	assert.Equal(t, "warning", found.CreditHealth.Name)
+	require.NotNil(t, found.ContractTracking)
+	assert.Equal(t, "contract in delivery", found.ContractTracking.Name)
+	require.NotNil(t, found.User)
+	assert.Equal(t, debtor.UserID, found.User.UserID)
+
+	_, err = repo.GetDebtorDetailsByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetLoanByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	lending := modelstest.CreateLending(t, testDB)
+	second := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = now.AddDate(0, 2, 0)
+	})
+	first := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.InstallmentStatusID = 2
+		i.DueDate = now.AddDate(0, 1, 0)
+	})
+
+	// Lendings keep showing their product after it is deleted.
+	require.NoError(t, testDB.Delete(&models.LoanProduct{}, "loan_product_id = ?", lending.LoanProductID).Error)
+
+	found, err := repo.GetLoanByID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.LoanProduct)
+	assert.Equal(t, lending.LoanProductID, found.LoanProduct.LoanProductID)
+	require.NotNil(t, found.Debtor)
+	require.NotNil(t, found.Debtor.User)
+	require.NotNil(t, found.Debtor.CreditHealth)
+	require.NotNil(t, found.LendingStatus)
+	assert.Nil(t, found.RejectionReason)
+
+	require.NotNil(t, found.Installments)
+	installments := *found.Installments
+	require.Len(t, installments, 2)
+	assert.Equal(t, first.InstallmentID, installments[0].InstallmentID)
+	assert.Equal(t, second.InstallmentID, installments[1].InstallmentID)
+	require.NotNil(t, installments[0].InstallmentStatus)
+	assert.Equal(t, "paid", installments[0].InstallmentStatus.Name)
+
+	_, err = repo.GetLoanByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetLoanForUpdate(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+
+	err := repo.Transaction(ctx, func(tx user.Repository) error {
+		found, err := tx.GetLoanForUpdate(ctx, lending.LendingID.String())
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, lending.Name, found.Name)
+		assert.Nil(t, found.Debtor)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetLoanForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUpdateLending(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 1 })
+
+	now := time.Now()
+	reason := "No longer needed"
+	lending.LendingStatusID = 7
+	lending.CancelReason = &reason
+	lending.CancelledAt = &now
+	lending.LendingStatus = &models.LendingStatusType{LendingStatusID: 1, Name: "ignored"}
+	_, err := repo.UpdateLending(ctx, lending)
+	require.NoError(t, err)
+
+	found, err := repo.GetLoanByID(ctx, lending.LendingID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 7, found.LendingStatusID)
+	assert.Equal(t, "cancelled", found.LendingStatus.Name)
+	require.NotNil(t, found.CancelReason)
+	assert.Equal(t, reason, *found.CancelReason)
+	assert.NotNil(t, found.CancelledAt)
+}
+
+func TestGetLoans(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	other := modelstest.CreateDebtor(t, testDB)
+	base := time.Now().Add(-time.Hour)
+
+	create := func(debtorID uuid.UUID, name string, status int, offset time.Duration) *models.Lending {
+		return modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.DebtorID = debtorID
+			l.Name = name
+			l.LendingStatusID = status
+			l.CreatedAt = base.Add(offset)
+		})
+	}
+	first := create(debtor.DebtorID, "Renovasi Rumah", 3, 0)
+	second := create(debtor.DebtorID, "renovasi dapur", 1, time.Minute)
+	third := create(debtor.DebtorID, "Biaya RENOVASI", 4, 2*time.Minute)
+	create(debtor.DebtorID, "Modal Usaha", 3, 3*time.Minute)
+	create(debtor.DebtorID, "Renovasi Kantor", 7, 4*time.Minute)
+	create(other.DebtorID, "Renovasi Lain", 3, 5*time.Minute)
+	modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = second.LendingID })
+
+	status := []int{1, 2, 3, 4}
+	pagination, err := repo.GetLoans(ctx, debtor.DebtorID.String(), "renovasi", status, page(2, 1, "created_at asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+
+	loans := pagination.Rows.([]*models.Lending)
+	require.Len(t, loans, 2)
+	assert.Equal(t, first.LendingID, loans[0].LendingID)
+	assert.Equal(t, second.LendingID, loans[1].LendingID)
+	require.NotNil(t, loans[1].Debtor)
+	require.NotNil(t, loans[1].Debtor.User)
+	require.NotNil(t, loans[1].LendingStatus)
+	require.NotNil(t, loans[1].LoanProduct)
+	require.NotNil(t, loans[1].Installments)
+	require.Len(t, *loans[1].Installments, 1)
+	assert.NotNil(t, (*loans[1].Installments)[0].InstallmentStatus)
+
+	pagination, err = repo.GetLoans(ctx, debtor.DebtorID.String(), "renovasi", status, page(2, 2, "created_at asc"))
+	require.NoError(t, err)
+	loans = pagination.Rows.([]*models.Lending)
+	require.Len(t, loans, 1)
+	assert.Equal(t, third.LendingID, loans[0].LendingID)
+
+	pagination, err = repo.GetLoans(ctx, debtor.DebtorID.String(), "", []int{7}, page(10, 1, "created_at desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pagination.TotalRows)
+	assert.Equal(t, 1, pagination.TotalPages)
+}
+
+func TestGetInstallmentByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB)
+
+	found, err := repo.GetInstallmentByID(ctx, installment.InstallmentID.String())
+	require.NoError(t, err)
+	assert.Equal(t, installment.Amount, found.Amount)
+	require.NotNil(t, found.InstallmentStatus)
+	assert.Equal(t, "on progress", found.InstallmentStatus.Name)
+	require.NotNil(t, found.Lending)
+	assert.Equal(t, installment.LendingID, found.Lending.LendingID)
+
+	_, err = repo.GetInstallmentByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetVoucherByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	loanProduct := modelstest.CreateLoanProduct(t, testDB)
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+		v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}}
+		v.LoanProducts = []models.LoanProduct{{LoanProductID: loanProduct.LoanProductID}}
+	})
+
+	found, err := repo.GetVoucherByID(ctx, voucher.VoucherID.String())
+	require.NoError(t, err)
+	assert.Equal(t, voucher.Name, found.Name)
+	require.NotNil(t, found.VoucherDiscountType)
+	assert.Equal(t, "percentage", found.VoucherDiscountType.Name)
+	require.Len(t, found.CreditHealths, 1)
+	assert.Equal(t, 1, found.CreditHealths[0].CreditHealthID)
+	require.Len(t, found.LoanProducts, 1)
+	assert.Equal(t, loanProduct.Name, found.LoanProducts[0].Name)
+
+	_, err = repo.GetVoucherByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetVoucherCodeByCode(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) {
+		v.CodeRequired = true
+		v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}, {CreditHealthID: 2}}
+	})
+	voucherCode := modelstest.CreateVoucherCode(t, testDB, voucher, "HEMAT10")
+
+	found, err := repo.GetVoucherCodeByCode(ctx, "HEMAT10")
+	require.NoError(t, err)
+	assert.Equal(t, voucherCode.VoucherCodeID, found.VoucherCodeID)
+	require.NotNil(t, found.Voucher)
+	assert.Equal(t, voucher.VoucherID, found.Voucher.VoucherID)
+	assert.NotNil(t, found.Voucher.VoucherDiscountType)
+	assert.Len(t, found.Voucher.CreditHealths, 2)
+	assert.Empty(t, found.Voucher.LoanProducts)
+
+	_, err = repo.GetVoucherCodeByCode(ctx, "hemat10")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCountVoucherRedemptions(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB)
+	debtor := modelstest.CreateDebtor(t, testDB)
+	other := modelstest.CreateDebtor(t, testDB)
+
+	redeem := func(debtor *models.Debtor) {
+		payment := modelstest.CreatePayment(t, testDB, func(p *models.Payment) { p.VoucherID = &voucher.VoucherID })
+		modelstest.CreateVoucherRedemption(t, testDB, payment, debtor)
+	}
+	redeem(debtor)
+	redeem(debtor)
+	redeem(other)
+
+	total, err := repo.CountVoucherRedemptions(ctx, voucher.VoucherID.String(), debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+
+	total, err = repo.CountVoucherRedemptions(ctx, uuid.NewString(), debtor.DebtorID.String())
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestGetVoucherForUpdate(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.PerUserLimit = 2 })
+
+	err := repo.Transaction(ctx, func(tx user.Repository) error {
+		locked, err := tx.GetVoucherForUpdate(ctx, voucher.VoucherID.String())
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 2, locked.PerUserLimit)
+		assert.Nil(t, locked.VoucherDiscountType)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetVoucherForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCheckVoucherCodeTaken(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	voucher := modelstest.CreateVoucher(t, testDB, func(v *models.Voucher) { v.CodeRequired = true })
+
+	withIntent := func(code string, status int, expireDate time.Time) *models.VoucherCode {
+		voucherCode := modelstest.CreateVoucherCode(t, testDB, voucher, code)
+		modelstest.CreatePaymentIntent(t, testDB, func(p *models.PaymentIntent) {
+			p.VoucherID = &voucher.VoucherID
+			p.VoucherCodeID = &voucherCode.VoucherCodeID
+			p.PaymentIntentStatusID = status
+			p.ExpireDate = expireDate
+		})
+		return voucherCode
+	}
+
+	free := modelstest.CreateVoucherCode(t, testDB, voucher, "FREE0001")
+	redeemed := modelstest.CreateVoucherCode(t, testDB, voucher, "USED0001")
+	require.NoError(t, testDB.Model(&models.VoucherCode{}).Where("voucher_code_id = ?", redeemed.VoucherCodeID).Update("redeemed_at", now).Error)
+	pending := withIntent("PEND0001", 1, now.Add(time.Hour))
+	lapsed := withIntent("LAPS0001", 1, now.Add(-time.Minute))
+	expired := withIntent("EXPR0001", 3, now.Add(time.Hour))
+	shared := modelstest.CreateVoucherCode(t, testDB, voucher, "SHARED01")
+	require.NoError(t, testDB.Model(&models.VoucherCode{}).Where("voucher_code_id = ?", shared.VoucherCodeID).Update("single_use", false).Error)
+	modelstest.CreatePaymentIntent(t, testDB, func(p *models.PaymentIntent) {
+		p.VoucherID = &voucher.VoucherID
+		p.VoucherCodeID = &shared.VoucherCodeID
+	})
+
+	for _, tt := range []struct {
+		code  *models.VoucherCode
+		taken bool
+	}{
+		{free, false},
+		{redeemed, true},
+		{pending, true},
+		{lapsed, false},
+		{expired, false},
+		{shared, false},
+	} {
+		taken, err := repo.CheckVoucherCodeTaken(ctx, tt.code.VoucherCodeID.String(), now)
+		require.NoError(t, err)
+		assert.Equal(t, tt.taken, taken, tt.code.Code)
+	}
+}
+
+func TestCountPendingVoucherIntents(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	voucher := modelstest.CreateVoucher(t, testDB)
+	debtor := modelstest.CreateDebtor(t, testDB)
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.DebtorID = debtor.DebtorID })
+
+	intent := func(voucherID uuid.UUID, lendingID uuid.UUID, status int, expireDate time.Time) {
+		installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) { i.LendingID = lendingID })
+		modelstest.CreatePaymentIntent(t, testDB, func(p *models.PaymentIntent) {
+			p.InstallmentID = installment.InstallmentID
+			p.VoucherID = &voucherID
+			p.PaymentIntentStatusID = status
+			p.ExpireDate = expireDate
+		})
+	}
+	intent(voucher.VoucherID, lending.LendingID, 1, now.Add(time.Hour))
+	intent(voucher.VoucherID, lending.LendingID, 1, now.Add(time.Hour))
+	intent(voucher.VoucherID, lending.LendingID, 1, now.Add(-time.Minute))
+	intent(voucher.VoucherID, lending.LendingID, 2, now.Add(time.Hour))
+	intent(voucher.VoucherID, lending.LendingID, 3, now.Add(time.Hour))
+	intent(voucher.VoucherID, modelstest.CreateLending(t, testDB).LendingID, 1, now.Add(time.Hour))
+	intent(modelstest.CreateVoucher(t, testDB).VoucherID, lending.LendingID, 1, now.Add(time.Hour))
+
+	total, err := repo.CountPendingVoucherIntents(ctx, voucher.VoucherID.String(), debtor.DebtorID.String(), now)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+
+	total, err = repo.CountPendingVoucherIntents(ctx, voucher.VoucherID.String(), "", now)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+}
+
+func TestCreatePaymentIntent(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB)
+
+	intent, err := repo.CreatePaymentIntent(ctx, &models.PaymentIntent{
+		PaymentIntentID:       uuid.New(),
+		InstallmentID:         installment.InstallmentID,
+		PaymentIntentStatusID: 1,
+		Provider:              "xendit",
+		Channel:               "BNI",
+		ExternalID:            "inv-1",
+		AccountNumber:         "8808123",
+		PaymentAmount:         installment.Amount,
+		ExpireDate:            time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, intent.PaymentIntentStatus)
+	assert.Equal(t, 1, intent.PaymentIntentStatus.PaymentIntentStatusID)
+
+	_, err = repo.CreatePaymentIntent(ctx, &models.PaymentIntent{
+		PaymentIntentID:       uuid.New(),
+		InstallmentID:         installment.InstallmentID,
+		PaymentIntentStatusID: 1,
+		Provider:              "xendit",
+		Channel:               "BNI",
+		ExternalID:            "inv-1",
+		AccountNumber:         "8808124",
+		PaymentAmount:         installment.Amount,
+		ExpireDate:            time.Now().Add(time.Hour),
+	})
+	assert.EqualError(t, err, response.DataAlreadyExist)
+}
+
+func TestExpirePaymentIntents(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	installment := modelstest.CreateInstallment(t, testDB)
+
+	onInstallment := func(status int) func(*models.PaymentIntent) {
+		return func(p *models.PaymentIntent) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentIntentStatusID = status
+		}
+	}
+	pending := modelstest.CreatePaymentIntent(t, testDB, onInstallment(1))
+	otherPending := modelstest.CreatePaymentIntent(t, testDB, onInstallment(1))
+	paid := modelstest.CreatePaymentIntent(t, testDB, onInstallment(2))
+	elsewhere := modelstest.CreatePaymentIntent(t, testDB)
+
+	require.NoError(t, repo.ExpirePaymentIntents(ctx, installment.InstallmentID.String()))
+
+	status := func(intent *models.PaymentIntent) int {
+		var stored models.PaymentIntent
+		require.NoError(t, testDB.Where("payment_intent_id = ?", intent.PaymentIntentID).First(&stored).Error)
+		return stored.PaymentIntentStatusID
+	}
+	assert.Equal(t, 3, status(pending))
+	assert.Equal(t, 3, status(otherPending))
+	assert.Equal(t, 2, status(paid))
+	assert.Equal(t, 1, status(elsewhere))
+}
+
+func TestUpdateUser(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	existing := modelstest.CreateUser(t, testDB)
+	taken := modelstest.CreateUser(t, testDB)
+
+	existing.Name = "Dewi Lestari"
+	existing.Address = "Jl. Diponegoro No. 5"
+	_, err := repo.UpdateUser(ctx, existing)
+	require.NoError(t, err)
+
+	found, err := repo.GetUserDetailsByID(ctx, existing.UserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Dewi Lestari", found.Name)
+	assert.Equal(t, "Jl. Diponegoro No. 5", found.Address)
+
+	existing.Email = taken.Email
+	_, err = repo.UpdateUser(ctx, existing)
+	assert.EqualError(t, err, response.EmailAlreadyExistMessage)
+}
+
+func TestCheckEmailExist(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	existing := modelstest.CreateUser(t, testDB, func(u *models.User) { u.Email = "rina@example.com" })
+
+	found, err := repo.CheckEmailExist(ctx, "RINA@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, existing.UserID, found.UserID)
+
+	_, err = repo.CheckEmailExist(ctx, "rina@example.org")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetUserDetailsByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	existing := modelstest.CreateUser(t, testDB)
+
+	found, err := repo.GetUserDetailsByID(ctx, existing.UserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, existing.Email, found.Email)
+	require.NotNil(t, found.Role)
+	assert.Equal(t, "user", found.Role.Name)
+
+	_, err = repo.GetUserDetailsByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetVouchers(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	create := func(name string, opts ...func(*models.Voucher)) *models.Voucher {
+		return modelstest.CreateVoucher(t, testDB, append([]func(*models.Voucher){
+			func(v *models.Voucher) { v.Name = name },
+		}, opts...)...)
+	}
+	early := create("Diskon Merdeka", func(v *models.Voucher) { v.ExpireDate = now.AddDate(0, 0, 10) })
+	late := create("DISKON Akhir Tahun", func(v *models.Voucher) {
+		v.ExpireDate = now.AddDate(0, 2, 0)
+		v.CreditHealths = []models.CreditHealthType{{CreditHealthID: 1}}
+	})
+	create("Diskon Arsip", func(v *models.Voucher) { v.VoucherStatusID = 5 })
+	create("Diskon Habis", func(v *models.Voucher) { v.DiscountQuota = 0 })
+	create("Diskon Nanti", func(v *models.Voucher) {
+		v.VoucherStatusID = 1
+		v.ActiveDate = now.AddDate(0, 0, 1)
+	})
+	create("Diskon Kode", func(v *models.Voucher) { v.CodeRequired = true })
+	create("Cashback Merdeka")
+
+	pagination, err := repo.GetVouchers(ctx, "diskon", page(1, 1, "expire_date asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+	vouchers := pagination.Rows.([]*models.Voucher)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, early.VoucherID, vouchers[0].VoucherID)
+	assert.NotNil(t, vouchers[0].VoucherDiscountType)
+
+	pagination, err = repo.GetVouchers(ctx, "diskon", page(1, 2, "expire_date asc"))
+	require.NoError(t, err)
+	vouchers = pagination.Rows.([]*models.Voucher)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, late.VoucherID, vouchers[0].VoucherID)
+	assert.Len(t, vouchers[0].CreditHealths, 1)
+
+	pagination, err = repo.GetVouchers(ctx, "", page(10, 1, "active_date desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pagination.TotalRows)
+}
+
+func TestGetPayments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	other := modelstest.CreateDebtor(t, testDB)
+	voucher := modelstest.CreateVoucher(t, testDB)
+	now := time.Now()
+
+	pay := func(debtor *models.Debtor, name string, paidAt time.Time, opts ...func(*models.Payment)) *models.Payment {
+		lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) {
+			l.DebtorID = debtor.DebtorID
+			l.Name = name
+		})
+		installment := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.InstallmentStatusID = 2
+		})
+		return modelstest.CreatePayment(t, testDB, append([]func(*models.Payment){func(p *models.Payment) {
+			p.InstallmentID = installment.InstallmentID
+			p.PaymentDate = paidAt
+		}}, opts...)...)
+	}
+	oldest := pay(debtor, "Renovasi Rumah", now.AddDate(0, 0, -3))
+	middle := pay(debtor, "renovasi dapur", now.AddDate(0, 0, -2), func(p *models.Payment) {
+		p.VoucherID = &voucher.VoucherID
+		p.PaymentDiscount = 105000
+		p.PaymentAmount = 945000
+	})
+	latest := pay(debtor, "RENOVASI Kantor", now.AddDate(0, 0, -1))
+	pay(debtor, "Modal Usaha", now)
+	pay(other, "Renovasi Lain", now)
+
+	pagination, err := repo.GetPayments(ctx, debtor.DebtorID.String(), "renovasi", page(2, 1, "payment_date desc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pagination.TotalRows)
+	assert.Equal(t, 2, pagination.TotalPages)
+
+	payments := pagination.Rows.([]*models.Payment)
+	require.Len(t, payments, 2)
+	assert.Equal(t, latest.PaymentID, payments[0].PaymentID)
+	assert.Nil(t, payments[0].Voucher)
+	assert.Equal(t, middle.PaymentID, payments[1].PaymentID)
+	require.NotNil(t, payments[1].Voucher)
+	require.NotNil(t, payments[1].Voucher.VoucherDiscountType)
+	require.NotNil(t, payments[1].Installment)
+	require.NotNil(t, payments[1].Installment.Lending)
+	assert.Equal(t, "renovasi dapur", payments[1].Installment.Lending.Name)
+	require.NotNil(t, payments[1].Installment.InstallmentStatus)
+	assert.Equal(t, "paid", payments[1].Installment.InstallmentStatus.Name)
+
+	pagination, err = repo.GetPayments(ctx, debtor.DebtorID.String(), "renovasi", page(2, 2, "payment_date desc"))
+	require.NoError(t, err)
+	payments = pagination.Rows.([]*models.Payment)
+	require.Len(t, payments, 1)
+	assert.Equal(t, oldest.PaymentID, payments[0].PaymentID)
+
+	pagination, err = repo.GetPayments(ctx, debtor.DebtorID.String(), "", page(10, 1, "payment_amount asc"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pagination.TotalRows)
+	payments = pagination.Rows.([]*models.Payment)
+	assert.Equal(t, middle.PaymentID, payments[0].PaymentID)
+}
+
+func TestGetRestructurings(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.DebtorID = debtor.DebtorID })
+	now := time.Now()
+
+	declined := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) {
+		r.LendingID = lending.LendingID
+		r.RestructuringStatusID = 3
+		r.CreatedAt = now.Add(-time.Hour)
+	})
+	proposed := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) {
+		r.LendingID = lending.LendingID
+		r.Version = 2
+		r.CreatedAt = now
+	})
+	modelstest.CreateRestructuring(t, testDB)
+
+	restructurings, err := repo.GetRestructurings(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	require.Len(t, restructurings, 2)
+	assert.Equal(t, proposed.RestructuringID, restructurings[0].RestructuringID)
+	assert.Equal(t, declined.RestructuringID, restructurings[1].RestructuringID)
+	require.NotNil(t, restructurings[0].RestructuringStatus)
+	assert.Equal(t, 1, restructurings[0].RestructuringStatus.RestructuringStatusID)
+	require.NotNil(t, restructurings[0].Lending)
+	assert.Equal(t, lending.Name, restructurings[0].Lending.Name)
+}
+
+func TestGetRestructuringByID(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	restructuring := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) {
+		r.LendingID = lending.LendingID
+		r.RestructuringStatusID = 2
+	})
+	now := time.Now()
+
+	installment := func(dueDate time.Time, opt func(*models.Installment)) *models.Installment {
+		return modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.DueDate = dueDate
+			opt(i)
+		})
+	}
+	replacedLate := installment(now.AddDate(0, 0, -10), func(i *models.Installment) {
+		i.InstallmentStatusID = 3
+		i.SupersededByID = &restructuring.RestructuringID
+	})
+	replacedEarly := installment(now.AddDate(0, 0, -40), func(i *models.Installment) {
+		i.InstallmentStatusID = 3
+		i.SupersededByID = &restructuring.RestructuringID
+	})
+	newLate := installment(now.AddDate(0, 2, 0), func(i *models.Installment) { i.RestructuringID = &restructuring.RestructuringID })
+	newEarly := installment(now.AddDate(0, 1, 0), func(i *models.Installment) { i.RestructuringID = &restructuring.RestructuringID })
+	installment(now.AddDate(0, 0, -70), func(i *models.Installment) { i.InstallmentStatusID = 2 })
+
+	found, err := repo.GetRestructuringByID(ctx, restructuring.RestructuringID.String())
+	require.NoError(t, err)
+	require.NotNil(t, found.RestructuringStatus)
+	assert.Equal(t, 2, found.RestructuringStatus.RestructuringStatusID)
+	require.NotNil(t, found.Lending)
+	assert.Equal(t, lending.LendingID, found.Lending.LendingID)
+
+	require.NotNil(t, found.Installments)
+	require.Len(t, *found.Installments, 2)
+	assert.Equal(t, newEarly.InstallmentID, (*found.Installments)[0].InstallmentID)
+	assert.Equal(t, newLate.InstallmentID, (*found.Installments)[1].InstallmentID)
+
+	require.NotNil(t, found.SupersededInstallments)
+	require.Len(t, *found.SupersededInstallments, 2)
+	assert.Equal(t, replacedEarly.InstallmentID, (*found.SupersededInstallments)[0].InstallmentID)
+	assert.Equal(t, replacedLate.InstallmentID, (*found.SupersededInstallments)[1].InstallmentID)
+
+	_, err = repo.GetRestructuringByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetRestructuringForUpdate(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	restructuring := modelstest.CreateRestructuring(t, testDB)
+
+	err := repo.Transaction(ctx, func(tx user.Repository) error {
+		found, err := tx.GetRestructuringForUpdate(ctx, restructuring.RestructuringID.String())
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, restructuring.TotalAmount, found.TotalAmount)
+		assert.Nil(t, found.Lending)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetRestructuringForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUpdateRestructuring(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	restructuring := modelstest.CreateRestructuring(t, testDB)
+
+	now := time.Now()
+	restructuring.RestructuringStatusID = 2
+	restructuring.RespondedAt = &now
+	_, err := repo.UpdateRestructuring(ctx, restructuring)
+	require.NoError(t, err)
+
+	found, err := repo.GetRestructuringByID(ctx, restructuring.RestructuringID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.RestructuringStatusID)
+	assert.NotNil(t, found.RespondedAt)
+}
+
+func TestSupersedeInstallments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	restructuring := modelstest.CreateRestructuring(t, testDB, func(r *models.Restructuring) { r.LendingID = lending.LendingID })
+
+	withStatus := func(status int) *models.Installment {
+		return modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lending.LendingID
+			i.InstallmentStatusID = status
+		})
+	}
+	open := withStatus(1)
+	otherOpen := withStatus(1)
+	paid := withStatus(2)
+	elsewhere := modelstest.CreateInstallment(t, testDB)
+
+	require.NoError(t, repo.SupersedeInstallments(ctx, lending.LendingID.String(), restructuring.RestructuringID.String()))
+
+	stored := func(installment *models.Installment) *models.Installment {
+		found, err := repo.GetInstallmentByID(ctx, installment.InstallmentID.String())
+		require.NoError(t, err)
+		return found
+	}
+	for _, installment := range []*models.Installment{open, otherOpen} {
+		found := stored(installment)
+		assert.Equal(t, 3, found.InstallmentStatusID)
+		require.NotNil(t, found.SupersededByID)
+		assert.Equal(t, restructuring.RestructuringID, *found.SupersededByID)
+	}
+	assert.Equal(t, 2, stored(paid).InstallmentStatusID)
+	assert.Nil(t, stored(paid).SupersededByID)
+	assert.Equal(t, 1, stored(elsewhere).InstallmentStatusID)
+}
+
+func TestCreateInstallments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	restructuring := modelstest.CreateRestructuring(t, testDB)
+
+	var installments []*models.Installment
+	for i := 0; i < 3; i++ {
+		installments = append(installments, &models.Installment{
+			InstallmentID:       uuid.New(),
+			LendingID:           restructuring.LendingID,
+			InstallmentStatusID: 1,
+			RestructuringID:     &restructuring.RestructuringID,
+			Amount:              restructuring.InstallmentAmount,
+			DueDate:             restructuring.FirstDueDate.AddDate(0, i, 0),
+		})
+	}
+	require.NoError(t, repo.CreateInstallments(ctx, installments))
+
+	found, err := repo.GetRestructuringByID(ctx, restructuring.RestructuringID.String())
+	require.NoError(t, err)
+	require.Len(t, *found.Installments, 3)
+	assert.Equal(t, installments[0].InstallmentID, (*found.Installments)[0].InstallmentID)
+
+	err = repo.CreateInstallments(ctx, []*models.Installment{{
+		InstallmentID:       uuid.New(),
+		LendingID:           uuid.New(),
+		InstallmentStatusID: 1,
+		Amount:              1,
+		DueDate:             time.Now(),
+	}})
+	assert.EqualError(t, err, response.ReferencedDataNotExist)
+}
+
+func TestGetCreditHealthHistories(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB)
+	now := time.Now()
+
+	older := modelstest.CreateCreditHealthHistory(t, testDB, func(h *models.CreditHealthHistory) {
+		h.DebtorID = debtor.DebtorID
+		h.CreatedAt = now.Add(-time.Hour)
+	})
+	newer := modelstest.CreateCreditHealthHistory(t, testDB, func(h *models.CreditHealthHistory) {
+		h.DebtorID = debtor.DebtorID
+		h.CreditHealthEventID = 2
+		h.PreviousCreditHealthID = 2
+		h.CreditHealthID = 3
+		h.CreatedAt = now
+	})
+	modelstest.CreateCreditHealthHistory(t, testDB)
+
+	histories, err := repo.GetCreditHealthHistories(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, newer.CreditHealthHistoryID, histories[0].CreditHealthHistoryID)
+	assert.Equal(t, older.CreditHealthHistoryID, histories[1].CreditHealthHistoryID)
+	require.NotNil(t, histories[0].CreditHealthEvent)
+	assert.Equal(t, "payment reversal", histories[0].CreditHealthEvent.Name)
+	require.NotNil(t, histories[0].PreviousCreditHealth)
+	assert.Equal(t, "warning", histories[0].PreviousCreditHealth.Name)
+	require.NotNil(t, histories[0].CreditHealth)
+	assert.Equal(t, "blocked", histories[0].CreditHealth.Name)
+}
+
+func TestKycSubmissions(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.KycStatusID = 1 })
+	now := time.Now()
+
+	rejected := modelstest.CreateKycSubmission(t, testDB, func(k *models.KycSubmission) {
+		k.DebtorID = debtor.DebtorID
+		k.KycStatusID = 4
+		k.RejectReason = "Blurry selfie"
+		k.CreatedAt = now.Add(-time.Hour)
+	})
+	submission, err := repo.CreateKycSubmission(ctx, &models.KycSubmission{
+		KycSubmissionID: uuid.New(),
+		DebtorID:        debtor.DebtorID,
+		KycStatusID:     2,
+		IDCardKey:       "kyc/id-card.jpg",
+		SelfieKey:       "kyc/selfie.jpg",
+	})
+	require.NoError(t, err)
+	modelstest.CreateKycSubmission(t, testDB)
+
+	submissions, err := repo.GetKycSubmissions(ctx, debtor.DebtorID.String())
+	require.NoError(t, err)
+	require.Len(t, submissions, 2)
+	assert.Equal(t, submission.KycSubmissionID, submissions[0].KycSubmissionID)
+	assert.Equal(t, rejected.KycSubmissionID, submissions[1].KycSubmissionID)
+	require.NotNil(t, submissions[0].KycStatus)
+	assert.Equal(t, "pending", submissions[0].KycStatus.Name)
+	assert.Equal(t, "Blurry selfie", submissions[1].RejectReason)
+
+	_, err = repo.CreateKycSubmission(ctx, &models.KycSubmission{
+		KycSubmissionID: uuid.New(),
+		DebtorID:        uuid.New(),
+		KycStatusID:     2,
+	})
+	assert.EqualError(t, err, response.ReferencedDataNotExist)
+}
+
+func TestCheckActiveGuarantor(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	invited := modelstest.CreateUser(t, testDB)
+	declined := modelstest.CreateUser(t, testDB)
+
+	guarantor := modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = lending.LendingID
+		g.UserID = invited.UserID
+	})
+	modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = lending.LendingID
+		g.UserID = declined.UserID
+		g.GuarantorStatusID = 3
+	})
+
+	found, err := repo.CheckActiveGuarantor(ctx, lending.LendingID.String(), invited.UserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, guarantor.GuarantorID, found.GuarantorID)
+
+	_, err = repo.CheckActiveGuarantor(ctx, lending.LendingID.String(), declined.UserID.String())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCreateGuarantor(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	lending := modelstest.CreateLending(t, testDB)
+	guarantorUser := modelstest.CreateUser(t, testDB)
+	now := time.Now()
+	late := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = now.AddDate(0, 2, 0)
+	})
+	early := modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+		i.LendingID = lending.LendingID
+		i.DueDate = now.AddDate(0, 1, 0)
+	})
+
+	guarantor, err := repo.CreateGuarantor(ctx, &models.Guarantor{
+		GuarantorID:       uuid.New(),
+		LendingID:         lending.LendingID,
+		UserID:            guarantorUser.UserID,
+		GuarantorStatusID: 1,
+	})
+	require.NoError(t, err)
+	assertGuaranteeShape(t, guarantor, lending)
+	require.Len(t, *guarantor.Lending.Installments, 2)
+	assert.Equal(t, early.InstallmentID, (*guarantor.Lending.Installments)[0].InstallmentID)
+	assert.Equal(t, late.InstallmentID, (*guarantor.Lending.Installments)[1].InstallmentID)
+	assert.NotNil(t, (*guarantor.Lending.Installments)[0].InstallmentStatus)
+
+	_, err = repo.GetGuaranteeByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetGuarantees(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	guarantorUser := modelstest.CreateUser(t, testDB)
+	firstLending := modelstest.CreateLending(t, testDB)
+	secondLending := modelstest.CreateLending(t, testDB)
+	now := time.Now()
+
+	older := modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = firstLending.LendingID
+		g.UserID = guarantorUser.UserID
+		g.GuarantorStatusID = 2
+		g.CreatedAt = now.Add(-time.Hour)
+	})
+	newer := modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = secondLending.LendingID
+		g.UserID = guarantorUser.UserID
+		g.CreatedAt = now
+	})
+	modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) { g.LendingID = firstLending.LendingID })
+
+	guarantees, err := repo.GetGuarantees(ctx, guarantorUser.UserID.String())
+	require.NoError(t, err)
+	require.Len(t, guarantees, 2)
+	assert.Equal(t, newer.GuarantorID, guarantees[0].GuarantorID)
+	assert.Equal(t, older.GuarantorID, guarantees[1].GuarantorID)
+	assertGuaranteeShape(t, guarantees[0], secondLending)
+	assertGuaranteeShape(t, guarantees[1], firstLending)
+	assert.Equal(t, "accepted", guarantees[1].GuarantorStatus.Name)
+}
+
+func TestGuarantorForUpdate(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	guarantor := modelstest.CreateGuarantor(t, testDB)
+
+	err := repo.Transaction(ctx, func(tx user.Repository) error {
+		found, err := tx.GetGuaranteeForUpdate(ctx, guarantor.GuarantorID.String())
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, found.Lending)
+
+		now := time.Now()
+		found.GuarantorStatusID = 2
+		found.RespondedAt = &now
+		found.GuarantorStatus = &models.GuarantorStatusType{GuarantorStatusID: 3, Name: "ignored"}
+		_, err = tx.UpdateGuarantor(ctx, found)
+		return err
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetGuaranteeByID(ctx, guarantor.GuarantorID.String())
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.GuarantorStatusID)
+	assert.Equal(t, "accepted", found.GuarantorStatus.Name)
+	assert.NotNil(t, found.RespondedAt)
+
+	_, err = repo.GetGuaranteeForUpdate(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func assertGuaranteeShape(t *testing.T, guarantor *models.Guarantor, lending *models.Lending) {
+	t.Helper()
+
+	require.NotNil(t, guarantor.GuarantorStatus)
+	require.NotNil(t, guarantor.User)
+	assert.Equal(t, guarantor.UserID, guarantor.User.UserID)
+	require.NotNil(t, guarantor.Lending)
+	assert.Equal(t, lending.LendingID, guarantor.Lending.LendingID)
+	require.NotNil(t, guarantor.Lending.LendingStatus)
+	require.NotNil(t, guarantor.Lending.Debtor)
+	require.NotNil(t, guarantor.Lending.Debtor.User)
+	assert.Equal(t, lending.DebtorID, guarantor.Lending.Debtor.DebtorID)
+	require.NotNil(t, guarantor.Lending.Installments)
+}
+
+func TestGetOverdueInstallments(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	lending := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 3 })
+	accepted := modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) {
+		g.LendingID = lending.LendingID
+		g.GuarantorStatusID = 2
+	})
+	modelstest.CreateGuarantor(t, testDB, func(g *models.Guarantor) { g.LendingID = lending.LendingID })
+
+	installment := func(lendingID uuid.UUID, status int, dueDate time.Time) *models.Installment {
+		return modelstest.CreateInstallment(t, testDB, func(i *models.Installment) {
+			i.LendingID = lendingID
+			i.InstallmentStatusID = status
+			i.DueDate = dueDate
+		})
+	}
+	overdue := installment(lending.LendingID, 1, now.AddDate(0, 0, -3))
+	installment(lending.LendingID, 1, now.AddDate(0, 0, 3))
+	installment(lending.LendingID, 2, now.AddDate(0, 0, -30))
+	installment(lending.LendingID, 3, now.AddDate(0, 0, -30))
+
+	writtenOff := modelstest.CreateLending(t, testDB, func(l *models.Lending) { l.LendingStatusID = 6 })
+	installment(writtenOff.LendingID, 1, now.AddDate(0, 0, -3))
+
+	installments, err := repo.GetOverdueInstallments(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, installments, 1)
+	assert.Equal(t, overdue.InstallmentID, installments[0].InstallmentID)
+	require.NotNil(t, installments[0].Lending)
+	require.NotNil(t, installments[0].Lending.Guarantors)
+	guarantors := *installments[0].Lending.Guarantors
+	require.Len(t, guarantors, 1)
+	assert.Equal(t, accepted.GuarantorID, guarantors[0].GuarantorID)
+}
+
+func TestNotifications(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	owner := modelstest.CreateUser(t, testDB)
+	installment := modelstest.CreateInstallment(t, testDB)
+	now := time.Now()
+
+	older := modelstest.CreateNotification(t, testDB, func(n *models.Notification) {
+		n.UserID = owner.UserID
+		n.CreatedAt = now.Add(-time.Hour)
+	})
+	newer, err := repo.CreateNotification(ctx, &models.Notification{
+		NotificationID: uuid.New(),
+		UserID:         owner.UserID,
+		Title:          "Installment overdue",
+		Message:        "Please pay your installment.",
+		ReferenceID:    &installment.InstallmentID,
+	})
+	require.NoError(t, err)
+	modelstest.CreateNotification(t, testDB)
+
+	exist, err := repo.CheckNotificationExist(ctx, owner.UserID.String(), installment.InstallmentID.String())
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	exist, err = repo.CheckNotificationExist(ctx, owner.UserID.String(), uuid.NewString())
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	notifications, err := repo.GetNotifications(ctx, owner.UserID.String())
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, newer.NotificationID, notifications[0].NotificationID)
+	assert.Equal(t, older.NotificationID, notifications[1].NotificationID)
+}
@@ -9,13 +9,25 @@ import (
 
 type UseCase interface {
 	GetDebtorDetails(ctx context.Context, userID string) (*models.Debtor, error)
+	GetCreditHealth(ctx context.Context, userID string) (*body.CreditHealthResponse, error)
+	SubmitKyc(ctx context.Context, userID string, body body.SubmitKycRequest) (*models.KycSubmission, error)
+	GetKycSubmissions(ctx context.Context, userID string) ([]*models.KycSubmission, error)
 	ConfirmContract(ctx context.Context, userID string) (*models.Debtor, error)
+	GetLoanProducts(ctx context.Context, userID string) ([]*models.LoanProduct, error)
 	CreateLoan(ctx context.Context, userID string, body body.CreateLoan) (*models.Lending, error)
+	CancelLoan(ctx context.Context, userID, lendingID string, body body.CancelLoanRequest) (*models.Lending, error)
 	GetLoans(ctx context.Context, userID, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error)
 	GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error)
 	GetInstallmentByID(ctx context.Context, installmentID string) (*models.Installment, error)
-	CreatePayment(ctx context.Context, userID, installmentID string, body body.CreatePayment) (*models.Payment, error)
+	CreatePayment(ctx context.Context, userID, installmentID string, body body.CreatePayment) (*models.PaymentIntent, error)
 	GetPayments(ctx context.Context, userID string, name string, pagination *utils.Pagination) (*utils.Pagination, error)
+	GetRestructurings(ctx context.Context, userID string) ([]*models.Restructuring, error)
+	RespondRestructuring(ctx context.Context, userID, restructuringID string, body body.RespondRestructuringRequest) (*models.Restructuring, error)
+	InviteGuarantor(ctx context.Context, userID, lendingID string, body body.InviteGuarantorRequest) (*models.Guarantor, error)
+	GetGuarantees(ctx context.Context, userID string) ([]*models.Guarantor, error)
+	RespondGuarantee(ctx context.Context, userID, guarantorID string, body body.RespondGuaranteeRequest) (*models.Guarantor, error)
+	GetNotifications(ctx context.Context, userID string) ([]*models.Notification, error)
+	NotifyOverdueGuarantors(ctx context.Context) (int, error)
 	UpdateUserByID(ctx context.Context, userID string, body body.UpdateUserRequest) (*models.User, error)
 }
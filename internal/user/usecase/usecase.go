@@ -6,23 +6,31 @@ import (
 	"final-project-backend/internal/models"
 	"final-project-backend/internal/user"
 	"final-project-backend/internal/user/delivery/body"
+	"final-project-backend/pkg/gateway"
 	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/metrics"
 	"final-project-backend/pkg/response"
+	"final-project-backend/pkg/storage"
 	"final-project-backend/pkg/utils"
 	"fmt"
 	"gorm.io/gorm"
 	"math"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
 type userUC struct {
 	cfg      *config.Config
 	userRepo user.Repository
+	provider gateway.Provider
+	storage  storage.Storage
 }
 
-func NewUserUseCase(cfg *config.Config, userRepo user.Repository) user.UseCase {
-	return &userUC{cfg: cfg, userRepo: userRepo}
+func NewUserUseCase(cfg *config.Config, userRepo user.Repository, provider gateway.Provider, storage storage.Storage) user.UseCase {
+	return &userUC{cfg: cfg, userRepo: userRepo, provider: provider, storage: storage}
 }
 
 func (u *userUC) GetLoanByID(ctx context.Context, lendingID string) (*models.Lending, error) {
@@ -49,148 +57,182 @@ func (u *userUC) GetInstallmentByID(ctx context.Context, id string) (*models.Ins
 	return installment, nil
 }
 
-func (u *userUC) CreatePayment(ctx context.Context, userID, installmentID string, body body.CreatePayment) (*models.Payment, error) {
-	delay := 0
-	payment := &models.Payment{}
+func (u *userUC) CreatePayment(ctx context.Context, userID, installmentID string, body body.CreatePayment) (*models.PaymentIntent, error) {
+	intent := &models.PaymentIntent{}
 
 	loc, _ := time.LoadLocation("Asia/Jakarta")
 	timeNow := time.Now().In(loc)
 
 	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
 	if err != nil {
-		return payment, err
+		return intent, err
 	}
 
 	lending, err := u.userRepo.GetLoanByID(ctx, body.LendingID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return payment, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+			return intent, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
 		}
-		return payment, err
+		return intent, err
 	}
 
 	installment, err := u.userRepo.GetInstallmentByID(ctx, installmentID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return payment, httperror.New(http.StatusBadRequest, response.InstallmentNotExist)
+			return intent, httperror.New(http.StatusBadRequest, response.InstallmentNotExist)
 		}
-		return payment, err
+		return intent, err
+	}
+
+	if installment.InstallmentStatusID == 3 {
+		return intent, httperror.New(http.StatusBadRequest, response.InstallmentRestructured)
 	}
 
 	if installment.InstallmentStatusID != 1 {
-		return payment, httperror.New(http.StatusBadRequest, response.InstallmentAlreadyPaid)
+		return intent, httperror.New(http.StatusBadRequest, response.InstallmentAlreadyPaid)
 	}
 
-	fmt.Println(lending.DebtorID)
-	fmt.Println(debtor.DebtorID)
-	if lending.DebtorID != debtor.DebtorID {
-		return payment, httperror.New(http.StatusBadRequest, response.LendingInstallmentNotMatch)
+	if lending.DebtorID != debtor.DebtorID || installment.LendingID != lending.LendingID {
+		return intent, httperror.New(http.StatusBadRequest, response.LendingInstallmentNotMatch)
 	}
 
-	payment.VoucherID = nil
-	voucher := &models.Voucher{}
-	if body.VoucherID != "" {
-		voucher, err = u.userRepo.GetVoucherByID(ctx, body.VoucherID)
+	intent.PaymentFine = installment.Fine(timeNow, lending.FinePerDay)
+	intent.VoucherID = nil
+	intent.VoucherCodeID = nil
+	if body.VoucherID != "" || body.VoucherCode != "" {
+		voucher, voucherCode, err := u.getVoucher(ctx, body.VoucherID, body.VoucherCode)
 		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				return payment, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
-			}
-			return payment, err
+			return intent, err
 		}
 
-		if voucher.DiscountQuota > 0 && (timeNow.Sub(voucher.ActiveDate).Seconds() >= 0 && timeNow.Sub(voucher.ExpireDate).Seconds() <= 0) {
-			payment.VoucherID = &voucher.VoucherID
-			payment.PaymentDiscount = math.Floor(installment.Amount * (float64(voucher.DiscountPayment) / 100.0))
-		} else {
-			return payment, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		if !voucher.IsActive(timeNow) {
+			return intent, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
 		}
-	}
 
-	delayTime := timeNow.Sub(installment.DueDate)
-	if delayTime.Seconds() > 0 {
-		delay = int(delayTime.Hours()/24) + 1
+		if !voucher.IsEligible(debtor.CreditHealthID, lending.LoanProductID, installment.Amount) {
+			return intent, httperror.New(http.StatusBadRequest, response.VoucherNotEligible)
+		}
+
+		// A fine waiver on an installment paid on time would use the voucher
+		// up for nothing.
+		discount, fineWaiver := voucher.Discount(installment.Amount, intent.PaymentFine)
+		if discount+fineWaiver <= 0 {
+			return intent, httperror.New(http.StatusBadRequest, response.VoucherNotEligible)
+		}
+
+		intent.VoucherID = &voucher.VoucherID
+		if voucherCode != nil {
+			intent.VoucherCodeID = &voucherCode.VoucherCodeID
+		}
+		intent.PaymentDiscount = discount
+		intent.PaymentFineWaiver = fineWaiver
 	}
 
-	payment.InstallmentID = installment.InstallmentID
-	payment.PaymentDate = timeNow
-	payment.PaymentFine = float64(5000 * delay)
-	payment.PaymentAmount = installment.Amount - payment.PaymentDiscount + payment.PaymentFine
-	if err := payment.PrepareCreate(); err != nil {
-		return payment, nil
+	intent.InstallmentID = installment.InstallmentID
+	intent.PaymentAmount = installment.Amount - intent.PaymentDiscount + intent.PaymentFine - intent.PaymentFineWaiver
+	if err := intent.PrepareCreate(); err != nil {
+		return intent, err
 	}
 
-	payment, err = u.userRepo.CreatePayment(ctx, payment)
+	charge, err := u.provider.CreateCharge(ctx, gateway.ChargeRequest{
+		ReferenceID:  intent.PaymentIntentID.String(),
+		Channel:      body.Channel,
+		CustomerName: debtor.User.Name,
+		Amount:       intent.PaymentAmount,
+		ExpireDate:   timeNow.Add(time.Duration(u.cfg.Gateway.IntentExpMin) * time.Minute),
+	})
 	if err != nil {
-		return payment, err
+		return intent, err
 	}
 
-	if body.VoucherID != "" {
-		voucher.DiscountQuota -= 1
-		if err := u.userRepo.UpdateVoucher(ctx, voucher); err != nil {
-			return payment, err
+	intent.Provider = u.provider.Name()
+	intent.Channel = charge.Channel
+	intent.ExternalID = charge.ExternalID
+	intent.AccountNumber = charge.AccountNumber
+	intent.ExpireDate = charge.ExpireDate
+
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		if err := repo.ExpirePaymentIntents(ctx, installment.InstallmentID.String()); err != nil {
+			return err
 		}
 
-		if voucher.DiscountQuota <= 0 {
-			if err := u.userRepo.DeleteVoucher(ctx, voucher); err != nil {
-				return payment, err
+		if intent.VoucherID != nil {
+			if err := reserveVoucher(ctx, repo, intent, debtor.DebtorID.String(), timeNow); err != nil {
+				return err
 			}
 		}
-	}
 
-	if debtor.CreditUsed-installment.Amount < 0 {
-		debtor.CreditUsed = 0
-	} else {
-		debtor.CreditUsed = debtor.CreditUsed - installment.Amount
+		intent, err = repo.CreatePaymentIntent(ctx, intent)
+		return err
+	})
+	if err != nil {
+		return intent, err
 	}
 
-	debtor.TotalDelay = debtor.TotalDelay + delay
-	if delay == 0 {
-		if debtor.TotalDelay-10 < 0 {
-			debtor.TotalDelay = 0
-		} else {
-			debtor.TotalDelay = debtor.TotalDelay - 10
-		}
-	}
+	return intent, nil
+}
 
-	switch {
-	case debtor.TotalDelay > 20:
-		debtor.CreditHealthID = 3
-	case debtor.TotalDelay > 10:
-		debtor.CreditHealthID = 2
-	default:
-		debtor.CreditHealthID = 1
+// reserveVoucher checks the single use code, the quota and the per user
+// limit of the intent's voucher with the voucher locked. A pending intent
+// counts as a use until it expires, so two intents paid later cannot both get
+// the discount.
+func reserveVoucher(ctx context.Context, repo user.Repository, intent *models.PaymentIntent, debtorID string, at time.Time) error {
+	voucher, err := repo.GetVoucherForUpdate(ctx, intent.VoucherID.String())
+	if err != nil {
+		return err
 	}
 
-	debtor, err = u.userRepo.UpdateDebtorByID(ctx, debtor)
+	reserved, err := repo.CountPendingVoucherIntents(ctx, voucher.VoucherID.String(), "", at)
 	if err != nil {
-		return payment, err
+		return err
 	}
 
-	installment.InstallmentStatusID = 2
-	installment, err = u.userRepo.UpdateInstallment(ctx, installment)
-	if err != nil {
-		return payment, err
+	if reserved >= int64(voucher.DiscountQuota) {
+		return httperror.New(http.StatusBadRequest, response.VoucherQuotaUsedUp)
+	}
+
+	if intent.VoucherCodeID != nil {
+		taken, err := repo.CheckVoucherCodeTaken(ctx, intent.VoucherCodeID.String(), at)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return httperror.New(http.StatusBadRequest, response.VoucherCodeRedeemed)
+		}
 	}
 
-	totalPaid := 0
-	for _, installment := range *lending.Installments {
-		if installment.InstallmentStatusID == 2 {
-			totalPaid++
+	if voucher.PerUserLimit > 0 {
+		redemptions, err := repo.CountVoucherRedemptions(ctx, voucher.VoucherID.String(), debtorID)
+		if err != nil {
+			return err
+		}
+
+		pending, err := repo.CountPendingVoucherIntents(ctx, voucher.VoucherID.String(), debtorID, at)
+		if err != nil {
+			return err
+		}
+
+		if redemptions+pending >= int64(voucher.PerUserLimit) {
+			return httperror.New(http.StatusBadRequest, response.VoucherLimitReached)
 		}
 	}
 
-	if totalPaid == len(*lending.Installments)-1 {
-		lending.LendingStatusID = 4
-	} else {
-		lending.LendingStatusID = 3
+	return nil
+}
+
+func (u *userUC) GetLoanProducts(ctx context.Context, userID string) ([]*models.LoanProduct, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	lending, err = u.userRepo.UpdateLending(ctx, lending)
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	loanProducts, err := u.userRepo.GetActiveLoanProducts(ctx, debtor.CreditHealthID, time.Now().In(loc))
 	if err != nil {
-		return payment, err
+		return nil, err
 	}
 
-	return payment, nil
+	return loanProducts, nil
 }
 
 func (u *userUC) CreateLoan(ctx context.Context, userID string, body body.CreateLoan) (*models.Lending, error) {
@@ -200,51 +242,145 @@ func (u *userUC) CreateLoan(ctx context.Context, userID string, body body.Create
 		return lending, err
 	}
 
+	if debtor.KycStatusID != 3 {
+		return lending, httperror.New(http.StatusBadRequest, response.KycNotApproved)
+	}
+
 	if debtor.ContractTrackingID != 5 {
 		return lending, httperror.New(http.StatusBadRequest, response.ContractNotConfirmed)
 	}
 
-	period, err := u.userRepo.GetLoanPeriodByID(ctx, body.LoadPeriodID)
+	product, err := u.userRepo.GetLoanProductByID(ctx, body.LoanProductID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return lending, httperror.New(http.StatusBadRequest, response.LoanPeriodNotExist)
+			return lending, httperror.New(http.StatusBadRequest, response.LoanProductNotExist)
 		}
 		return lending, err
 	}
 
-	amount := body.Amount * (float64(period.Percentage) / 100)
-	switch debtor.CreditHealthID {
-	case 1:
-		if debtor.CreditLimit-(debtor.CreditUsed+amount) < 0 {
-			return lending, httperror.New(http.StatusBadRequest, response.LoanAmountExceedCreditLimit)
-		}
-	case 2:
-		if (debtor.CreditLimit*(float64(80)/100))-(debtor.CreditUsed+amount) < 0 {
-			return lending, httperror.New(http.StatusBadRequest, response.LoanAmountExceedCreditLimitWarning)
-		}
-	case 3:
-		return lending, httperror.New(http.StatusBadRequest, response.CreditHealthStatusBlocked)
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	if !product.IsActive(time.Now().In(loc)) {
+		return lending, httperror.New(http.StatusBadRequest, response.LoanProductNotActive)
 	}
 
+	if body.Amount < product.MinAmount || body.Amount > product.MaxAmount {
+		return lending, httperror.New(http.StatusBadRequest, response.LoanAmountOutOfRange)
+	}
+
+	if debtor.CreditHealthID != 3 && !product.IsEligible(debtor.CreditHealthID) {
+		return lending, httperror.New(http.StatusBadRequest, response.LoanProductNotEligible)
+	}
+
+	amount := body.Amount * (float64(product.Percentage) / 100)
 	lending.DebtorID = debtor.DebtorID
-	lending.LoanPeriodID = period.LoanPeriodID
+	lending.ApplyProduct(product)
 	lending.Name = body.Name
 	lending.Amount = amount
 	if err = lending.PrepareCreate(); err != nil {
 		return lending, err
 	}
 
-	createdLending, err := u.userRepo.CreateLending(ctx, lending)
+	// The lending and the credit it reserves are written together, against
+	// the debtor as it is once locked.
+	var createdLending *models.Lending
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		debtor, err := repo.GetDebtorForUpdate(ctx, debtor.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		switch debtor.CreditHealthID {
+		case 1:
+			if debtor.CreditLimit-(debtor.CreditUsed+amount) < 0 {
+				return httperror.New(http.StatusBadRequest, response.LoanAmountExceedCreditLimit)
+			}
+		case 2:
+			if (debtor.CreditLimit*(float64(80)/100))-(debtor.CreditUsed+amount) < 0 {
+				return httperror.New(http.StatusBadRequest, response.LoanAmountExceedCreditLimitWarning)
+			}
+		case 3:
+			return httperror.New(http.StatusBadRequest, response.CreditHealthStatusBlocked)
+		}
+
+		createdLending, err = repo.CreateLending(ctx, lending)
+		if err != nil {
+			return err
+		}
+
+		debtor.CreditUsed = debtor.CreditUsed + amount
+		_, err = repo.UpdateDebtorByID(ctx, debtor)
+		return err
+	})
+	if err != nil {
+		return lending, err
+	}
+
+	metrics.LoanCreated()
+	return createdLending, nil
+}
+
+func (u *userUC) CancelLoan(ctx context.Context, userID, lendingID string, body body.CancelLoanRequest) (*models.Lending, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	debtor.CreditUsed = debtor.CreditUsed + amount
-	if _, err := u.userRepo.UpdateDebtorByID(ctx, debtor); err != nil {
+	lending, err := u.userRepo.GetLoanByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
 		return nil, err
 	}
 
-	return createdLending, nil
+	if lending.DebtorID != debtor.DebtorID {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timeNow := time.Now().In(loc)
+
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		lending, err := repo.GetLoanForUpdate(ctx, lendingID)
+		if err != nil {
+			return err
+		}
+
+		if lending.LendingStatusID != 1 {
+			return httperror.New(http.StatusBadRequest, response.LendingCannotCancel)
+		}
+
+		lending.LendingStatusID = 7
+		lending.CancelledAt = &timeNow
+		if body.Reason != "" {
+			lending.CancelReason = &body.Reason
+		}
+
+		if _, err := repo.UpdateLending(ctx, lending); err != nil {
+			return err
+		}
+
+		// Payments settling at the same time change the debtor too, so the
+		// credit is given back on the locked row.
+		debtor, err := repo.GetDebtorForUpdate(ctx, lending.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		debtor.CreditUsed -= lending.Amount
+		_, err = repo.UpdateDebtorByID(ctx, debtor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lending, err = u.userRepo.GetLoanByID(ctx, lendingID)
+	if err != nil {
+		return lending, err
+	}
+
+	return lending, nil
 }
 
 func (u *userUC) ConfirmContract(ctx context.Context, userID string) (*models.Debtor, error) {
@@ -311,6 +447,26 @@ func (u *userUC) GetDebtorDetails(ctx context.Context, userID string) (*models.D
 	return debtor, nil
 }
 
+func (u *userUC) GetCreditHealth(ctx context.Context, userID string) (*body.CreditHealthResponse, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := u.userRepo.GetCreditHealthHistories(ctx, debtor.DebtorID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &body.CreditHealthResponse{
+		CreditHealth:            debtor.CreditHealth,
+		TotalDelay:              debtor.TotalDelay,
+		GoodDelayThreshold:      models.GoodCreditHealthDelay,
+		OnTimePaymentsToRecover: models.OnTimePaymentsToRecover(debtor.TotalDelay),
+		Histories:               histories,
+	}, nil
+}
+
 func (u *userUC) GetLoans(ctx context.Context, userID, name string, status []int, pagination *utils.Pagination) (*utils.Pagination, error) {
 	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
 	if err != nil {
@@ -324,6 +480,44 @@ func (u *userUC) GetLoans(ctx context.Context, userID, name string, status []int
 	return loans, nil
 }
 
+// getVoucher finds a voucher by its code or, for vouchers that do not require
+// one, by its ID. A single use code must not have been redeemed yet.
+func (u *userUC) getVoucher(ctx context.Context, voucherID, code string) (*models.Voucher, *models.VoucherCode, error) {
+	if code != "" {
+		voucherCode, err := u.userRepo.GetVoucherCodeByCode(ctx, code)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, nil, httperror.New(http.StatusBadRequest, response.VoucherCodeNotExist)
+			}
+			return nil, nil, err
+		}
+
+		if voucherCode.Voucher == nil || voucherCode.Voucher.IsArchived() {
+			return nil, nil, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		}
+
+		if voucherCode.IsRedeemed() {
+			return nil, nil, httperror.New(http.StatusBadRequest, response.VoucherCodeRedeemed)
+		}
+
+		return voucherCode.Voucher, voucherCode, nil
+	}
+
+	voucher, err := u.userRepo.GetVoucherByID(ctx, voucherID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, httperror.New(http.StatusBadRequest, response.VoucherNotExist)
+		}
+		return nil, nil, err
+	}
+
+	if voucher.CodeRequired {
+		return nil, nil, httperror.New(http.StatusBadRequest, response.VoucherCodeRequired)
+	}
+
+	return voucher, nil, nil
+}
+
 func (u *userUC) GetVouchers(ctx context.Context, name string, pagination *utils.Pagination) (*utils.Pagination, error) {
 	vouchers, err := u.userRepo.GetVouchers(ctx, name, pagination)
 	if err != nil {
@@ -346,3 +540,423 @@ func (u *userUC) GetPayments(ctx context.Context, userID, name string, paginatio
 
 	return payments, nil
 }
+
+func (u *userUC) GetRestructurings(ctx context.Context, userID string) ([]*models.Restructuring, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	restructurings, err := u.userRepo.GetRestructurings(ctx, debtor.DebtorID.String())
+	if err != nil {
+		return restructurings, err
+	}
+
+	return restructurings, nil
+}
+
+func (u *userUC) RespondRestructuring(ctx context.Context, userID, restructuringID string, body body.RespondRestructuringRequest) (*models.Restructuring, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	restructuring, err := u.userRepo.GetRestructuringByID(ctx, restructuringID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.RestructuringNotExist)
+		}
+		return nil, err
+	}
+
+	if restructuring.Lending.DebtorID != debtor.DebtorID {
+		return nil, httperror.New(http.StatusBadRequest, response.RestructuringNotExist)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timeNow := time.Now().In(loc)
+
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		restructuring, err := repo.GetRestructuringForUpdate(ctx, restructuringID)
+		if err != nil {
+			return err
+		}
+
+		if restructuring.RestructuringStatusID != 1 {
+			return httperror.New(http.StatusBadRequest, response.RestructuringAlreadyResponded)
+		}
+
+		restructuring.RespondedAt = &timeNow
+		if body.Action == "decline" {
+			restructuring.RestructuringStatusID = 3
+			_, err := repo.UpdateRestructuring(ctx, restructuring)
+			return err
+		}
+
+		lending, err := repo.GetLoanByID(ctx, restructuring.LendingID.String())
+		if err != nil {
+			return err
+		}
+
+		outstanding := 0.0
+		for _, installment := range *lending.Installments {
+			if installment.InstallmentStatusID == 1 {
+				outstanding += installment.Amount
+			}
+		}
+
+		// A payment or another restructuring since the proposal changes what
+		// is owed, so the terms no longer apply.
+		if (lending.LendingStatusID != 2 && lending.LendingStatusID != 3) || math.Abs(outstanding-restructuring.OutstandingAmount) >= 0.01 {
+			return httperror.New(http.StatusBadRequest, response.RestructuringOutdated)
+		}
+
+		for _, installment := range *lending.Installments {
+			if installment.InstallmentStatusID != 1 {
+				continue
+			}
+
+			if err := repo.ExpirePaymentIntents(ctx, installment.InstallmentID.String()); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.SupersedeInstallments(ctx, lending.LendingID.String(), restructuringID); err != nil {
+			return err
+		}
+
+		restructuring.FirstDueDate = models.DueDateAfter(timeNow, 1)
+		installments, err := restructuring.BuildInstallments()
+		if err != nil {
+			return err
+		}
+
+		if err := repo.CreateInstallments(ctx, installments); err != nil {
+			return err
+		}
+
+		// Payments settled since the debtor was read above change credit
+		// used, so the fine is added to the locked row.
+		locked, err := repo.GetDebtorForUpdate(ctx, debtor.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		locked.CreditUsed = locked.CreditUsed + restructuring.CapitalizedFine
+		if _, err := repo.UpdateDebtorByID(ctx, locked); err != nil {
+			return err
+		}
+
+		restructuring.RestructuringStatusID = 2
+		_, err = repo.UpdateRestructuring(ctx, restructuring)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restructuring, err = u.userRepo.GetRestructuringByID(ctx, restructuringID)
+	if err != nil {
+		return restructuring, err
+	}
+
+	return restructuring, nil
+}
+
+func (u *userUC) SubmitKyc(ctx context.Context, userID string, body body.SubmitKycRequest) (*models.KycSubmission, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkKycSubmittable(debtor); err != nil {
+		return nil, err
+	}
+
+	submission := &models.KycSubmission{}
+	if err := submission.PrepareCreate(debtor.DebtorID); err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("kyc/%s/%s", debtor.DebtorID, submission.KycSubmissionID)
+	submission.IDCardKey, err = u.saveKycFile(ctx, prefix+"/id_card", body.IDCard)
+	if err != nil {
+		return nil, err
+	}
+
+	submission.SelfieKey, err = u.saveKycFile(ctx, prefix+"/selfie", body.Selfie)
+	if err != nil {
+		u.deleteKycFiles(submission.IDCardKey)
+		return nil, err
+	}
+
+	// Another submission may have been made while the files were uploading,
+	// so the status is checked again on the locked debtor.
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		debtor, err := repo.GetDebtorForUpdate(ctx, debtor.DebtorID.String())
+		if err != nil {
+			return err
+		}
+
+		if err := checkKycSubmittable(debtor); err != nil {
+			return err
+		}
+
+		submission, err = repo.CreateKycSubmission(ctx, submission)
+		if err != nil {
+			return err
+		}
+
+		debtor.KycStatusID = 2
+		return repo.UpdateDebtorKycStatus(ctx, debtor)
+	})
+	if err != nil {
+		u.deleteKycFiles(submission.IDCardKey, submission.SelfieKey)
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+func checkKycSubmittable(debtor *models.Debtor) error {
+	switch debtor.KycStatusID {
+	case 2:
+		return httperror.New(http.StatusBadRequest, response.KycAlreadyPending)
+	case 3:
+		return httperror.New(http.StatusBadRequest, response.KycAlreadyApproved)
+	}
+
+	return nil
+}
+
+// deleteKycFiles removes the uploads of a submission that was not saved. It
+// runs on a fresh context, so a cancelled request still cleans up.
+func (u *userUC) deleteKycFiles(keys ...string) {
+	for _, key := range keys {
+		_ = u.storage.Delete(context.Background(), key)
+	}
+}
+
+func (u *userUC) GetKycSubmissions(ctx context.Context, userID string) ([]*models.KycSubmission, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := u.userRepo.GetKycSubmissions(ctx, debtor.DebtorID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+func (u *userUC) saveKycFile(ctx context.Context, key string, file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key = key + strings.ToLower(filepath.Ext(file.Filename))
+	if err := u.storage.Save(ctx, key, src); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (u *userUC) InviteGuarantor(ctx context.Context, userID, lendingID string, body body.InviteGuarantorRequest) (*models.Guarantor, error) {
+	debtor, err := u.userRepo.GetDebtorDetailsByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lending, err := u.userRepo.GetLoanByID(ctx, lendingID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+		}
+		return nil, err
+	}
+
+	if lending.DebtorID != debtor.DebtorID {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingIDNotExist)
+	}
+
+	if lending.LendingStatusID != 1 {
+		return nil, httperror.New(http.StatusBadRequest, response.LendingCannotAddGuarantor)
+	}
+
+	invitee, err := u.userRepo.CheckEmailExist(ctx, body.Email)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.GuarantorInvalid)
+		}
+		return nil, err
+	}
+
+	if invitee.RoleID != 2 || invitee.UserID == debtor.UserID {
+		return nil, httperror.New(http.StatusBadRequest, response.GuarantorInvalid)
+	}
+
+	_, err = u.userRepo.CheckActiveGuarantor(ctx, lendingID, invitee.UserID.String())
+	if err == nil {
+		return nil, httperror.New(http.StatusBadRequest, response.GuarantorAlreadyInvited)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	guarantor := &models.Guarantor{}
+	if err := guarantor.PrepareCreate(lending.LendingID, invitee.UserID); err != nil {
+		return nil, err
+	}
+
+	notification, err := models.NewNotification(
+		invitee.UserID,
+		"Guarantor invitation",
+		fmt.Sprintf("%s invited you to guarantee the loan %s.", debtor.User.Name, lending.Name),
+		&guarantor.GuarantorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdGuarantor *models.Guarantor
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		createdGuarantor, err = repo.CreateGuarantor(ctx, guarantor)
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.CreateNotification(ctx, notification)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdGuarantor, nil
+}
+
+func (u *userUC) GetGuarantees(ctx context.Context, userID string) ([]*models.Guarantor, error) {
+	guarantees, err := u.userRepo.GetGuarantees(ctx, userID)
+	if err != nil {
+		return guarantees, err
+	}
+
+	return guarantees, nil
+}
+
+func (u *userUC) RespondGuarantee(ctx context.Context, userID, guarantorID string, body body.RespondGuaranteeRequest) (*models.Guarantor, error) {
+	guarantor, err := u.userRepo.GetGuaranteeByID(ctx, guarantorID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, httperror.New(http.StatusBadRequest, response.GuarantorNotExist)
+		}
+		return nil, err
+	}
+
+	if guarantor.UserID.String() != userID {
+		return nil, httperror.New(http.StatusBadRequest, response.GuarantorNotExist)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	timeNow := time.Now().In(loc)
+
+	err = u.userRepo.Transaction(ctx, func(repo user.Repository) error {
+		// The lending is locked first, as approving or cancelling it does,
+		// so an answer cannot land on a loan decided in the meantime.
+		lending, err := repo.GetLoanForUpdate(ctx, guarantor.LendingID.String())
+		if err != nil {
+			return err
+		}
+
+		if lending.LendingStatusID != 1 {
+			return httperror.New(http.StatusBadRequest, response.LendingCannotRespondGuarantee)
+		}
+
+		guarantor, err := repo.GetGuaranteeForUpdate(ctx, guarantorID)
+		if err != nil {
+			return err
+		}
+
+		if guarantor.GuarantorStatusID != 1 {
+			return httperror.New(http.StatusBadRequest, response.GuarantorAlreadyResponded)
+		}
+
+		guarantor.RespondedAt = &timeNow
+		guarantor.GuarantorStatusID = 2
+		if body.Action == "decline" {
+			guarantor.GuarantorStatusID = 3
+		}
+
+		_, err = repo.UpdateGuarantor(ctx, guarantor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	guarantor, err = u.userRepo.GetGuaranteeByID(ctx, guarantorID)
+	if err != nil {
+		return guarantor, err
+	}
+
+	return guarantor, nil
+}
+
+func (u *userUC) GetNotifications(ctx context.Context, userID string) ([]*models.Notification, error) {
+	notifications, err := u.userRepo.GetNotifications(ctx, userID)
+	if err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
+
+// NotifyOverdueGuarantors tells every accepted guarantor about each overdue
+// installment once, keyed on the installment so repeated runs stay quiet.
+func (u *userUC) NotifyOverdueGuarantors(ctx context.Context) (int, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	installments, err := u.userRepo.GetOverdueInstallments(ctx, time.Now().In(loc))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, installment := range installments {
+		if installment.Lending == nil || installment.Lending.Guarantors == nil {
+			continue
+		}
+
+		for _, guarantor := range *installment.Lending.Guarantors {
+			exist, err := u.userRepo.CheckNotificationExist(ctx, guarantor.UserID.String(), installment.InstallmentID.String())
+			if err != nil {
+				return sent, err
+			}
+			if exist {
+				continue
+			}
+
+			notification, err := models.NewNotification(
+				guarantor.UserID,
+				"Guaranteed loan overdue",
+				fmt.Sprintf("An installment of the loan %s you guarantee was due on %s and is unpaid.",
+					installment.Lending.Name, installment.DueDate.In(loc).Format("2006-01-02")),
+				&installment.InstallmentID,
+			)
+			if err != nil {
+				return sent, err
+			}
+
+			if _, err := u.userRepo.CreateNotification(ctx, notification); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+	}
+
+	return sent, nil
+}
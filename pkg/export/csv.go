@@ -0,0 +1,93 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvFlushRows bounds how many rows are buffered before they are written out.
+const csvFlushRows = 500
+
+const dateLayout = "2006-01-02 15:04:05"
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []Column
+	record  []string
+	pending int
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	c := &csvWriter{
+		writer:  csv.NewWriter(w),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+
+	for i, column := range columns {
+		c.record[i] = column.Name
+	}
+	if err := c.writer.Write(c.record); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *csvWriter) Write(values ...interface{}) error {
+	if len(values) != len(c.columns) {
+		return fmt.Errorf("export row has %d values for %d columns", len(values), len(c.columns))
+	}
+
+	for i, column := range c.columns {
+		c.record[i] = formatCSV(column.Kind, values[i])
+	}
+	if err := c.writer.Write(c.record); err != nil {
+		return err
+	}
+
+	c.pending++
+	if c.pending >= csvFlushRows {
+		c.pending = 0
+		c.writer.Flush()
+		return c.writer.Error()
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) Abort() {}
+
+func formatCSV(kind Kind, value interface{}) string {
+	switch kind {
+	case Money:
+		if v, ok := value.(float64); ok {
+			return strconv.FormatFloat(v, 'f', 2, 64)
+		}
+	case Ratio:
+		if v, ok := value.(float64); ok {
+			return strconv.FormatFloat(v, 'f', 4, 64)
+		}
+	case Integer:
+		if v, ok := value.(int); ok {
+			return strconv.Itoa(v)
+		}
+	case Date:
+		if t, ok := dateValue(value); ok {
+			return t.Format(dateLayout)
+		}
+		return ""
+	}
+
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Kind tells a writer how to render a column.
+type Kind int
+
+const (
+	Text Kind = iota
+	Money
+	Integer
+	Date
+	Ratio
+)
+
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Writer writes a header and then one row at a time. Values must match the
+// column kinds: string for Text, float64 for Money and Ratio, int for Integer
+// and time.Time or *time.Time for Date. Close finishes the file; Abort releases
+// the writer without finishing it when the export fails part way.
+type Writer interface {
+	Write(values ...interface{}) error
+	Close() error
+	Abort()
+}
+
+func NewWriter(format string, w io.Writer, columns []Column) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// FormatFor picks the export format from the format query parameter or the
+// Accept header. An empty result means a regular JSON response.
+func FormatFor(query, accept string) string {
+	switch strings.ToLower(strings.TrimSpace(query)) {
+	case FormatCSV:
+		return FormatCSV
+	case FormatXLSX:
+		return FormatXLSX
+	}
+
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return FormatCSV
+	case strings.Contains(accept, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"):
+		return FormatXLSX
+	}
+
+	return ""
+}
+
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FileName names an export after the listing and the time it was taken.
+func FileName(name, format string) string {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	return name + "-" + time.Now().In(loc).Format("20060102-150405") + "." + format
+}
+
+// dateValue converts a Date column value to Jakarta time. A nil pointer is an
+// empty cell.
+func dateValue(value interface{}) (time.Time, bool) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return v, false
+		}
+		return v.In(loc), true
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return time.Time{}, false
+		}
+		return v.In(loc), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Table is a small, fully built report that can be exported as a whole.
+type Table interface {
+	ExportColumns() []Column
+	ExportRows() [][]interface{}
+}
+
+func WriteTable(format string, w io.Writer, table Table) error {
+	writer, err := NewWriter(format, w, table.ExportColumns())
+	if err != nil {
+		return err
+	}
+
+	for _, row := range table.ExportRows() {
+		if err := writer.Write(row...); err != nil {
+			writer.Abort()
+			return err
+		}
+	}
+
+	return writer.Close()
+}
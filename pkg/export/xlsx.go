@@ -0,0 +1,106 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const sheetName = "Sheet1"
+
+// xlsxWriter uses the excelize stream writer, which spills rows to a
+// temporary file once its buffer fills, so large exports do not stay in
+// memory. The workbook is written to w on Close.
+type xlsxWriter struct {
+	w       io.Writer
+	file    *excelize.File
+	stream  *excelize.StreamWriter
+	columns []Column
+	styles  map[Kind]int
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, columns []Column) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	x := &xlsxWriter{w: w, file: file, stream: stream, columns: columns, styles: map[Kind]int{}}
+
+	moneyFormat := "#,##0.00"
+	dateFormat := "yyyy-mm-dd hh:mm:ss"
+	ratioFormat := "0.00%"
+	for kind, format := range map[Kind]*string{Money: &moneyFormat, Date: &dateFormat, Ratio: &ratioFormat} {
+		style, err := file.NewStyle(&excelize.Style{CustomNumFmt: format})
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		x.styles[kind] = style
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	if err := x.writeRow(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return x, nil
+}
+
+func (x *xlsxWriter) Write(values ...interface{}) error {
+	if len(values) != len(x.columns) {
+		return fmt.Errorf("export row has %d values for %d columns", len(values), len(x.columns))
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, column := range x.columns {
+		switch column.Kind {
+		case Money, Ratio:
+			cells[i] = excelize.Cell{StyleID: x.styles[column.Kind], Value: values[i]}
+		case Date:
+			// Excel has no time zones, so the Jakarta wall time is written as is.
+			if t, ok := dateValue(values[i]); ok {
+				cells[i] = excelize.Cell{StyleID: x.styles[Date], Value: t}
+			} else {
+				cells[i] = nil
+			}
+		default:
+			cells[i] = values[i]
+		}
+	}
+
+	return x.writeRow(cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	_, err := x.file.WriteTo(x.w)
+	return err
+}
+
+func (x *xlsxWriter) Abort() {
+	x.file.Close()
+}
+
+func (x *xlsxWriter) writeRow(cells []interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, cells)
+}
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"final-project-backend/config"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	ChannelVirtualAccount = "virtual_account"
+	ChannelPaymentCode    = "payment_code"
+
+	StatusPaid    = "paid"
+	StatusExpired = "expired"
+	StatusFailed  = "failed"
+
+	SignatureHeader = "X-Callback-Signature"
+	TimestampHeader = "X-Callback-Timestamp"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid callback signature")
+	ErrExpiredTimestamp = errors.New("callback timestamp outside tolerance")
+)
+
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	VerifyCallback(header http.Header, payload []byte) error
+}
+
+type ChargeRequest struct {
+	ReferenceID  string
+	Channel      string
+	CustomerName string
+	Amount       float64
+	ExpireDate   time.Time
+}
+
+type Charge struct {
+	ExternalID    string
+	Channel       string
+	AccountNumber string
+	ExpireDate    time.Time
+}
+
+func NewProvider(cfg *config.Config) (Provider, error) {
+	switch cfg.Gateway.Provider {
+	case "", "fake":
+		return NewFakeProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment gateway provider: %s", cfg.Gateway.Provider)
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>".
+// Binding the timestamp into the signature stops an attacker from replaying
+// an old body with a fresh timestamp header.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(secret string, tolerance time.Duration, header http.Header, payload []byte, now time.Time) error {
+	timestamp := header.Get(TimestampHeader)
+	signature := header.Get(SignatureHeader)
+	if timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	diff := now.Sub(time.Unix(unix, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if diff > tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
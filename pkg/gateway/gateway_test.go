@@ -0,0 +1,127 @@
+package gateway_test
+
+import (
+	"final-project-backend/config"
+	"final-project-backend/pkg/gateway"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const secret = "callbacksecret"
+
+func signedHeader(secret string, at time.Time, payload []byte) http.Header {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	header := http.Header{}
+	header.Set(gateway.TimestampHeader, timestamp)
+	header.Set(gateway.SignatureHeader, gateway.Sign(secret, timestamp, payload))
+	return header
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	payload := []byte(`{"event_id":"evt-1","status":"paid"}`)
+	tolerance := 5 * time.Minute
+
+	tests := []struct {
+		name    string
+		header  func() http.Header
+		payload []byte
+		want    error
+	}{
+		{
+			name:    "valid signature",
+			header:  func() http.Header { return signedHeader(secret, now, payload) },
+			payload: payload,
+		},
+		{
+			name:    "timestamp at the edge of the tolerance",
+			header:  func() http.Header { return signedHeader(secret, now.Add(-tolerance), payload) },
+			payload: payload,
+		},
+		{
+			name:    "timestamp slightly in the future",
+			header:  func() http.Header { return signedHeader(secret, now.Add(time.Minute), payload) },
+			payload: payload,
+		},
+		{
+			name:    "signed with another secret",
+			header:  func() http.Header { return signedHeader("othersecret", now, payload) },
+			payload: payload,
+			want:    gateway.ErrInvalidSignature,
+		},
+		{
+			name:    "payload changed after signing",
+			header:  func() http.Header { return signedHeader(secret, now, payload) },
+			payload: []byte(`{"event_id":"evt-1","status":"expired"}`),
+			want:    gateway.ErrInvalidSignature,
+		},
+		{
+			name: "timestamp replaced after signing",
+			header: func() http.Header {
+				header := signedHeader(secret, now.Add(-time.Hour), payload)
+				header.Set(gateway.TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+				return header
+			},
+			payload: payload,
+			want:    gateway.ErrInvalidSignature,
+		},
+		{
+			name:    "stale timestamp",
+			header:  func() http.Header { return signedHeader(secret, now.Add(-tolerance-time.Second), payload) },
+			payload: payload,
+			want:    gateway.ErrExpiredTimestamp,
+		},
+		{
+			name:    "timestamp too far in the future",
+			header:  func() http.Header { return signedHeader(secret, now.Add(tolerance+time.Second), payload) },
+			payload: payload,
+			want:    gateway.ErrExpiredTimestamp,
+		},
+		{
+			name: "timestamp not a number",
+			header: func() http.Header {
+				header := signedHeader(secret, now, payload)
+				header.Set(gateway.TimestampHeader, "yesterday")
+				return header
+			},
+			payload: payload,
+			want:    gateway.ErrInvalidSignature,
+		},
+		{
+			name: "missing signature",
+			header: func() http.Header {
+				header := signedHeader(secret, now, payload)
+				header.Del(gateway.SignatureHeader)
+				return header
+			},
+			payload: payload,
+			want:    gateway.ErrInvalidSignature,
+		},
+		{
+			name:    "missing headers",
+			header:  func() http.Header { return http.Header{} },
+			payload: payload,
+			want:    gateway.ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gateway.VerifySignature(secret, tolerance, tt.header(), tt.payload, now)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestFakeProviderVerifiesOwnCallbacks(t *testing.T) {
+	cfg := &config.Config{Gateway: config.GatewayConfig{CallbackSecret: secret, CallbackTolerance: 300}}
+	provider := gateway.NewFakeProvider(cfg)
+	payload := []byte(`{"event_id":"evt-1","status":"paid"}`)
+
+	assert.NoError(t, provider.VerifyCallback(provider.SignCallback(payload, time.Now()), payload))
+	assert.Equal(t, gateway.ErrExpiredTimestamp, provider.VerifyCallback(provider.SignCallback(payload, time.Now().Add(-time.Hour)), payload))
+	assert.Equal(t, gateway.ErrInvalidSignature, provider.VerifyCallback(provider.SignCallback(payload, time.Now()), []byte(`{}`)))
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const startKey = "metrics:start"
+
+// RegisterDB times every statement run through db and exports the stats of
+// its connection pool.
+func RegisterDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := Registry.Register(collectors.NewDBStatsCollector(sqlDB, "postgres")); err != nil {
+		return err
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("*").Register("metrics:before_create", before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("*").Register("metrics:after_create", after("create")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("*").Register("metrics:before_query", before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("*").Register("metrics:after_query", after("query")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("*").Register("metrics:before_update", before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("*").Register("metrics:after_update", after("update")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("*").Register("metrics:before_delete", before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("*").Register("metrics:after_delete", after("delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("*").Register("metrics:before_row", before); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("*").Register("metrics:after_row", after("row")); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("*").Register("metrics:before_raw", before); err != nil {
+		return err
+	}
+
+	return callbacks.Raw().After("*").Register("metrics:after_raw", after("raw"))
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "none"
+		}
+
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
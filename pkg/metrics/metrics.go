@@ -0,0 +1,119 @@
+// Package metrics holds the Prometheus collectors of the api: request and
+// query durations, connection pool stats and counters of business events.
+// Everything is registered on Registry, which the server exposes on the
+// configured metrics path.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of gorm statements by operation and table.",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	loansCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "loans_created_total",
+		Help: "Loans requested by debtors.",
+	})
+
+	loansApproved = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "loans_approved_total",
+		Help: "Loans approved by an admin.",
+	})
+
+	loansRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "loans_rejected_total",
+		Help: "Loans rejected by an admin.",
+	})
+
+	paymentsSettled = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "payments_settled_total",
+		Help: "Installment payments settled.",
+	})
+
+	paymentsCollected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "payments_collected_amount_total",
+		Help: "Amount collected by settled payments, in rupiah.",
+	})
+
+	finesCollected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "fines_collected_amount_total",
+		Help: "Late fines collected by settled payments after waivers, in rupiah.",
+	})
+
+	vouchersRedeemed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vouchers_redeemed_total",
+		Help: "Vouchers redeemed by settled payments.",
+	})
+
+	loginFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "login_failures_total",
+		Help: "Logins refused for an unknown email or a wrong password.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		dbQueryDuration,
+		loansCreated,
+		loansApproved,
+		loansRejected,
+		paymentsSettled,
+		paymentsCollected,
+		finesCollected,
+		vouchersRedeemed,
+		loginFailures,
+	)
+}
+
+// LoanCreated counts a loan requested by a debtor.
+func LoanCreated() {
+	loansCreated.Inc()
+}
+
+// LoanApproved counts a loan approved by an admin.
+func LoanApproved() {
+	loansApproved.Inc()
+}
+
+// LoanRejected counts a loan rejected by an admin.
+func LoanRejected() {
+	loansRejected.Inc()
+}
+
+// LoginFailed counts a login refused for an unknown email or a wrong
+// password.
+func LoginFailed() {
+	loginFailures.Inc()
+}
+
+// PaymentSettled counts a payment once the transaction settling it has
+// committed. Fine is what the debtor paid for being late after any waiver.
+func PaymentSettled(amount, fine float64, voucherRedeemed bool) {
+	paymentsSettled.Inc()
+	paymentsCollected.Add(amount)
+
+	if fine > 0 {
+		finesCollected.Add(fine)
+	}
+
+	if voucherRedeemed {
+		vouchersRedeemed.Inc()
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// delta returns how much counter moved while fn ran. Counters are global, so
+// tests compare against the value before rather than zero.
+func delta(counter prometheus.Counter, fn func()) float64 {
+	before := testutil.ToFloat64(counter)
+	fn()
+	return testutil.ToFloat64(counter) - before
+}
+
+func TestEventCounters(t *testing.T) {
+	tests := []struct {
+		name    string
+		counter prometheus.Counter
+		count   func()
+	}{
+		{name: "loan created", counter: loansCreated, count: LoanCreated},
+		{name: "loan approved", counter: loansApproved, count: LoanApproved},
+		{name: "loan rejected", counter: loansRejected, count: LoanRejected},
+		{name: "login failed", counter: loginFailures, count: LoginFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, 2.0, delta(tt.counter, func() {
+				tt.count()
+				tt.count()
+			}))
+		})
+	}
+}
+
+func TestPaymentSettled(t *testing.T) {
+	tests := []struct {
+		name            string
+		amount          float64
+		fine            float64
+		voucherRedeemed bool
+		wantFine        float64
+		wantVouchers    float64
+	}{
+		{name: "on time without voucher", amount: 1005000},
+		{name: "late", amount: 1025000, fine: 20000, wantFine: 20000},
+		{name: "fine fully waived", amount: 1005000},
+		{name: "with voucher", amount: 955000, voucherRedeemed: true, wantVouchers: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settled := testutil.ToFloat64(paymentsSettled)
+			collected := testutil.ToFloat64(paymentsCollected)
+			fines := testutil.ToFloat64(finesCollected)
+			vouchers := testutil.ToFloat64(vouchersRedeemed)
+
+			PaymentSettled(tt.amount, tt.fine, tt.voucherRedeemed)
+
+			assert.Equal(t, 1.0, testutil.ToFloat64(paymentsSettled)-settled)
+			assert.Equal(t, tt.amount, testutil.ToFloat64(paymentsCollected)-collected)
+			assert.Equal(t, tt.wantFine, testutil.ToFloat64(finesCollected)-fines)
+			assert.Equal(t, tt.wantVouchers, testutil.ToFloat64(vouchersRedeemed)-vouchers)
+		})
+	}
+}
+
+func TestMiddlewareLabelsRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	httpRequestDuration.Reset()
+
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/loans/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/loans/1", "/loans/2", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2, testutil.CollectAndCount(httpRequestDuration))
+	assert.Equal(t, uint64(2), histogramCount(t, "GET", "/loans/:id", "200"))
+	assert.Equal(t, uint64(1), histogramCount(t, "GET", "unmatched", "404"))
+}
+
+func histogramCount(t *testing.T, labels ...string) uint64 {
+	t.Helper()
+
+	observer, err := httpRequestDuration.GetMetricWithLabelValues(labels...)
+	assert.NoError(t, err)
+
+	var metric dto.Metric
+	assert.NoError(t, observer.(prometheus.Metric).Write(&metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/metrics", Handler("secret"))
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing token", want: http.StatusForbidden},
+		{name: "wrong token", header: "Bearer other", want: http.StatusForbidden},
+		{name: "valid token", header: "Bearer secret", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.header != "" {
+				request.Header.Set("Authorization", tt.header)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.want, recorder.Code)
+			if tt.want == http.StatusOK {
+				assert.Contains(t, recorder.Body.String(), "loans_created_total")
+			}
+		})
+	}
+}
@@ -0,0 +1,445 @@
+// Package migrate applies numbered up and down SQL migrations and records
+// them in a schema_migrations table. Every run holds a Postgres advisory
+// lock, so instances started together apply each migration once, and
+// refuses to run when an applied up file has since been edited.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockKey identifies the advisory lock shared by every instance.
+const lockKey = 461752310
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var (
+	ErrUnknownVersion   = errors.New("unknown migration version")
+	ErrChecksumMismatch = errors.New("migration changed after it was applied")
+)
+
+// Migration is one numbered pair of files. Checksum is the sha256 of the up
+// file; down files may still be fixed after they ship.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status is a migration as the database sees it. AppliedAt is nil while the
+// migration is pending. Migrations applied by a newer binary have no Up or
+// Down.
+type Status struct {
+	Migration *Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads the migrations at the root of fsys in version order. Every
+// version needs both an up and a down file.
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migration %s: invalid version", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d: names %s and %s do not match", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Latest is the highest version this binary knows, or 0 without migrations.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var reverted []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.verify(ctx, conn); err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := appliedVersions(applied)
+		for i := len(versions) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration, err := m.find(versions[i])
+			if err != nil {
+				return err
+			}
+
+			if err := m.run(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// To applies pending migrations up to version and reverts applied ones
+// above it, so the database ends at exactly that version. Version 0 reverts
+// everything.
+func (m *Migrator) To(ctx context.Context, version int64) ([]*Migration, error) {
+	if version != 0 {
+		if _, err := m.find(version); err != nil {
+			return nil, err
+		}
+	}
+
+	var ran []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		if err := m.verify(ctx, conn); err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := appliedVersions(applied)
+		for i := len(versions) - 1; i >= 0 && versions[i] > version; i-- {
+			migration, err := m.find(versions[i])
+			if err != nil {
+				return err
+			}
+
+			if err := m.run(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			ran = append(ran, migration)
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+
+			if err := m.run(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			ran = append(ran, migration)
+		}
+
+		return nil
+	})
+
+	return ran, err
+}
+
+// Baseline records every migration up to version as applied without running
+// it, for databases whose schema was created before migrations existed.
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]*Migration, error) {
+	if _, err := m.find(version); err != nil {
+		return nil, err
+	}
+
+	var recorded []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+
+			if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+				migration.Version, migration.Name, migration.Checksum); err != nil {
+				return err
+			}
+			recorded = append(recorded, migration)
+		}
+
+		return nil
+	})
+
+	return recorded, err
+}
+
+// Status lists every known migration and any applied by a newer binary, in
+// version order.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := &Status{Migration: migration}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, version := range appliedVersions(applied) {
+			if _, err := m.find(version); err == nil {
+				continue
+			}
+
+			appliedAt := applied[version]
+			statuses = append(statuses, &Status{Migration: &Migration{Version: version}, AppliedAt: &appliedAt})
+		}
+
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Migration.Version < statuses[j].Migration.Version
+		})
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Pending lists the known migrations the database has not applied. It reads
+// without taking the lock, so health checks never wait on a running
+// migration.
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+func (m *Migrator) find(version int64) (*Migration, error) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+}
+
+// withLock runs fn on a single connection holding the advisory lock. The
+// lock belongs to the session, so every statement must use that connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations
+		(
+		    version    bigint PRIMARY KEY NOT NULL,
+		    name       VARCHAR            NOT NULL,
+		    applied_at timestamptz        NOT NULL DEFAULT (NOW()),
+		    checksum   VARCHAR
+		)`); err != nil {
+		return err
+	}
+
+	// Tables created before checksums were recorded gain the column here.
+	if _, err := conn.ExecContext(ctx, "ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR"); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// verify compares the checksum recorded for every applied migration with the
+// file this binary carries. Rows recorded before checksums existed, and
+// migrations this binary does not know, are skipped.
+func (m *Migrator) verify(ctx context.Context, conn *sql.Conn) error {
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum FROM schema_migrations WHERE checksum IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return err
+		}
+
+		migration, err := m.find(version)
+		if err != nil {
+			continue
+		}
+		if migration.Checksum != checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	return rows.Err()
+}
+
+// run applies or reverts one migration and its schema_migrations row in a
+// single transaction.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, migration *Migration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	script := migration.Down
+	if up {
+		script = migration.Up
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func appliedVersions(applied map[int64]time.Time) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+
+	return versions
+}
@@ -0,0 +1,279 @@
+//go:build integration
+
+package migrate_test
+
+import (
+	"context"
+	"database/sql"
+	"final-project-backend/pkg/migrate"
+	"final-project-backend/pkg/postgres/postgrestest"
+	"final-project-backend/sql/migrations"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// lockKey is the advisory lock key the migrator takes.
+const lockKey = 461752310
+
+var testDB *sql.DB
+
+func TestMain(m *testing.M) {
+	db, stop, err := postgrestest.OpenEmpty()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	testDB = db
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func widgets() fstest.MapFS {
+	return fstest.MapFS{
+		"1_add_widgets.up.sql":    file("CREATE TABLE widgets (id int PRIMARY KEY);"),
+		"1_add_widgets.down.sql":  file("DROP TABLE widgets;"),
+		"2_seed_widgets.up.sql":   file("INSERT INTO widgets (id) VALUES (1), (2);"),
+		"2_seed_widgets.down.sql": file("DELETE FROM widgets;"),
+		"10_add_gadgets.up.sql":   file("CREATE TABLE gadgets (widget_id int REFERENCES widgets (id));"),
+		"10_add_gadgets.down.sql": file("DROP TABLE gadgets;"),
+	}
+}
+
+func reset(t *testing.T) {
+	t.Helper()
+
+	_, err := testDB.Exec("DROP TABLE IF EXISTS gadgets, widgets, schema_migrations")
+	require.NoError(t, err)
+}
+
+func newMigrator(t *testing.T, fsys fstest.MapFS) *migrate.Migrator {
+	t.Helper()
+
+	migrator, err := migrate.New(testDB, fsys)
+	require.NoError(t, err)
+	return migrator
+}
+
+func versions(ran []*migrate.Migration) []int64 {
+	result := make([]int64, len(ran))
+	for i, migration := range ran {
+		result[i] = migration.Version
+	}
+	return result
+}
+
+func TestMigratorOrder(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	migrator := newMigrator(t, widgets())
+
+	ran, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 10}, versions(ran))
+
+	ran, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ran)
+
+	ran, err = migrator.To(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 2}, versions(ran))
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 10}, versions(pending))
+
+	ran, err = migrator.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, versions(ran))
+
+	var count int
+	require.NoError(t, testDB.QueryRow("SELECT count(*) FROM schema_migrations").Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestMigratorFailedMigrationRollsBack(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	fsys := widgets()
+	fsys["10_add_gadgets.up.sql"] = file("CREATE TABLE gadgets (id int); SELECT * FROM missing_table;")
+	migrator := newMigrator(t, fsys)
+
+	ran, err := migrator.Up(ctx)
+	assert.ErrorContains(t, err, "migration 10_add_gadgets")
+	assert.Equal(t, []int64{1, 2}, versions(ran))
+
+	var gadgets sql.NullString
+	require.NoError(t, testDB.QueryRow("SELECT to_regclass('gadgets')::text").Scan(&gadgets))
+	assert.False(t, gadgets.Valid)
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, versions(pending))
+}
+
+func TestMigratorChecksum(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+
+	_, err := newMigrator(t, widgets()).Up(ctx)
+	require.NoError(t, err)
+
+	var checksum string
+	require.NoError(t, testDB.QueryRow("SELECT checksum FROM schema_migrations WHERE version = 2").Scan(&checksum))
+	assert.Len(t, checksum, 64)
+
+	edited := widgets()
+	edited["2_seed_widgets.up.sql"] = file("INSERT INTO widgets (id) VALUES (1), (2), (3);")
+	migrator := newMigrator(t, edited)
+
+	_, err = migrator.Up(ctx)
+	assert.ErrorIs(t, err, migrate.ErrChecksumMismatch)
+	_, err = migrator.Down(ctx, 1)
+	assert.ErrorIs(t, err, migrate.ErrChecksumMismatch)
+
+	t.Run("down file may change", func(t *testing.T) {
+		fixed := widgets()
+		fixed["2_seed_widgets.down.sql"] = file("DELETE FROM widgets WHERE id <= 2;")
+
+		_, err := newMigrator(t, fixed).Up(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rows without a checksum are not checked", func(t *testing.T) {
+		_, err := testDB.Exec("UPDATE schema_migrations SET checksum = NULL WHERE version = 2")
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMigratorChecksumColumnAdded(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+
+	// schema_migrations as it was created before checksums were recorded.
+	_, err := testDB.Exec(`CREATE TABLE schema_migrations
+		(
+		    version    bigint PRIMARY KEY NOT NULL,
+		    name       VARCHAR            NOT NULL,
+		    applied_at timestamptz        NOT NULL DEFAULT (NOW())
+		)`)
+	require.NoError(t, err)
+	_, err = testDB.Exec("CREATE TABLE widgets (id int PRIMARY KEY); INSERT INTO schema_migrations (version, name) VALUES (1, 'add_widgets')")
+	require.NoError(t, err)
+
+	ran, err := newMigrator(t, widgets()).Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 10}, versions(ran))
+
+	var missing int
+	require.NoError(t, testDB.QueryRow("SELECT count(*) FROM schema_migrations WHERE checksum IS NULL").Scan(&missing))
+	assert.Equal(t, 1, missing)
+}
+
+func TestMigratorBaselineRecordsChecksum(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	migrator := newMigrator(t, widgets())
+
+	recorded, err := migrator.Baseline(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, versions(recorded))
+
+	var missing int
+	require.NoError(t, testDB.QueryRow("SELECT count(*) FROM schema_migrations WHERE checksum IS NULL").Scan(&missing))
+	assert.Zero(t, missing)
+}
+
+func TestMigratorWaitsForLock(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+
+	conn, err := testDB.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey)
+	require.NoError(t, err)
+
+	migrator := newMigrator(t, widgets())
+	done := make(chan error, 1)
+	go func() {
+		_, err := migrator.Up(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("migrated while another session held the lock: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+	require.NoError(t, err)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("migrator did not run after the lock was released")
+	}
+}
+
+func TestMigratorConcurrentUp(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var ran []*migrate.Migration
+	for i := 0; i < 4; i++ {
+		migrator := newMigrator(t, widgets())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied, err := migrator.Up(ctx)
+			assert.NoError(t, err)
+
+			mu.Lock()
+			ran = append(ran, applied...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, ran, 3)
+
+	var count int
+	require.NoError(t, testDB.QueryRow("SELECT count(*) FROM widgets").Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func TestEmbeddedMigrationsRoundTrip(t *testing.T) {
+	reset(t)
+	ctx := context.Background()
+	migrator, err := migrate.New(testDB, migrations.FS)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	_, err = migrator.To(ctx, 0)
+	require.NoError(t, err)
+
+	ran, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, ran, int(migrator.Latest()))
+
+	_, err = migrator.To(ctx, 0)
+	require.NoError(t, err)
+}
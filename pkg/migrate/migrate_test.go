@@ -0,0 +1,101 @@
+package migrate_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"final-project-backend/pkg/migrate"
+	"final-project-backend/sql/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"10_add_gadgets.up.sql":     file("CREATE TABLE gadgets (id int);"),
+		"10_add_gadgets.down.sql":   file("DROP TABLE gadgets;"),
+		"2_add_widgets.up.sql":      file("CREATE TABLE widgets (id int);"),
+		"2_add_widgets.down.sql":    file("DROP TABLE widgets;"),
+		"1_create_schema.down.sql":  file("DROP SCHEMA app;"),
+		"1_create_schema.up.sql":    file("CREATE SCHEMA app;"),
+		"README.md":                 file("not a migration"),
+		"3_add_widgets.up.sql.orig": file("not a migration either"),
+	}
+
+	loaded, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, loaded, 3)
+
+	assert.Equal(t, []int64{1, 2, 10}, []int64{loaded[0].Version, loaded[1].Version, loaded[2].Version})
+	assert.Equal(t, "add_gadgets", loaded[2].Name)
+	assert.Equal(t, "CREATE TABLE gadgets (id int);", loaded[2].Up)
+	assert.Equal(t, "DROP TABLE gadgets;", loaded[2].Down)
+
+	sum := sha256.Sum256([]byte("CREATE TABLE gadgets (id int);"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), loaded[2].Checksum)
+}
+
+func TestLoadChecksumIgnoresDown(t *testing.T) {
+	before, err := migrate.Load(fstest.MapFS{
+		"1_add_widgets.up.sql":   file("CREATE TABLE widgets (id int);"),
+		"1_add_widgets.down.sql": file("DROP TABLE widgets;"),
+	})
+	require.NoError(t, err)
+
+	after, err := migrate.Load(fstest.MapFS{
+		"1_add_widgets.up.sql":   file("CREATE TABLE widgets (id int);"),
+		"1_add_widgets.down.sql": file("DROP TABLE IF EXISTS widgets;"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, before[0].Checksum, after[0].Checksum)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing down file",
+			fsys: fstest.MapFS{"1_add_widgets.up.sql": file("CREATE TABLE widgets (id int);")},
+			want: "migration 1_add_widgets: needs both an up and a down file",
+		},
+		{
+			name: "names do not match",
+			fsys: fstest.MapFS{
+				"1_add_widgets.up.sql":   file("CREATE TABLE widgets (id int);"),
+				"1_add_gadgets.down.sql": file("DROP TABLE widgets;"),
+			},
+			want: "migration 1: names add_gadgets and add_widgets do not match",
+		},
+		{
+			name: "version zero",
+			fsys: fstest.MapFS{"0_add_widgets.up.sql": file("CREATE TABLE widgets (id int);")},
+			want: "migration 0_add_widgets.up.sql: invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migrate.Load(tt.fsys)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	loaded, err := migrate.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for i, migration := range loaded {
+		assert.Equal(t, int64(i+1), migration.Version, "migration %s is out of sequence", migration.Name)
+	}
+}
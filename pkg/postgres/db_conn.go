@@ -36,5 +36,9 @@ func NewGormDB(c *config.Config) (*gorm.DB, error) {
 		return db, err
 	}
 
+	if err := RegisterTranslateError(db); err != nil {
+		return db, err
+	}
+
 	return db, nil
 }
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"errors"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+const (
+	notNullViolation    = "23502"
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	checkViolation      = "23514"
+)
+
+// constraintMessages names the constraints a client can run into with a
+// message of its own. Every other violation gets a generic message.
+var constraintMessages = map[string]string{
+	"users_email_key":               response.EmailAlreadyExistMessage,
+	"voucher_codes_code_key":        response.VoucherCodeExist,
+	"rejection_reasons_code_key":    response.RejectionReasonCodeExist,
+	"loan_products_amount_check":    response.LoanProductAmountNotValid,
+	"loan_products_date_check":      response.ExpireDateBeforeActiveDate,
+	"vouchers_date_check":           response.ExpireDateBeforeActiveDate,
+	"vouchers_discount_quota_check": response.VoucherQuotaNotValid,
+}
+
+// TranslateError turns a constraint violation into an httperror, so a request
+// the database refuses is answered as a bad request rather than a server
+// error. Other errors are returned unchanged.
+func TranslateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	message, ok := constraintMessages[pgErr.ConstraintName]
+	switch pgErr.Code {
+	case uniqueViolation:
+		if !ok {
+			message = response.DataAlreadyExist
+		}
+	case foreignKeyViolation:
+		if !ok {
+			message = response.ReferencedDataNotExist
+		}
+	case checkViolation, notNullViolation:
+		if !ok {
+			message = response.DataConstraintViolation
+		}
+	default:
+		return err
+	}
+
+	return httperror.New(http.StatusBadRequest, message)
+}
+
+// RegisterTranslateError runs TranslateError on the result of every create,
+// update, delete and raw statement run through db.
+func RegisterTranslateError(db *gorm.DB) error {
+	translate := func(db *gorm.DB) {
+		if db.Error != nil {
+			db.Error = TranslateError(db.Error)
+		}
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Register("postgres:translate_error", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Register("postgres:translate_error", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Register("postgres:translate_error", translate); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Register("postgres:translate_error", translate); err != nil {
+		return err
+	}
+
+	return callbacks.Row().Register("postgres:translate_error", translate)
+}
@@ -0,0 +1,68 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/models/modelstest"
+	"final-project-backend/pkg/postgres/postgrestest"
+	"final-project-backend/pkg/response"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+)
+
+var testDB *gorm.DB
+
+func TestMain(m *testing.M) {
+	db, stop, err := postgrestest.Open()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	testDB = db
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func TestRegisterTranslateError(t *testing.T) {
+	require.NoError(t, postgrestest.Reset(testDB))
+	user := modelstest.CreateUser(t, testDB)
+
+	t.Run("create", func(t *testing.T) {
+		duplicate := *user
+		duplicate.UserID = uuid.New()
+		assert.EqualError(t, testDB.Create(&duplicate).Error, response.EmailAlreadyExistMessage)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		debtor := modelstest.CreateDebtor(t, testDB, func(d *models.Debtor) { d.UserID = user.UserID })
+		err := testDB.Model(debtor).Update("credit_used", -1).Error
+		assert.EqualError(t, err, response.DataConstraintViolation)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := testDB.Where("role_id = ?", 2).Delete(&models.Role{}).Error
+		assert.EqualError(t, err, response.ReferencedDataNotExist)
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		err := testDB.Exec("INSERT INTO roles (role_id, name) VALUES (1, 'admin')").Error
+		assert.EqualError(t, err, response.DataAlreadyExist)
+	})
+
+	t.Run("other errors pass through", func(t *testing.T) {
+		err := testDB.Exec("SELECT * FROM no_such_table").Error
+
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		assert.Equal(t, "42P01", pgErr.Code)
+	})
+}
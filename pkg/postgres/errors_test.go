@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"errors"
+	"final-project-backend/pkg/httperror"
+	"final-project-backend/pkg/response"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{
+			name:    "named unique violation",
+			err:     &pgconn.PgError{Code: uniqueViolation, ConstraintName: "users_email_key"},
+			message: response.EmailAlreadyExistMessage,
+		},
+		{
+			name:    "unique violation",
+			err:     &pgconn.PgError{Code: uniqueViolation, ConstraintName: "write_offs_lending_id_key"},
+			message: response.DataAlreadyExist,
+		},
+		{
+			name:    "foreign key violation",
+			err:     &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: "lendings_debtor_id_fkey"},
+			message: response.ReferencedDataNotExist,
+		},
+		{
+			name:    "named check violation",
+			err:     &pgconn.PgError{Code: checkViolation, ConstraintName: "loan_products_amount_check"},
+			message: response.LoanProductAmountNotValid,
+		},
+		{
+			name:    "check violation",
+			err:     &pgconn.PgError{Code: checkViolation, ConstraintName: "debtors_credit_used_check"},
+			message: response.DataConstraintViolation,
+		},
+		{
+			name:    "not null violation",
+			err:     &pgconn.PgError{Code: notNullViolation},
+			message: response.DataConstraintViolation,
+		},
+		{
+			name:    "wrapped violation",
+			err:     fmt.Errorf("create: %w", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "voucher_codes_code_key"}),
+			message: response.VoucherCodeExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TranslateError(tt.err)
+
+			var httpErr *httperror.Error
+			if assert.True(t, errors.As(err, &httpErr)) {
+				assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+				assert.EqualError(t, err, tt.message)
+			}
+		})
+	}
+}
+
+func TestTranslateErrorLeavesOtherErrors(t *testing.T) {
+	errSyntax := &pgconn.PgError{Code: "42601"}
+
+	for _, err := range []error{nil, gorm.ErrRecordNotFound, errSyntax} {
+		assert.Equal(t, err, TranslateError(err))
+	}
+}
@@ -0,0 +1,239 @@
+//go:build integration
+
+// Package postgrestest gives integration tests a disposable Postgres database
+// with every migration applied. It uses the server at TEST_POSTGRES_DSN when
+// set, and otherwise starts a throwaway cluster with the initdb and pg_ctl
+// binaries found in PG_BIN, on PATH or under /usr/lib/postgresql.
+package postgrestest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"final-project-backend/pkg/migrate"
+	"final-project-backend/pkg/postgres"
+	"final-project-backend/sql/migrations"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Open creates an empty database, migrates it to the latest version and
+// returns it with a function that drops it again.
+func Open() (*gorm.DB, func(), error) {
+	sqlDB, stop, err := OpenEmpty()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := open(sqlDB)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+
+	return db, stop, nil
+}
+
+// OpenEmpty creates a database without running any migration, for tests of
+// the migrator itself, and returns it with a function that drops it again.
+func OpenEmpty() (*sql.DB, func(), error) {
+	server, stopServer, err := startServer()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adminDB := stdlib.OpenDB(*server)
+	name := "test_" + randomHex(8)
+	if _, err := adminDB.Exec(fmt.Sprintf(`CREATE DATABASE "%s"`, name)); err != nil {
+		adminDB.Close()
+		stopServer()
+		return nil, nil, err
+	}
+
+	config := server.Copy()
+	config.Database = name
+	sqlDB := stdlib.OpenDB(*config)
+
+	stop := func() {
+		sqlDB.Close()
+		adminDB.Exec(fmt.Sprintf(`DROP DATABASE IF EXISTS "%s"`, name))
+		adminDB.Close()
+		stopServer()
+	}
+
+	return sqlDB, stop, nil
+}
+
+func open(sqlDB *sql.DB) (*gorm.DB, error) {
+	migrator, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(driver.New(driver.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postgres.RegisterTranslateError(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Reset empties every table and loads the seed migrations again, so each
+// test starts from a freshly migrated database.
+func Reset(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	var tables []string
+	if err := db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename <> 'schema_migrations'").
+		Scan(&tables).Error; err != nil {
+		return err
+	}
+
+	for i, table := range tables {
+		tables[i] = fmt.Sprintf(`"%s"`, table)
+	}
+	if _, err := sqlDB.Exec(fmt.Sprintf("TRUNCATE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))); err != nil {
+		return err
+	}
+
+	all, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range all {
+		if !strings.HasPrefix(migration.Name, "seed_") {
+			continue
+		}
+
+		if _, err := sqlDB.Exec(migration.Up); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// startServer returns the connection config of a server the tests may create
+// databases on, and a function that stops it if it was started here.
+func startServer() (*pgx.ConnConfig, func(), error) {
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		config, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return config, func() {}, nil
+	}
+
+	initdb, err := findBinary("initdb")
+	if err != nil {
+		return nil, nil, err
+	}
+	pgCtl, err := findBinary("pg_ctl")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "postgrestest")
+	if err != nil {
+		return nil, nil, err
+	}
+	data := filepath.Join(dir, "data")
+
+	if output, err := exec.Command(initdb, "-D", data, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync").
+		CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, fmt.Errorf("initdb: %w: %s", err, output)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, err
+	}
+
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -F", port, dir)
+	if output, err := exec.Command(pgCtl, "-D", data, "-o", options, "-l", filepath.Join(dir, "postgres.log"), "-w", "start").
+		CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, fmt.Errorf("pg_ctl start: %w: %s", err, output)
+	}
+
+	stop := func() {
+		exec.Command(pgCtl, "-D", data, "-m", "immediate", "-w", "stop").Run()
+		os.RemoveAll(dir)
+	}
+
+	config, err := pgx.ParseConfig(fmt.Sprintf("host=127.0.0.1 port=%d user=postgres dbname=postgres sslmode=disable connect_timeout=10", port))
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+
+	return config, stop, nil
+}
+
+func findBinary(name string) (string, error) {
+	if dir := os.Getenv("PG_BIN"); dir != "" {
+		return filepath.Join(dir, name), nil
+	}
+
+	if path, err := exec.LookPath(name); err == nil {
+		return path, nil
+	}
+
+	// Debian and Ubuntu keep the server binaries out of PATH.
+	matches, _ := filepath.Glob(filepath.Join("/usr/lib/postgresql", "*", "bin", name))
+	if len(matches) > 0 {
+		sort.Strings(matches)
+		return matches[len(matches)-1], nil
+	}
+
+	return "", errors.New("postgrestest: set TEST_POSTGRES_DSN or install the Postgres server binaries (" + name + " not found)")
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprint(time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -12,6 +12,7 @@ const (
 	NotFoundMessage            = "Route does not exist, please check again your route path."
 	UnauthorizedMessage        = "Email or password not valid."
 	ForbiddenMessage           = "Forbidden"
+	NotReadyMessage            = "Service not ready."
 
 	EmailAlreadyExistMessage           = "Email already exist."
 	DebtorIDNotExist                   = "Debtor ID not exist."
@@ -22,14 +23,77 @@ const (
 	ContractNotAccepted                = "Contract not accepted."
 	ContractNotConfirmed               = "Contract not confirmed."
 	ContractAlreadyAccepted            = "Contract already accepted."
-	LoanPeriodNotExist                 = "Loan period ID not exist."
+	LoanProductNotExist                = "Loan product ID not exist."
+	LoanProductNotActive               = "Loan product not active."
+	LoanProductNotEligible             = "Loan product not available for credit health status."
+	LoanAmountOutOfRange               = "Loan amount outside the product range."
 	InstallmentNotExist                = "Installment ID not exist."
 	VoucherNotExist                    = "Voucher ID not exist."
+	VoucherCodeNotExist                = "Voucher code not exist."
+	VoucherCodeExist                   = "Voucher code already exist."
+	VoucherCodeRedeemed                = "Voucher code already redeemed."
+	VoucherCodeRequired                = "Voucher can only be used with a code."
+	VoucherNotEligible                 = "Voucher not available for this installment."
+	VoucherLimitReached                = "Voucher usage limit reached."
+	VoucherQuotaUsedUp                 = "Voucher quota already used up."
+	VoucherArchived                    = "Voucher already archived."
 	LendingInstallmentNotMatch         = "Lending installment not match"
 	InstallmentAlreadyPaid             = "Installment already paid."
 	LoanAmountExceedCreditLimit        = "Loan amount exceed credit limit."
 	LoanAmountExceedCreditLimitWarning = "Loan amount exceed credit limit warning."
 	CreditHealthStatusBlocked          = "Credit health status blocked"
+	PaymentIntentNotExist              = "Payment intent not exist."
+	PaymentIntentAlreadyPaid           = "Payment intent already paid."
+	PaymentIntentReversed              = "Payment intent was reversed."
+	PaymentIntentRefundRequired        = "Payment intent is waiting for a refund."
+	PaymentNotExist                    = "Payment ID not exist."
+	PaymentAlreadyReversed             = "Payment already reversed."
+	LendingNotDelinquent               = "Lending is not delinquent."
+	LendingCannotBeWrittenOff          = "Lending cannot be written off."
+	CollectorNotExist                  = "Collector ID not exist."
+	InstallmentRestructured            = "Installment was replaced by a restructuring."
+	LendingCannotBeRestructured        = "Lending cannot be restructured."
+	RestructuringNotExist              = "Restructuring ID not exist."
+	RestructuringAlreadyPending        = "Lending already has a pending restructuring."
+	RestructuringAlreadyResponded      = "Restructuring already responded."
+	RestructuringOutdated              = "Lending changed since the restructuring was proposed."
+	RestructuringTenorExceeded         = "Installment amount too small for the maximum tenor."
+	CreditLimitProposalNotExist        = "Credit limit proposal ID not exist."
+	CreditLimitProposalResponded       = "Credit limit proposal already responded."
+	CreditLimitProposalOutdated        = "Credit limit changed since the proposal was made."
+	KycNotApproved                     = "KYC not approved."
+	KycAlreadyPending                  = "KYC already waiting for review."
+	KycAlreadyApproved                 = "KYC already approved."
+	KycSubmissionNotExist              = "KYC submission ID not exist."
+	KycAlreadyReviewed                 = "KYC submission already reviewed."
+	KycDocumentNotExist                = "KYC document not exist."
+	GuarantorNotExist                  = "Guarantor ID not exist."
+	GuarantorInvalid                   = "User cannot be a guarantor for this loan."
+	GuarantorAlreadyInvited            = "User already invited as guarantor."
+	GuarantorAlreadyResponded          = "Guarantor invitation already responded."
+	LendingCannotAddGuarantor          = "Guarantors can only be added to a new loan."
+	LendingCannotRespondGuarantee      = "Guarantee can only be answered while the loan is new."
+	LendingCannotCancel                = "Only a new loan can be cancelled."
+	LendingCannotApprove               = "Only a new loan can be approved."
+	LendingCannotReject                = "Only a new loan can be rejected."
+	RejectionReasonNotExist            = "Rejection reason ID not exist."
+	RejectionReasonNotActive           = "Rejection reason is not active."
+	RejectionReasonCodeExist           = "Rejection reason code already exist."
+	ContractCannotAdvance              = "Contract status cannot be advanced further by admin."
+	InvalidBulkRow                     = "Row could not be read."
+	InvalidCallbackSignature           = "Invalid callback signature."
+	InvalidStatementFile               = "Statement file could not be read."
+	ReconciliationItemNotExist         = "Reconciliation item ID not exist."
+	ReconciliationItemAlreadyResolved  = "Reconciliation item already resolved."
+	ReconciliationReferenceNotFound    = "No open payment found for the transfer reference."
+	ReconciliationAmountNotMatch       = "Transfer amount does not match any open payment."
+	ReconciliationAmbiguous            = "Transfer matches more than one open payment."
+	LoanProductAmountNotValid          = "Loan product max amount must not be below min amount."
+	ExpireDateBeforeActiveDate         = "Expire date must not be before active date."
+	VoucherQuotaNotValid               = "Voucher quota must not be negative."
+	DataAlreadyExist                   = "Data already exist."
+	ReferencedDataNotExist             = "Referenced data not exist."
+	DataConstraintViolation            = "Data not valid."
 )
 
 type JSONResponse struct {
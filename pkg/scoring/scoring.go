@@ -0,0 +1,147 @@
+// Package scoring computes a credit score and a recommended credit limit
+// from a debtor's repayment history. Every result carries the version of
+// the rules that produced it, so a stored score can always be explained.
+package scoring
+
+import (
+	"final-project-backend/config"
+	"math"
+	"time"
+)
+
+const (
+	FactorOnTimePayments = "on_time_payments"
+	FactorLatePayments   = "late_payments"
+	FactorTotalDelay     = "total_delay"
+	FactorUtilization    = "utilization"
+	FactorTenure         = "tenure_months"
+)
+
+// Rules are the weights applied to each factor. They are read from the
+// scoring section of the config; bump Version whenever a weight changes.
+type Rules struct {
+	Version           string
+	BaseScore         int
+	MaxScore          int
+	OnTimePoints      int
+	LatePoints        int
+	DelayPoints       int
+	UtilizationPoints int
+	TenurePoints      int
+	MaxTenureMonths   int
+	MinLimitScore     int
+	LimitPerPoint     float64
+	LimitStep         float64
+}
+
+func NewRules(cfg *config.Config) Rules {
+	return Rules{
+		Version:           cfg.Scoring.Version,
+		BaseScore:         cfg.Scoring.BaseScore,
+		MaxScore:          cfg.Scoring.MaxScore,
+		OnTimePoints:      cfg.Scoring.OnTimePoints,
+		LatePoints:        cfg.Scoring.LatePoints,
+		DelayPoints:       cfg.Scoring.DelayPoints,
+		UtilizationPoints: cfg.Scoring.UtilizationPoints,
+		TenurePoints:      cfg.Scoring.TenurePoints,
+		MaxTenureMonths:   cfg.Scoring.MaxTenureMonths,
+		MinLimitScore:     cfg.Scoring.MinLimitScore,
+		LimitPerPoint:     cfg.Scoring.LimitPerPoint,
+		LimitStep:         cfg.Scoring.LimitStep,
+	}
+}
+
+// Repayment is a settled installment: when it was due and when it was paid.
+type Repayment struct {
+	DueDate     time.Time
+	PaymentDate time.Time
+}
+
+type Input struct {
+	Repayments  []Repayment
+	TotalDelay  int
+	CreditUsed  float64
+	CreditLimit float64
+	Since       time.Time
+	At          time.Time
+}
+
+// Factor explains how much a single input moved the score.
+type Factor struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Points int     `json:"points"`
+}
+
+type Result struct {
+	Version          string   `json:"version"`
+	Score            int      `json:"score"`
+	RecommendedLimit float64  `json:"recommended_limit"`
+	Factors          []Factor `json:"factors"`
+}
+
+func (r Rules) Score(in Input) Result {
+	onTime, late := 0, 0
+	for _, repayment := range in.Repayments {
+		if repayment.PaymentDate.After(repayment.DueDate) {
+			late++
+		} else {
+			onTime++
+		}
+	}
+
+	utilization := 0.0
+	switch {
+	case in.CreditLimit > 0:
+		utilization = math.Min(in.CreditUsed/in.CreditLimit, 1)
+	case in.CreditUsed > 0:
+		utilization = 1
+	}
+
+	tenure := 0
+	if in.At.After(in.Since) {
+		tenure = int(in.At.Sub(in.Since).Hours() / 24 / 30)
+	}
+	if tenure > r.MaxTenureMonths {
+		tenure = r.MaxTenureMonths
+	}
+
+	factors := []Factor{
+		{Name: FactorOnTimePayments, Value: float64(onTime), Points: onTime * r.OnTimePoints},
+		{Name: FactorLatePayments, Value: float64(late), Points: -late * r.LatePoints},
+		{Name: FactorTotalDelay, Value: float64(in.TotalDelay), Points: -in.TotalDelay * r.DelayPoints},
+		{Name: FactorUtilization, Value: utilization, Points: -int(math.Round(utilization * float64(r.UtilizationPoints)))},
+		{Name: FactorTenure, Value: float64(tenure), Points: tenure * r.TenurePoints},
+	}
+
+	score := r.BaseScore
+	for _, factor := range factors {
+		score += factor.Points
+	}
+	if score < 0 {
+		score = 0
+	}
+	if score > r.MaxScore {
+		score = r.MaxScore
+	}
+
+	return Result{
+		Version:          r.Version,
+		Score:            score,
+		RecommendedLimit: r.limit(score),
+		Factors:          factors,
+	}
+}
+
+func (r Rules) limit(score int) float64 {
+	if score < r.MinLimitScore {
+		return 0
+	}
+
+	limit := float64(score) * r.LimitPerPoint
+	if r.LimitStep > 0 {
+		limit = math.Floor(limit/r.LimitStep) * r.LimitStep
+	}
+
+	return limit
+}
@@ -0,0 +1,166 @@
+package scoring_test
+
+import (
+	"final-project-backend/pkg/scoring"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var rules = scoring.Rules{
+	Version:           "v1",
+	BaseScore:         500,
+	MaxScore:          850,
+	OnTimePoints:      10,
+	LatePoints:        25,
+	DelayPoints:       5,
+	UtilizationPoints: 100,
+	TenurePoints:      5,
+	MaxTenureMonths:   24,
+	MinLimitScore:     450,
+	LimitPerPoint:     20000,
+	LimitStep:         500000,
+}
+
+func repayments(count int, late bool) []scoring.Repayment {
+	due := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	paid := due.AddDate(0, 0, -1)
+	if late {
+		paid = due.AddDate(0, 0, 3)
+	}
+
+	result := make([]scoring.Repayment, count)
+	for i := range result {
+		result[i] = scoring.Repayment{DueDate: due, PaymentDate: paid}
+	}
+	return result
+}
+
+func TestScore(t *testing.T) {
+	at := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rules      func(r scoring.Rules) scoring.Rules
+		in         scoring.Input
+		wantScore  int
+		wantLimit  float64
+		wantTenure float64
+	}{
+		{
+			name:      "no history",
+			in:        scoring.Input{CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 500,
+			wantLimit: 10000000,
+		},
+		{
+			name:      "on time and late repayments",
+			in:        scoring.Input{Repayments: append(repayments(5, false), repayments(2, true)...), TotalDelay: 6, CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 500 + 50 - 50 - 30,
+			wantLimit: 9000000,
+		},
+		{
+			name:      "clamped to max score",
+			in:        scoring.Input{Repayments: repayments(40, false), CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 850,
+			wantLimit: 17000000,
+		},
+		{
+			name:      "clamped to zero",
+			in:        scoring.Input{Repayments: repayments(30, true), TotalDelay: 10, CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 0,
+			wantLimit: 0,
+		},
+		{
+			name:      "at the minimum limit score",
+			in:        scoring.Input{CreditUsed: 5000000, CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 450,
+			wantLimit: 9000000,
+		},
+		{
+			name:      "below the minimum limit score",
+			in:        scoring.Input{CreditUsed: 5100000, CreditLimit: 10000000, Since: at, At: at},
+			wantScore: 449,
+			wantLimit: 0,
+		},
+		{
+			name:      "utilization above the credit limit counts as full",
+			in:        scoring.Input{CreditUsed: 15000000, CreditLimit: 10000000, Repayments: repayments(10, false), Since: at, At: at},
+			wantScore: 500 + 100 - 100,
+			wantLimit: 10000000,
+		},
+		{
+			name:      "zero credit limit without usage",
+			in:        scoring.Input{Since: at, At: at},
+			wantScore: 500,
+			wantLimit: 10000000,
+		},
+		{
+			name:      "zero credit limit with usage",
+			in:        scoring.Input{CreditUsed: 1000000, Repayments: repayments(10, false), Since: at, At: at},
+			wantScore: 500,
+			wantLimit: 10000000,
+		},
+		{
+			name:       "limit rounded down to the step",
+			in:         scoring.Input{CreditLimit: 10000000, Since: at.AddDate(0, 0, -120), At: at},
+			wantScore:  520,
+			wantLimit:  10000000,
+			wantTenure: 4,
+		},
+		{
+			name: "limit not rounded without a step",
+			rules: func(r scoring.Rules) scoring.Rules {
+				r.LimitStep = 0
+				return r
+			},
+			in:         scoring.Input{CreditLimit: 10000000, Since: at.AddDate(0, 0, -120), At: at},
+			wantScore:  520,
+			wantLimit:  10400000,
+			wantTenure: 4,
+		},
+		{
+			name:       "tenure capped",
+			in:         scoring.Input{CreditLimit: 10000000, Since: at.AddDate(-3, 0, 0), At: at},
+			wantScore:  500 + 24*5,
+			wantLimit:  12000000,
+			wantTenure: 24,
+		},
+		{
+			name:      "since in the future",
+			in:        scoring.Input{CreditLimit: 10000000, Since: at.AddDate(0, 1, 0), At: at},
+			wantScore: 500,
+			wantLimit: 10000000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rules
+			if tt.rules != nil {
+				r = tt.rules(r)
+			}
+
+			result := r.Score(tt.in)
+
+			assert.Equal(t, "v1", result.Version)
+			assert.Equal(t, tt.wantScore, result.Score)
+			assert.Equal(t, tt.wantLimit, result.RecommendedLimit)
+			for _, factor := range result.Factors {
+				if factor.Name == scoring.FactorTenure {
+					assert.Equal(t, tt.wantTenure, factor.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreWithZeroLimitPerPoint(t *testing.T) {
+	r := rules
+	r.LimitPerPoint = 0
+
+	result := r.Score(scoring.Input{Repayments: repayments(40, false)})
+
+	assert.Equal(t, 850, result.Score)
+	assert.Equal(t, 0.0, result.RecommendedLimit)
+}
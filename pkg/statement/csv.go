@@ -0,0 +1,104 @@
+package statement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{"2006-01-02", "02-01-2006", "02/01/2006", "2006-01-02 15:04:05", "02-01-2006 15:04:05"}
+
+// ParseCSV reads a statement with a header row containing at least the
+// date, reference and amount columns. A description column is optional.
+// Debit rows (negative amounts) are skipped.
+func ParseCSV(r io.Reader) ([]Line, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("statement is empty")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"date", "reference", "amount"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("statement is missing %s column", name)
+		}
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+
+	var lines []Line
+	number := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		number++
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		amount, err := parseAmount(field("amount"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", number, field("amount"))
+		}
+
+		if amount <= 0 {
+			continue
+		}
+
+		date, err := parseDate(field("date"), loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", number, field("date"))
+		}
+
+		lines = append(lines, Line{
+			Number:          number,
+			TransactionDate: date,
+			Reference:       field("reference"),
+			Description:     field("description"),
+			Amount:          amount,
+		})
+	}
+
+	return countOccurrences(lines), nil
+}
+
+func parseAmount(value string) (float64, error) {
+	value = strings.ReplaceAll(value, ",", "")
+	value = strings.ReplaceAll(value, " ", "")
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseDate(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid date")
+}
@@ -0,0 +1,93 @@
+package statement
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statementLine matches the :61: field, e.g.
+// :61:2212251225C1005000,00NTRF8808123456789012//BANKREF
+var statementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])[A-Z]?(\d+,\d{0,2})N[A-Z0-9]{3}([^/]*)(?://(.*))?$`)
+
+// ParseMT940 reads the :61: statement lines of a SWIFT MT940 file along
+// with the :86: narrative that follows each of them. Only credit entries
+// are returned.
+func ParseMT940(r io.Reader) ([]Line, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	scanner := bufio.NewScanner(r)
+
+	var lines []Line
+	var current *Line
+	var credit bool
+	var inNarrative bool
+	number := 0
+
+	flush := func() {
+		if current != nil && credit {
+			current.Description = strings.TrimSpace(current.Description)
+			lines = append(lines, *current)
+		}
+		current = nil
+		inNarrative = false
+	}
+
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		switch {
+		case strings.HasPrefix(text, ":61:"):
+			flush()
+			match := statementLine.FindStringSubmatch(strings.TrimPrefix(text, ":61:"))
+			if match == nil {
+				return nil, fmt.Errorf("line %d: invalid statement line", number)
+			}
+
+			date, err := time.ParseInLocation("060102", match[1], loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid date %q", number, match[1])
+			}
+
+			amount, err := strconv.ParseFloat(strings.Replace(match[4], ",", ".", 1), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid amount %q", number, match[4])
+			}
+
+			credit = match[3] == "C" || match[3] == "RD"
+			current = &Line{
+				Number:          number,
+				TransactionDate: date,
+				Reference:       strings.TrimSpace(match[5]),
+				Amount:          amount,
+			}
+		case strings.HasPrefix(text, ":86:"):
+			if current != nil {
+				current.Description = strings.TrimPrefix(text, ":86:")
+				inNarrative = true
+			}
+		case strings.HasPrefix(text, ":"), strings.HasPrefix(text, "-}"), text == "-":
+			flush()
+		default:
+			if inNarrative && current != nil {
+				current.Description += " " + strings.TrimSpace(text)
+			}
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if number == 0 {
+		return nil, errors.New("statement is empty")
+	}
+
+	return countOccurrences(lines), nil
+}
@@ -0,0 +1,140 @@
+package statement_test
+
+import (
+	"final-project-backend/pkg/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func jakarta(t *testing.T, year int, month time.Month, day, hour, min, sec int) time.Time {
+	t.Helper()
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	return time.Date(year, month, day, hour, min, sec, 0, loc)
+}
+
+func TestParseCSV(t *testing.T) {
+	file, err := os.Open("testdata/bca.csv")
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines, err := statement.Parse(statement.FormatCSV, file)
+	require.NoError(t, err)
+
+	expected := []statement.Line{
+		{
+			Number:          2,
+			TransactionDate: jakarta(t, 2026, 3, 9, 0, 0, 0),
+			Reference:       "8808123456789012",
+			Description:     "TRSF E-BANKING CR 0903/FTSCY/WS95031 BUDI SANTOSO",
+			Amount:          1005000,
+		},
+		{
+			Number:          3,
+			TransactionDate: jakarta(t, 2026, 3, 9, 0, 0, 0),
+			Reference:       "ABCD2345EFGH",
+			Description:     "SETORAN TUNAI PAYMENT CODE ABCD2345EFGH",
+			Amount:          525000,
+		},
+		{
+			Number:          5,
+			TransactionDate: jakarta(t, 2026, 3, 10, 0, 0, 0),
+			Description:     "TRANSFER 3f1c2a4e-9b7d-4c1e-8a2b-6d5e4f3a2b1c",
+			Amount:          2100000,
+		},
+		{
+			Number:          6,
+			TransactionDate: jakarta(t, 2026, 3, 11, 14, 25, 10),
+			Reference:       "8808123456789012",
+			Description:     "TRSF E-BANKING CR 1103/FTSCY/WS95044 BUDI SANTOSO",
+			Amount:          1005000,
+		},
+	}
+
+	require.Len(t, lines, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Number, lines[i].Number)
+		assert.True(t, expected[i].TransactionDate.Equal(lines[i].TransactionDate), "line %d date %s", lines[i].Number, lines[i].TransactionDate)
+		assert.Equal(t, expected[i].Reference, lines[i].Reference)
+		assert.Equal(t, expected[i].Description, lines[i].Description)
+		assert.Equal(t, expected[i].Amount, lines[i].Amount)
+	}
+
+	assert.Contains(t, lines[2].References(), "3f1c2a4e-9b7d-4c1e-8a2b-6d5e4f3a2b1c")
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "empty file", file: "", want: "statement is empty"},
+		{name: "missing amount column", file: "date,reference\n2026-03-09,8808123456789012\n", want: "statement is missing amount column"},
+		{name: "invalid amount", file: "date,reference,amount\n2026-03-09,8808123456789012,seribu\n", want: `line 2: invalid amount "seribu"`},
+		{name: "invalid date", file: "date,reference,amount\n9 Maret 2026,8808123456789012,1000\n", want: `line 2: invalid date "9 Maret 2026"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statement.ParseCSV(strings.NewReader(tt.file))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseMT940(t *testing.T) {
+	file, err := os.Open("testdata/mandiri.sta")
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines, err := statement.Parse(statement.FormatMT940, file)
+	require.NoError(t, err)
+
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, 6, lines[0].Number)
+	assert.True(t, jakarta(t, 2026, 3, 9, 0, 0, 0).Equal(lines[0].TransactionDate))
+	assert.Equal(t, "8808123456789012", lines[0].Reference)
+	assert.Equal(t, "TRSF E-BANKING CR 0903 BUDI SANTOSO INSTALLMENT 1 OF 12", lines[0].Description)
+	assert.Equal(t, 1005000.0, lines[0].Amount)
+
+	assert.Equal(t, 11, lines[1].Number)
+	assert.Equal(t, "ABCD2345EFGH", lines[1].Reference)
+	assert.Equal(t, "SETORAN PAYMENT CODE ABCD2345EFGH", lines[1].Description)
+	assert.Equal(t, 525000.0, lines[1].Amount)
+
+	// A reversed debit puts money back on the account, so it counts as a credit.
+	assert.Equal(t, 13, lines[2].Number)
+	assert.Equal(t, "NONREF", lines[2].Reference)
+	assert.Equal(t, 250000.0, lines[2].Amount)
+}
+
+func TestParseMT940Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "empty file", file: "", want: "statement is empty"},
+		{name: "invalid statement line", file: ":20:STMT\n:61:NOTADATE\n", want: "line 2: invalid statement line"},
+		{name: "invalid date", file: ":20:STMT\n:61:2613450345C1000,00NTRFREF\n", want: `line 2: invalid date "261345"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := statement.ParseMT940(strings.NewReader(tt.file))
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseUnsupportedFormat(t *testing.T) {
+	_, err := statement.Parse("ofx", strings.NewReader(""))
+	assert.Equal(t, statement.ErrUnsupportedFormat, err)
+}
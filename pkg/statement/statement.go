@@ -0,0 +1,89 @@
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatMT940 = "mt940"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// Line is a single credit entry read from a bank statement.
+type Line struct {
+	Number          int
+	TransactionDate time.Time
+	Reference       string
+	Description     string
+	Amount          float64
+	// Occurrence counts the earlier lines of the same file that have the
+	// same date, reference, description and amount, so two identical
+	// transfers on one day are told apart.
+	Occurrence int
+}
+
+// Hash identifies a line across imports so the same transfer is never
+// reconciled twice when a statement is uploaded again. The first occurrence
+// hashes as it did before occurrences were counted.
+func (l Line) Hash() string {
+	key := fmt.Sprintf("%s|%s|%s|%.2f", l.TransactionDate.Format("2006-01-02"), l.Reference, l.Description, l.Amount)
+	if l.Occurrence > 0 {
+		key += fmt.Sprintf("|%d", l.Occurrence)
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// References returns the reference and every description token long enough
+// to be a virtual account number, payment code or installment id.
+func (l Line) References() []string {
+	seen := map[string]bool{}
+	var refs []string
+	add := func(ref string) {
+		ref = strings.Trim(ref, " .,;:/()")
+		if len(ref) < 8 || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	add(l.Reference)
+	for _, token := range strings.Fields(l.Description) {
+		add(token)
+	}
+
+	return refs
+}
+
+// countOccurrences numbers the lines that would otherwise hash the same.
+func countOccurrences(lines []Line) []Line {
+	seen := map[string]int{}
+	for i := range lines {
+		key := lines[i].Hash()
+		lines[i].Occurrence = seen[key]
+		seen[key]++
+	}
+
+	return lines
+}
+
+func Parse(format string, r io.Reader) ([]Line, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatMT940:
+		return ParseMT940(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
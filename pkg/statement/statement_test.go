@@ -0,0 +1,46 @@
+package statement_test
+
+import (
+	"final-project-backend/pkg/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestIdenticalTransfersHashApart(t *testing.T) {
+	file := `date,reference,description,amount
+2026-03-10,8808123456789012,TRANSFER BUDI,1005000
+2026-03-10,8808123456789012,TRANSFER BUDI,1005000
+2026-03-10,8808123456789012,TRANSFER BUDI,1005000
+2026-03-11,8808123456789012,TRANSFER BUDI,1005000
+`
+	lines, err := statement.ParseCSV(strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, []int{0, 1, 2, 0}, []int{lines[0].Occurrence, lines[1].Occurrence, lines[2].Occurrence, lines[3].Occurrence})
+
+	hashes := map[string]bool{}
+	for _, line := range lines {
+		hashes[line.Hash()] = true
+	}
+	assert.Len(t, hashes, 4)
+
+	again, err := statement.ParseCSV(strings.NewReader(file))
+	require.NoError(t, err)
+	for i := range lines {
+		assert.Equal(t, lines[i].Hash(), again[i].Hash(), "line %d hashes differently when uploaded again", i)
+	}
+}
+
+func TestFirstOccurrenceKeepsHash(t *testing.T) {
+	line := statement.Line{Reference: "8808123456789012", Description: "TRANSFER BUDI", Amount: 1005000}
+	second := line
+	second.Occurrence = 1
+
+	// sha256 of "0001-01-01|8808123456789012|TRANSFER BUDI|1005000.00", the
+	// hash statement lines had before occurrences were counted.
+	assert.Equal(t, "a1e402c0bd53afa3affa2a87459d73ad65789bd81837eace90acddfb056c720a", line.Hash())
+	assert.NotEqual(t, line.Hash(), second.Hash())
+}
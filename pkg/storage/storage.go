@@ -0,0 +1,28 @@
+// Package storage keeps uploaded files outside the database. Callers address
+// files by a slash separated key and never see where a backend puts them.
+package storage
+
+import (
+	"context"
+	"errors"
+	"final-project-backend/config"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+type Storage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewStorage(cfg *config.Config) (Storage, error) {
+	switch cfg.Storage.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.Storage.LocalPath), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Storage.Driver)
+	}
+}
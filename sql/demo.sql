@@ -1,213 +1,13 @@
-CREATE
-DATABASE p2p_db_emir;
-
-DROP TABLE IF EXISTS users CASCADE;
-DROP TABLE IF EXISTS roles CASCADE;
-DROP TABLE IF EXISTS debtors CASCADE;
-DROP TABLE IF EXISTS credit_health_types CASCADE;
-DROP TABLE IF EXISTS contract_tracking_types CASCADE;
-DROP TABLE IF EXISTS lendings CASCADE;
-DROP TABLE IF EXISTS loan_periods CASCADE;
-DROP TABLE IF EXISTS lending_status_types CASCADE;
-DROP TABLE IF EXISTS installments CASCADE;
-DROP TABLE IF EXISTS installment_status_types CASCADE;
-DROP TABLE IF EXISTS payments CASCADE;
-DROP TABLE IF EXISTS vouchers CASCADE;
-
-CREATE TABLE "users"
-(
-    "user_id"      UUID PRIMARY KEY NOT NULL,
-    "role_id"      int              NOT NULL,
-    "name"         VARCHAR          NOT NULL,
-    "phone_number" VARCHAR          NOT NULL,
-    "address"      TEXT             NOT NULL,
-    "email"        VARCHAR UNIQUE   NOT NULL,
-    "password"     VARCHAR          NOT NULL,
-    "created_at"   timestamptz      NOT NULL DEFAULT (NOW()),
-    "updated_at"   timestamptz
-);
-
-CREATE TABLE "roles"
-(
-    "role_id"    serial PRIMARY KEY NOT NULL,
-    "name"       VARCHAR            NOT NULL,
-    "created_at" timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at" timestamptz
-);
-
-CREATE TABLE "debtors"
-(
-    "debtor_id"            UUID PRIMARY KEY NOT NULL,
-    "user_id"              UUID             NOT NULL,
-    "credit_health_id"     int              NOT NULL,
-    "contract_tracking_id" int              NOT NULL,
-    "credit_limit"         FLOAT            NOT NULL DEFAULT 0,
-    "credit_used"          FLOAT            NOT NULL DEFAULT 0,
-    "total_delay"          int              NOT NULL DEFAULT 0,
-    "created_at"           timestamptz      NOT NULL DEFAULT (NOW()),
-    "updated_at"           timestamptz
-);
-
-CREATE TABLE "credit_health_types"
-(
-    "credit_health_id" serial PRIMARY KEY NOT NULL,
-    "name"             VARCHAR            NOT NULL,
-    "created_at"       timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at"       timestamptz
-);
-
-CREATE TABLE "contract_tracking_types"
-(
-    "contract_tracking_id" serial PRIMARY KEY NOT NULL,
-    "name"                 VARCHAR            NOT NULL,
-    "created_at"           timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at"           timestamptz
-);
-
-CREATE TABLE "lendings"
-(
-    "lending_id"        UUID PRIMARY KEY NOT NULL,
-    "debtor_id"         UUID             NOT NULL,
-    "loan_period_id"    int              NOT NULL,
-    "lending_status_id" int              NOT NULL,
-    "name"              VARCHAR          NOT NULL,
-    "amount"            float            NOT NULL,
-    "created_at"        timestamptz      NOT NULL DEFAULT (NOW()),
-    "updated_at"        timestamptz
-);
-
-CREATE TABLE "loan_periods"
-(
-    "loan_period_id" serial PRIMARY KEY NOT NULL,
-    "duration"       int                NOT NULL,
-    "percentage"     int                NOT NULL,
-    "created_at"     timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at"     timestamptz
-);
-
-CREATE TABLE "lending_status_types"
-(
-    "lending_status_id" serial PRIMARY KEY NOT NULL,
-    "name"              VARCHAR            NOT NULL,
-    "created_at"        timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at"        timestamptz
-);
-
-CREATE TABLE "installments"
-(
-    "installment_id"        UUID PRIMARY KEY NOT NULL,
-    "lending_id"            UUID             NOT NULL,
-    "installment_status_id" int              NOT NULL,
-    "amount"                float            NOT NULL,
-    "due_date"              timestamptz      NOT NULL,
-    "created_at"            timestamptz      NOT NULL DEFAULT (NOW()),
-    "updated_at"            timestamptz
-);
-
-CREATE TABLE "installment_status_types"
-(
-    "installment_status_id" serial PRIMARY KEY NOT NULL,
-    "name"                  VARCHAR            NOT NULL,
-    "created_at"            timestamptz        NOT NULL DEFAULT (NOW()),
-    "updated_at"            timestamptz
-);
-
-CREATE TABLE "payments"
-(
-    "payment_id"       UUID PRIMARY KEY NOT NULL,
-    "installment_id"   UUID             NOT NULL,
-    "voucher_id"       UUID,
-    "payment_fine"     float            NOT NULL DEFAULT 0,
-    "payment_discount" float            NOT NULL DEFAULT 0,
-    "payment_amount"   float            NOT NULL,
-    "payment_date"     timestamptz      NOT NULL DEFAULT (NOW())
-);
-
-CREATE TABLE "vouchers"
-(
-    "voucher_id"       UUID PRIMARY KEY NOT NULL,
-    "name"             VARCHAR          NOT NULL,
-    "discount_payment" int              NOT NULL,
-    "discount_quota"   int              NOT NULL,
-    "active_date"      timestamptz      NOT NULL,
-    "expire_date"      timestamptz      NOT NULL,
-    "created_at"       timestamptz      NOT NULL DEFAULT (NOW()),
-    "updated_at"       timestamptz,
-    "deleted_at"       timestamptz
-);
-
-ALTER TABLE "debtors"
-    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
-
-ALTER TABLE "users"
-    ADD FOREIGN KEY ("role_id") REFERENCES "roles" ("role_id");
-
-ALTER TABLE "lendings"
-    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
-
-ALTER TABLE "debtors"
-    ADD FOREIGN KEY ("credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
-
-ALTER TABLE "debtors"
-    ADD FOREIGN KEY ("contract_tracking_id") REFERENCES "contract_tracking_types" ("contract_tracking_id");
-
-ALTER TABLE "installments"
-    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
-
-ALTER TABLE "lendings"
-    ADD FOREIGN KEY ("lending_status_id") REFERENCES "lending_status_types" ("lending_status_id");
-
-ALTER TABLE "lendings"
-    ADD FOREIGN KEY ("loan_period_id") REFERENCES "loan_periods" ("loan_period_id");
-
-ALTER TABLE "payments"
-    ADD FOREIGN KEY ("installment_id") REFERENCES "installments" ("installment_id");
-
-ALTER TABLE "installments"
-    ADD FOREIGN KEY ("installment_status_id") REFERENCES "installment_status_types" ("installment_status_id");
-
-ALTER TABLE "payments"
-    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
-
-INSERT INTO "roles" (name)
-VALUES ('admin'),
-       ('user');
-
-INSERT INTO "credit_health_types" (name)
-VALUES ('good'),
-       ('warning'),
-       ('blocked');
-
-INSERT INTO "contract_tracking_types" (name)
-VALUES ('no contract yet'),
-       ('the contract was given to the expedition partner'),
-       ('contract in delivery'),
-       ('contract accepted by user'),
-       ('confirmed contract');
-
-insert into "loan_periods" (duration, percentage)
-values (1, 100),
-       (3, 105),
-       (6, 110),
-       (12, 120),
-       (18, 130),
-       (24, 150);
-
-insert into "lending_status_types" (name)
-values ('new'),
-       ('approved'),
-       ('on progress'),
-       ('paid'),
-       ('reject');
-
-insert into "installment_status_types" (name)
-values ('on progress'),
-       ('paid');
+-- Demo accounts and loan history for local development. Apply it after
+-- `migrate up`; it is not part of the migrations and must not be loaded into
+-- a shared environment.
 
 -- // password: Tested8*
 insert into "users" (user_id, role_id, name, phone_number, address, email, password)
 values ('101401ce-4a0f-11ed-9772-acde48001122', 1, 'Admin', '911', 'USA', 'admin@seafund.com',
         '$2a$10$ne0VPTKWnzVsdX7zfg1I1.MVK8RiNJDrXRf3JzoXqjaFdA3jaAGCC'),
+       ('5a2e7c1c-4f0b-4a8e-9d41-0c6f2b1e7a10', 3, 'Collector', '911', 'Jakarta', 'collector@seafund.com',
+        '$2a$10$ne0VPTKWnzVsdX7zfg1I1.MVK8RiNJDrXRf3JzoXqjaFdA3jaAGCC'),
        ('b4959bd6-dbbc-4871-9bc7-bbfd4d97f1ae', 2, 'Emir', '083187115996', 'Jakarta', 'emir@gmail.com',
         '$2a$10$ne0VPTKWnzVsdX7zfg1I1.MVK8RiNJDrXRf3JzoXqjaFdA3jaAGCC'),
        ('8c0c6601-dfc0-465a-a2ac-6628410d4639', 2, 'Angela', '083187115996', 'Jakarta', 'angela@gmail.com',
@@ -272,27 +72,27 @@ values ('f8d54756-37ca-4fc4-8fa4-a822248daf59', 'b4959bd6-dbbc-4871-9bc7-bbfd4d9
        ('920b5857-7e55-4a2f-930d-98e8105930eb', 'c4d46062-a4a9-4aeb-97e5-b5e4c4cf24c0', 1, 5, 1000000, 0, 0),
        ('ec55a8af-b02c-4550-96ef-44d7b8bffea0', '7735e399-54b7-4b90-9f4d-62f65ef8ceec', 1, 5, 1000000, 0, 0);
 
-insert into "lendings" (lending_id, debtor_id, loan_period_id, lending_status_id, name, amount)
-VALUES ('fb5ab385-20a1-4c87-8e4b-900507838d86', 'f8d54756-37ca-4fc4-8fa4-a822248daf59', 1, 4, 'sbux', 1000000),
-       ('1a028314-72e5-11ed-a1eb-0242ac120002', '81a05d49-678f-4c11-bc8b-c3dc92d4a346', 1, 4, 'sbux', 1000000),
-       ('35e76898-e54e-4eae-ae61-8a768442d42f', 'b77059d0-e60d-4d31-b7c4-da34a42126b8', 1, 4, 'sbux', 1000000),
-       ('28314064-6130-4764-8e26-358a8c8004b6', '5f640496-eab2-4897-b22c-e283e645dae7', 1, 4, 'sbux', 1000000),
-       ('21989865-f009-4077-9cf8-bb611273d258', '5abf0fb9-19a2-452a-8457-8ddfb17a8ebd', 1, 4, 'sbux', 1000000),
-       ('368b0f27-4ea5-42a5-9106-1c5f5a465f15', 'e6ecf20f-dea3-4a87-94e8-64407dd388d2', 1, 4, 'sbux', 1000000),
-       ('a5011f09-4fa6-4945-9dbd-4ec42b4ba275', '406ba062-512b-4c7b-8e5f-e1eb3d94cb6e', 1, 4, 'sbux', 1000000),
-       ('982b53c6-bd7e-4a73-bede-d275f0665f7e', '4e196206-63ec-45c1-9d94-433774f78a92', 1, 4, 'sbux', 1000000),
-       ('66302fb4-2302-4d92-9c8f-4770c8a95258', '8e95b61a-72e5-11ed-a1eb-0242ac120002', 1, 4, 'sbux', 1000000),
-       ('bea872f8-c548-47dc-aa4b-4bb5308442d7', '498c09ac-960d-452f-b67d-76b89e7efdac', 1, 4, 'sbux', 1000000),
-       ('3e056754-dfc5-47cb-b98e-e7d986e87341', 'b09cc98e-4ec9-42a0-8cfb-f423e6913728', 1, 4, 'sbux', 1000000),
-       ('2280abf5-058e-4ba1-9672-c8f2eafb026c', '23fa7af5-ac10-4237-8058-f7a1e8709fa8', 1, 4, 'sbux', 1000000),
-       ('6eece99e-e356-4392-be25-de7cdeba6434', '5b1b3cc9-79fb-422b-b781-ad952f4a5f23', 1, 4, 'sbux', 1000000),
-       ('1d70d7db-aea6-4c87-b00d-a033a6741855', 'a1373364-817e-41c3-b833-a9354dd3274f', 1, 4, 'sbux', 1000000),
-       ('c58180b7-fe28-4e49-bd77-9af7bb844e7b', '9ce1c7a6-1f8e-4337-9272-453eaf60276f', 1, 4, 'sbux', 1000000),
-       ('d5800b4d-2fa8-4cf9-80cc-47ea5420bc24', '13dee731-03d9-40bc-b48f-59cdfc8545d4', 1, 4, 'sbux', 1000000),
-       ('7876fe2e-ec53-4362-9d5c-0b923af4466c', '51caf4f1-2095-47fb-b66d-99a7db2418de', 1, 4, 'sbux', 1000000),
-       ('09895fc0-72e1-11ed-a1eb-0242ac120002', '78e5d4b3-2c19-47d9-9d73-8a662ca52d99', 1, 4, 'sbux', 1000000),
-       ('0e3162f2-72e1-11ed-a1eb-0242ac120002', '920b5857-7e55-4a2f-930d-98e8105930eb', 1, 4, 'sbux', 1000000),
-       ('1260284a-72e1-11ed-a1eb-0242ac120002', 'ec55a8af-b02c-4550-96ef-44d7b8bffea0', 1, 4, 'sbux', 1000000);
+insert into "lendings" (lending_id, debtor_id, loan_product_id, lending_status_id, name, amount, duration, percentage, fine_per_day)
+VALUES ('fb5ab385-20a1-4c87-8e4b-900507838d86', 'f8d54756-37ca-4fc4-8fa4-a822248daf59', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('1a028314-72e5-11ed-a1eb-0242ac120002', '81a05d49-678f-4c11-bc8b-c3dc92d4a346', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('35e76898-e54e-4eae-ae61-8a768442d42f', 'b77059d0-e60d-4d31-b7c4-da34a42126b8', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('28314064-6130-4764-8e26-358a8c8004b6', '5f640496-eab2-4897-b22c-e283e645dae7', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('21989865-f009-4077-9cf8-bb611273d258', '5abf0fb9-19a2-452a-8457-8ddfb17a8ebd', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('368b0f27-4ea5-42a5-9106-1c5f5a465f15', 'e6ecf20f-dea3-4a87-94e8-64407dd388d2', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('a5011f09-4fa6-4945-9dbd-4ec42b4ba275', '406ba062-512b-4c7b-8e5f-e1eb3d94cb6e', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('982b53c6-bd7e-4a73-bede-d275f0665f7e', '4e196206-63ec-45c1-9d94-433774f78a92', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('66302fb4-2302-4d92-9c8f-4770c8a95258', '8e95b61a-72e5-11ed-a1eb-0242ac120002', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('bea872f8-c548-47dc-aa4b-4bb5308442d7', '498c09ac-960d-452f-b67d-76b89e7efdac', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('3e056754-dfc5-47cb-b98e-e7d986e87341', 'b09cc98e-4ec9-42a0-8cfb-f423e6913728', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('2280abf5-058e-4ba1-9672-c8f2eafb026c', '23fa7af5-ac10-4237-8058-f7a1e8709fa8', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('6eece99e-e356-4392-be25-de7cdeba6434', '5b1b3cc9-79fb-422b-b781-ad952f4a5f23', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('1d70d7db-aea6-4c87-b00d-a033a6741855', 'a1373364-817e-41c3-b833-a9354dd3274f', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('c58180b7-fe28-4e49-bd77-9af7bb844e7b', '9ce1c7a6-1f8e-4337-9272-453eaf60276f', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('d5800b4d-2fa8-4cf9-80cc-47ea5420bc24', '13dee731-03d9-40bc-b48f-59cdfc8545d4', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('7876fe2e-ec53-4362-9d5c-0b923af4466c', '51caf4f1-2095-47fb-b66d-99a7db2418de', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('09895fc0-72e1-11ed-a1eb-0242ac120002', '78e5d4b3-2c19-47d9-9d73-8a662ca52d99', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('0e3162f2-72e1-11ed-a1eb-0242ac120002', '920b5857-7e55-4a2f-930d-98e8105930eb', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000),
+       ('1260284a-72e1-11ed-a1eb-0242ac120002', 'ec55a8af-b02c-4550-96ef-44d7b8bffea0', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 4, 'sbux', 1000000, 1, 100, 5000);
 
 insert into "installments" (installment_id, lending_id, installment_status_id, amount, due_date)
 VALUES ('897fa8c4-72e1-11ed-a1eb-0242ac120002', 'fb5ab385-20a1-4c87-8e4b-900507838d86', 2, 1000000, current_timestamp),
@@ -399,4 +199,4 @@ VALUES ('3f286a92-72e4-11ed-a1eb-0242ac120002', 'end year 1%', 1, 1, current_tim
        ('f12b17ee-72e4-11ed-a1eb-0242ac120002', 'end year 19%', 19, 1, current_timestamp,
         '2022-12-31 23:59:59.999 +0700'),
        ('f45ff1a0-72e4-11ed-a1eb-0242ac120002', 'end year 20%', 20, 1, current_timestamp,
-        '2022-12-31 23:59:59.999 +0700');
\ No newline at end of file
+        '2022-12-31 23:59:59.999 +0700');
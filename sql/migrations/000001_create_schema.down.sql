@@ -0,0 +1,43 @@
+DROP TABLE IF EXISTS "admin_actions" CASCADE;
+DROP TABLE IF EXISTS "rejection_reasons" CASCADE;
+DROP TABLE IF EXISTS "notifications" CASCADE;
+DROP TABLE IF EXISTS "guarantor_status_types" CASCADE;
+DROP TABLE IF EXISTS "guarantors" CASCADE;
+DROP TABLE IF EXISTS "kyc_status_types" CASCADE;
+DROP TABLE IF EXISTS "kyc_submissions" CASCADE;
+DROP TABLE IF EXISTS "credit_health_event_types" CASCADE;
+DROP TABLE IF EXISTS "credit_health_histories" CASCADE;
+DROP TABLE IF EXISTS "credit_limit_proposal_status_types" CASCADE;
+DROP TABLE IF EXISTS "credit_limit_proposals" CASCADE;
+DROP TABLE IF EXISTS "restructuring_status_types" CASCADE;
+DROP TABLE IF EXISTS "restructurings" CASCADE;
+DROP TABLE IF EXISTS "write_offs" CASCADE;
+DROP TABLE IF EXISTS "promise_to_pays" CASCADE;
+DROP TABLE IF EXISTS "collection_notes" CASCADE;
+DROP TABLE IF EXISTS "collection_cases" CASCADE;
+DROP TABLE IF EXISTS "payment_reversals" CASCADE;
+DROP TABLE IF EXISTS "reconciliation_status_types" CASCADE;
+DROP TABLE IF EXISTS "reconciliation_items" CASCADE;
+DROP TABLE IF EXISTS "statement_imports" CASCADE;
+DROP TABLE IF EXISTS "payment_callbacks" CASCADE;
+DROP TABLE IF EXISTS "payment_intent_status_types" CASCADE;
+DROP TABLE IF EXISTS "payment_intents" CASCADE;
+DROP TABLE IF EXISTS "voucher_redemptions" CASCADE;
+DROP TABLE IF EXISTS "voucher_codes" CASCADE;
+DROP TABLE IF EXISTS "voucher_loan_products" CASCADE;
+DROP TABLE IF EXISTS "voucher_credit_healths" CASCADE;
+DROP TABLE IF EXISTS "voucher_status_types" CASCADE;
+DROP TABLE IF EXISTS "voucher_discount_types" CASCADE;
+DROP TABLE IF EXISTS "vouchers" CASCADE;
+DROP TABLE IF EXISTS "payments" CASCADE;
+DROP TABLE IF EXISTS "installment_status_types" CASCADE;
+DROP TABLE IF EXISTS "installments" CASCADE;
+DROP TABLE IF EXISTS "lending_status_types" CASCADE;
+DROP TABLE IF EXISTS "loan_product_credit_healths" CASCADE;
+DROP TABLE IF EXISTS "loan_products" CASCADE;
+DROP TABLE IF EXISTS "lendings" CASCADE;
+DROP TABLE IF EXISTS "contract_tracking_types" CASCADE;
+DROP TABLE IF EXISTS "credit_health_types" CASCADE;
+DROP TABLE IF EXISTS "debtors" CASCADE;
+DROP TABLE IF EXISTS "roles" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
@@ -0,0 +1,685 @@
+CREATE TABLE "users"
+(
+    "user_id"      UUID PRIMARY KEY NOT NULL,
+    "role_id"      int              NOT NULL,
+    "name"         VARCHAR          NOT NULL,
+    "phone_number" VARCHAR          NOT NULL,
+    "address"      TEXT             NOT NULL,
+    "email"        VARCHAR UNIQUE   NOT NULL,
+    "password"     VARCHAR          NOT NULL,
+    "created_at"   timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"   timestamptz
+);
+
+CREATE TABLE "roles"
+(
+    "role_id"    serial PRIMARY KEY NOT NULL,
+    "name"       VARCHAR            NOT NULL,
+    "created_at" timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at" timestamptz
+);
+
+CREATE TABLE "debtors"
+(
+    "debtor_id"            UUID PRIMARY KEY NOT NULL,
+    "user_id"              UUID             NOT NULL,
+    "credit_health_id"     int              NOT NULL,
+    "contract_tracking_id" int              NOT NULL,
+    "kyc_status_id"        int              NOT NULL DEFAULT 1,
+    "credit_limit"         FLOAT            NOT NULL DEFAULT 0,
+    "credit_used"          FLOAT            NOT NULL DEFAULT 0,
+    "total_delay"          int              NOT NULL DEFAULT 0,
+    "created_at"           timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"           timestamptz
+);
+
+CREATE TABLE "credit_health_types"
+(
+    "credit_health_id" serial PRIMARY KEY NOT NULL,
+    "name"             VARCHAR            NOT NULL,
+    "created_at"       timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"       timestamptz
+);
+
+CREATE TABLE "contract_tracking_types"
+(
+    "contract_tracking_id" serial PRIMARY KEY NOT NULL,
+    "name"                 VARCHAR            NOT NULL,
+    "created_at"           timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"           timestamptz
+);
+
+CREATE TABLE "lendings"
+(
+    "lending_id"          UUID PRIMARY KEY NOT NULL,
+    "debtor_id"           UUID             NOT NULL,
+    "loan_product_id"     UUID             NOT NULL,
+    "lending_status_id"   int              NOT NULL,
+    "name"                VARCHAR          NOT NULL,
+    "amount"              float            NOT NULL,
+    "duration"            int              NOT NULL,
+    "percentage"          int              NOT NULL,
+    "fine_per_day"        float            NOT NULL,
+    "approved_at"         timestamptz,
+    "cancel_reason"       VARCHAR,
+    "cancelled_at"        timestamptz,
+    "rejection_reason_id" int,
+    "rejection_note"      TEXT,
+    "rejected_at"         timestamptz,
+    "created_at"          timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"          timestamptz
+);
+
+CREATE TABLE "loan_products"
+(
+    "loan_product_id" UUID PRIMARY KEY NOT NULL,
+    "name"            VARCHAR          NOT NULL,
+    "duration"        int              NOT NULL,
+    "percentage"      int              NOT NULL,
+    "fine_per_day"    float            NOT NULL,
+    "min_amount"      float            NOT NULL,
+    "max_amount"      float            NOT NULL,
+    "active_date"     timestamptz      NOT NULL,
+    "expire_date"     timestamptz      NOT NULL,
+    "created_at"      timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"      timestamptz,
+    "deleted_at"      timestamptz
+);
+
+CREATE TABLE "loan_product_credit_healths"
+(
+    "loan_product_id"  UUID NOT NULL,
+    "credit_health_id" int  NOT NULL,
+    PRIMARY KEY ("loan_product_id", "credit_health_id")
+);
+
+CREATE TABLE "lending_status_types"
+(
+    "lending_status_id" serial PRIMARY KEY NOT NULL,
+    "name"              VARCHAR            NOT NULL,
+    "created_at"        timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"        timestamptz
+);
+
+CREATE TABLE "installments"
+(
+    "installment_id"        UUID PRIMARY KEY NOT NULL,
+    "lending_id"            UUID             NOT NULL,
+    "installment_status_id" int              NOT NULL,
+    "restructuring_id"      UUID,
+    "superseded_by_id"      UUID,
+    "amount"                float            NOT NULL,
+    "due_date"              timestamptz      NOT NULL,
+    "created_at"            timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"            timestamptz
+);
+
+CREATE TABLE "installment_status_types"
+(
+    "installment_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                  VARCHAR            NOT NULL,
+    "created_at"            timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"            timestamptz
+);
+
+CREATE TABLE "payments"
+(
+    "payment_id"          UUID PRIMARY KEY NOT NULL,
+    "installment_id"      UUID             NOT NULL,
+    "voucher_id"          UUID,
+    "payment_intent_id"   UUID,
+    "payment_fine"        float            NOT NULL DEFAULT 0,
+    "payment_discount"    float            NOT NULL DEFAULT 0,
+    "payment_fine_waiver" float            NOT NULL DEFAULT 0,
+    "payment_amount"      float            NOT NULL,
+    "payment_date"        timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "vouchers"
+(
+    "voucher_id"               UUID PRIMARY KEY NOT NULL,
+    "name"                     VARCHAR          NOT NULL,
+    "voucher_status_id"        int              NOT NULL DEFAULT 1,
+    "voucher_discount_type_id" int              NOT NULL DEFAULT 1,
+    "discount_payment"         int              NOT NULL DEFAULT 0,
+    "discount_amount"          float            NOT NULL DEFAULT 0,
+    "max_discount"             float            NOT NULL DEFAULT 0,
+    "discount_quota"           int              NOT NULL,
+    "per_user_limit"           int              NOT NULL DEFAULT 0,
+    "min_installment_amount"   float            NOT NULL DEFAULT 0,
+    "code_required"            boolean          NOT NULL DEFAULT false,
+    "active_date"              timestamptz      NOT NULL,
+    "expire_date"              timestamptz      NOT NULL,
+    "created_at"               timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz,
+    "archived_at"              timestamptz
+);
+
+CREATE TABLE "voucher_discount_types"
+(
+    "voucher_discount_type_id" serial PRIMARY KEY NOT NULL,
+    "name"                     VARCHAR            NOT NULL,
+    "created_at"               timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz
+);
+
+CREATE TABLE "voucher_status_types"
+(
+    "voucher_status_id" serial PRIMARY KEY NOT NULL,
+    "name"              VARCHAR            NOT NULL,
+    "created_at"        timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"        timestamptz
+);
+
+CREATE TABLE "voucher_credit_healths"
+(
+    "voucher_id"       UUID NOT NULL,
+    "credit_health_id" int  NOT NULL,
+    PRIMARY KEY ("voucher_id", "credit_health_id")
+);
+
+CREATE TABLE "voucher_loan_products"
+(
+    "voucher_id"      UUID NOT NULL,
+    "loan_product_id" UUID NOT NULL,
+    PRIMARY KEY ("voucher_id", "loan_product_id")
+);
+
+CREATE TABLE "voucher_codes"
+(
+    "voucher_code_id" UUID PRIMARY KEY NOT NULL,
+    "voucher_id"      UUID             NOT NULL,
+    "code"            VARCHAR UNIQUE   NOT NULL,
+    "single_use"      boolean          NOT NULL DEFAULT false,
+    "redeemed_at"     timestamptz,
+    "created_at"      timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "voucher_redemptions"
+(
+    "voucher_redemption_id" UUID PRIMARY KEY NOT NULL,
+    "voucher_id"            UUID             NOT NULL,
+    "voucher_code_id"       UUID,
+    "debtor_id"             UUID             NOT NULL,
+    "payment_id"            UUID UNIQUE      NOT NULL,
+    "created_at"            timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "payment_intents"
+(
+    "payment_intent_id"        UUID PRIMARY KEY NOT NULL,
+    "installment_id"           UUID             NOT NULL,
+    "voucher_id"               UUID,
+    "voucher_code_id"          UUID,
+    "payment_intent_status_id" int              NOT NULL,
+    "provider"                 VARCHAR          NOT NULL,
+    "channel"                  VARCHAR          NOT NULL,
+    "external_id"              VARCHAR UNIQUE   NOT NULL,
+    "account_number"           VARCHAR          NOT NULL,
+    "payment_fine"             float            NOT NULL DEFAULT 0,
+    "payment_discount"         float            NOT NULL DEFAULT 0,
+    "payment_fine_waiver"      float            NOT NULL DEFAULT 0,
+    "payment_amount"           float            NOT NULL,
+    "expire_date"              timestamptz      NOT NULL,
+    "paid_at"                  timestamptz,
+    "created_at"               timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz
+);
+
+CREATE TABLE "payment_intent_status_types"
+(
+    "payment_intent_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                     VARCHAR            NOT NULL,
+    "created_at"               timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz
+);
+
+CREATE TABLE "payment_callbacks"
+(
+    "payment_callback_id" UUID PRIMARY KEY NOT NULL,
+    "payment_intent_id"   UUID,
+    "provider"            VARCHAR          NOT NULL,
+    "event_id"            VARCHAR          NOT NULL,
+    "payload"             TEXT             NOT NULL,
+    "created_at"          timestamptz      NOT NULL DEFAULT (NOW()),
+    UNIQUE ("provider", "event_id")
+);
+
+CREATE TABLE "statement_imports"
+(
+    "statement_import_id" UUID PRIMARY KEY NOT NULL,
+    "file_name"           VARCHAR          NOT NULL,
+    "format"              VARCHAR          NOT NULL,
+    "total_lines"         int              NOT NULL DEFAULT 0,
+    "matched_lines"       int              NOT NULL DEFAULT 0,
+    "unmatched_lines"     int              NOT NULL DEFAULT 0,
+    "duplicate_lines"     int              NOT NULL DEFAULT 0,
+    "created_at"          timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"          timestamptz
+);
+
+CREATE TABLE "reconciliation_items"
+(
+    "reconciliation_item_id"   UUID PRIMARY KEY NOT NULL,
+    "statement_import_id"      UUID             NOT NULL,
+    "reconciliation_status_id" int              NOT NULL,
+    "payment_intent_id"        UUID,
+    "payment_id"               UUID,
+    "line_number"              int              NOT NULL,
+    "line_hash"                VARCHAR UNIQUE   NOT NULL,
+    "transaction_date"         timestamptz      NOT NULL,
+    "reference"                VARCHAR          NOT NULL,
+    "description"              TEXT             NOT NULL,
+    "amount"                   float            NOT NULL,
+    "note"                     TEXT             NOT NULL DEFAULT '',
+    "resolved_at"              timestamptz,
+    "created_at"               timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz
+);
+
+CREATE TABLE "reconciliation_status_types"
+(
+    "reconciliation_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                     VARCHAR            NOT NULL,
+    "created_at"               timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"               timestamptz
+);
+
+CREATE TABLE "payment_reversals"
+(
+    "payment_reversal_id"  UUID PRIMARY KEY NOT NULL,
+    "payment_id"           UUID UNIQUE      NOT NULL,
+    "installment_id"       UUID             NOT NULL,
+    "reason"               TEXT             NOT NULL,
+    "reversed_fine"        float            NOT NULL DEFAULT 0,
+    "reversed_discount"    float            NOT NULL DEFAULT 0,
+    "reversed_fine_waiver" float            NOT NULL DEFAULT 0,
+    "reversed_amount"      float            NOT NULL,
+    "created_at"           timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "collection_cases"
+(
+    "collection_case_id" UUID PRIMARY KEY NOT NULL,
+    "lending_id"         UUID UNIQUE      NOT NULL,
+    "collector_id"       UUID,
+    "assigned_at"        timestamptz,
+    "created_at"         timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"         timestamptz
+);
+
+CREATE TABLE "collection_notes"
+(
+    "collection_note_id" UUID PRIMARY KEY NOT NULL,
+    "collection_case_id" UUID             NOT NULL,
+    "user_id"            UUID             NOT NULL,
+    "channel"            VARCHAR          NOT NULL,
+    "note"               TEXT             NOT NULL,
+    "created_at"         timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "promise_to_pays"
+(
+    "promise_to_pay_id"  UUID PRIMARY KEY NOT NULL,
+    "collection_case_id" UUID             NOT NULL,
+    "user_id"            UUID             NOT NULL,
+    "amount"             float            NOT NULL,
+    "promise_date"       timestamptz      NOT NULL,
+    "created_at"         timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "write_offs"
+(
+    "write_off_id"   UUID PRIMARY KEY NOT NULL,
+    "lending_id"     UUID UNIQUE      NOT NULL,
+    "user_id"        UUID             NOT NULL,
+    "reason"         TEXT             NOT NULL,
+    "principal_loss" float            NOT NULL DEFAULT 0,
+    "fine_loss"      float            NOT NULL DEFAULT 0,
+    "created_at"     timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "restructurings"
+(
+    "restructuring_id"        UUID PRIMARY KEY NOT NULL,
+    "lending_id"              UUID             NOT NULL,
+    "restructuring_status_id" int              NOT NULL,
+    "user_id"                 UUID             NOT NULL,
+    "version"                 int              NOT NULL,
+    "tenor"                   int              NOT NULL,
+    "capitalize_fines"        boolean          NOT NULL DEFAULT false,
+    "outstanding_amount"      float            NOT NULL,
+    "capitalized_fine"        float            NOT NULL DEFAULT 0,
+    "total_amount"            float            NOT NULL,
+    "installment_amount"      float            NOT NULL,
+    "first_due_date"          timestamptz      NOT NULL,
+    "responded_at"            timestamptz,
+    "created_at"              timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"              timestamptz
+);
+
+CREATE TABLE "restructuring_status_types"
+(
+    "restructuring_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                    VARCHAR            NOT NULL,
+    "created_at"              timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"              timestamptz
+);
+
+CREATE TABLE "credit_limit_proposals"
+(
+    "credit_limit_proposal_id"        UUID PRIMARY KEY NOT NULL,
+    "debtor_id"                       UUID             NOT NULL,
+    "credit_limit_proposal_status_id" int              NOT NULL,
+    "user_id"                         UUID,
+    "rules_version"                   VARCHAR          NOT NULL,
+    "score"                           int              NOT NULL,
+    "current_limit"                   float            NOT NULL,
+    "proposed_limit"                  float            NOT NULL,
+    "responded_at"                    timestamptz,
+    "created_at"                      timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"                      timestamptz
+);
+
+CREATE TABLE "credit_limit_proposal_status_types"
+(
+    "credit_limit_proposal_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                            VARCHAR            NOT NULL,
+    "created_at"                      timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"                      timestamptz
+);
+
+CREATE TABLE "credit_health_histories"
+(
+    "credit_health_history_id"  UUID PRIMARY KEY NOT NULL,
+    "debtor_id"                 UUID             NOT NULL,
+    "credit_health_event_id"    int              NOT NULL,
+    "previous_credit_health_id" int              NOT NULL,
+    "credit_health_id"          int              NOT NULL,
+    "previous_total_delay"      int              NOT NULL DEFAULT 0,
+    "total_delay"               int              NOT NULL DEFAULT 0,
+    "delay_days"                int              NOT NULL DEFAULT 0,
+    "reference_id"              UUID,
+    "user_id"                   UUID,
+    "created_at"                timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "credit_health_event_types"
+(
+    "credit_health_event_id" serial PRIMARY KEY NOT NULL,
+    "name"                   VARCHAR            NOT NULL,
+    "created_at"             timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"             timestamptz
+);
+
+CREATE TABLE "kyc_submissions"
+(
+    "kyc_submission_id" UUID PRIMARY KEY NOT NULL,
+    "debtor_id"         UUID             NOT NULL,
+    "kyc_status_id"     int              NOT NULL,
+    "id_card_key"       VARCHAR          NOT NULL,
+    "selfie_key"        VARCHAR          NOT NULL,
+    "reject_reason"     TEXT             NOT NULL DEFAULT '',
+    "user_id"           UUID,
+    "reviewed_at"       timestamptz,
+    "created_at"        timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"        timestamptz
+);
+
+CREATE TABLE "kyc_status_types"
+(
+    "kyc_status_id" serial PRIMARY KEY NOT NULL,
+    "name"          VARCHAR            NOT NULL,
+    "created_at"    timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"    timestamptz
+);
+
+CREATE TABLE "guarantors"
+(
+    "guarantor_id"        UUID PRIMARY KEY NOT NULL,
+    "lending_id"          UUID             NOT NULL,
+    "user_id"             UUID             NOT NULL,
+    "guarantor_status_id" int              NOT NULL,
+    "responded_at"        timestamptz,
+    "created_at"          timestamptz      NOT NULL DEFAULT (NOW()),
+    "updated_at"          timestamptz
+);
+
+CREATE TABLE "guarantor_status_types"
+(
+    "guarantor_status_id" serial PRIMARY KEY NOT NULL,
+    "name"                VARCHAR            NOT NULL,
+    "created_at"          timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"          timestamptz
+);
+
+CREATE TABLE "notifications"
+(
+    "notification_id" UUID PRIMARY KEY NOT NULL,
+    "user_id"         UUID             NOT NULL,
+    "title"           VARCHAR          NOT NULL,
+    "message"         TEXT             NOT NULL,
+    "reference_id"    UUID,
+    "created_at"      timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+CREATE TABLE "rejection_reasons"
+(
+    "rejection_reason_id" serial PRIMARY KEY NOT NULL,
+    "code"                VARCHAR UNIQUE     NOT NULL,
+    "name"                VARCHAR            NOT NULL,
+    "is_active"           boolean            NOT NULL DEFAULT true,
+    "created_at"          timestamptz        NOT NULL DEFAULT (NOW()),
+    "updated_at"          timestamptz
+);
+
+CREATE TABLE "admin_actions"
+(
+    "admin_action_id" UUID PRIMARY KEY NOT NULL,
+    "user_id"         UUID             NOT NULL,
+    "action"          VARCHAR          NOT NULL,
+    "target_id"       UUID             NOT NULL,
+    "detail"          TEXT             NOT NULL,
+    "created_at"      timestamptz      NOT NULL DEFAULT (NOW())
+);
+
+ALTER TABLE "debtors"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "users"
+    ADD FOREIGN KEY ("role_id") REFERENCES "roles" ("role_id");
+
+ALTER TABLE "lendings"
+    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
+
+ALTER TABLE "debtors"
+    ADD FOREIGN KEY ("credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
+
+ALTER TABLE "debtors"
+    ADD FOREIGN KEY ("contract_tracking_id") REFERENCES "contract_tracking_types" ("contract_tracking_id");
+
+ALTER TABLE "installments"
+    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
+
+ALTER TABLE "lendings"
+    ADD FOREIGN KEY ("lending_status_id") REFERENCES "lending_status_types" ("lending_status_id");
+
+ALTER TABLE "lendings"
+    ADD FOREIGN KEY ("loan_product_id") REFERENCES "loan_products" ("loan_product_id");
+
+ALTER TABLE "loan_product_credit_healths"
+    ADD FOREIGN KEY ("loan_product_id") REFERENCES "loan_products" ("loan_product_id");
+
+ALTER TABLE "loan_product_credit_healths"
+    ADD FOREIGN KEY ("credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
+
+ALTER TABLE "payments"
+    ADD FOREIGN KEY ("installment_id") REFERENCES "installments" ("installment_id");
+
+ALTER TABLE "installments"
+    ADD FOREIGN KEY ("installment_status_id") REFERENCES "installment_status_types" ("installment_status_id");
+
+ALTER TABLE "payments"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "payments"
+    ADD FOREIGN KEY ("payment_intent_id") REFERENCES "payment_intents" ("payment_intent_id");
+
+ALTER TABLE "payment_intents"
+    ADD FOREIGN KEY ("installment_id") REFERENCES "installments" ("installment_id");
+
+ALTER TABLE "payment_intents"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "payment_intents"
+    ADD FOREIGN KEY ("voucher_code_id") REFERENCES "voucher_codes" ("voucher_code_id");
+
+ALTER TABLE "payment_intents"
+    ADD FOREIGN KEY ("payment_intent_status_id") REFERENCES "payment_intent_status_types" ("payment_intent_status_id");
+
+ALTER TABLE "payment_callbacks"
+    ADD FOREIGN KEY ("payment_intent_id") REFERENCES "payment_intents" ("payment_intent_id");
+
+ALTER TABLE "reconciliation_items"
+    ADD FOREIGN KEY ("statement_import_id") REFERENCES "statement_imports" ("statement_import_id");
+
+ALTER TABLE "reconciliation_items"
+    ADD FOREIGN KEY ("reconciliation_status_id") REFERENCES "reconciliation_status_types" ("reconciliation_status_id");
+
+ALTER TABLE "reconciliation_items"
+    ADD FOREIGN KEY ("payment_intent_id") REFERENCES "payment_intents" ("payment_intent_id");
+
+ALTER TABLE "reconciliation_items"
+    ADD FOREIGN KEY ("payment_id") REFERENCES "payments" ("payment_id");
+
+ALTER TABLE "payment_reversals"
+    ADD FOREIGN KEY ("payment_id") REFERENCES "payments" ("payment_id");
+
+ALTER TABLE "payment_reversals"
+    ADD FOREIGN KEY ("installment_id") REFERENCES "installments" ("installment_id");
+
+ALTER TABLE "collection_cases"
+    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
+
+ALTER TABLE "collection_cases"
+    ADD FOREIGN KEY ("collector_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "collection_notes"
+    ADD FOREIGN KEY ("collection_case_id") REFERENCES "collection_cases" ("collection_case_id");
+
+ALTER TABLE "collection_notes"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "promise_to_pays"
+    ADD FOREIGN KEY ("collection_case_id") REFERENCES "collection_cases" ("collection_case_id");
+
+ALTER TABLE "promise_to_pays"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "write_offs"
+    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
+
+ALTER TABLE "write_offs"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "restructurings"
+    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
+
+ALTER TABLE "restructurings"
+    ADD FOREIGN KEY ("restructuring_status_id") REFERENCES "restructuring_status_types" ("restructuring_status_id");
+
+ALTER TABLE "restructurings"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "installments"
+    ADD FOREIGN KEY ("restructuring_id") REFERENCES "restructurings" ("restructuring_id");
+
+ALTER TABLE "installments"
+    ADD FOREIGN KEY ("superseded_by_id") REFERENCES "restructurings" ("restructuring_id");
+
+ALTER TABLE "credit_limit_proposals"
+    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
+
+ALTER TABLE "credit_limit_proposals"
+    ADD FOREIGN KEY ("credit_limit_proposal_status_id") REFERENCES "credit_limit_proposal_status_types" ("credit_limit_proposal_status_id");
+
+ALTER TABLE "credit_limit_proposals"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "credit_health_histories"
+    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
+
+ALTER TABLE "credit_health_histories"
+    ADD FOREIGN KEY ("credit_health_event_id") REFERENCES "credit_health_event_types" ("credit_health_event_id");
+
+ALTER TABLE "credit_health_histories"
+    ADD FOREIGN KEY ("previous_credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
+
+ALTER TABLE "credit_health_histories"
+    ADD FOREIGN KEY ("credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
+
+ALTER TABLE "credit_health_histories"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "debtors"
+    ADD FOREIGN KEY ("kyc_status_id") REFERENCES "kyc_status_types" ("kyc_status_id");
+
+ALTER TABLE "kyc_submissions"
+    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
+
+ALTER TABLE "kyc_submissions"
+    ADD FOREIGN KEY ("kyc_status_id") REFERENCES "kyc_status_types" ("kyc_status_id");
+
+ALTER TABLE "kyc_submissions"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "guarantors"
+    ADD FOREIGN KEY ("lending_id") REFERENCES "lendings" ("lending_id");
+
+ALTER TABLE "guarantors"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "guarantors"
+    ADD FOREIGN KEY ("guarantor_status_id") REFERENCES "guarantor_status_types" ("guarantor_status_id");
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "lendings"
+    ADD FOREIGN KEY ("rejection_reason_id") REFERENCES "rejection_reasons" ("rejection_reason_id");
+
+ALTER TABLE "admin_actions"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("user_id");
+
+ALTER TABLE "vouchers"
+    ADD FOREIGN KEY ("voucher_status_id") REFERENCES "voucher_status_types" ("voucher_status_id");
+
+ALTER TABLE "vouchers"
+    ADD FOREIGN KEY ("voucher_discount_type_id") REFERENCES "voucher_discount_types" ("voucher_discount_type_id");
+
+ALTER TABLE "voucher_credit_healths"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "voucher_credit_healths"
+    ADD FOREIGN KEY ("credit_health_id") REFERENCES "credit_health_types" ("credit_health_id");
+
+ALTER TABLE "voucher_loan_products"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "voucher_loan_products"
+    ADD FOREIGN KEY ("loan_product_id") REFERENCES "loan_products" ("loan_product_id");
+
+ALTER TABLE "voucher_codes"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "voucher_redemptions"
+    ADD FOREIGN KEY ("voucher_id") REFERENCES "vouchers" ("voucher_id");
+
+ALTER TABLE "voucher_redemptions"
+    ADD FOREIGN KEY ("voucher_code_id") REFERENCES "voucher_codes" ("voucher_code_id");
+
+ALTER TABLE "voucher_redemptions"
+    ADD FOREIGN KEY ("debtor_id") REFERENCES "debtors" ("debtor_id");
+
+ALTER TABLE "voucher_redemptions"
+    ADD FOREIGN KEY ("payment_id") REFERENCES "payments" ("payment_id");
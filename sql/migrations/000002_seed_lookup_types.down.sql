@@ -0,0 +1,2 @@
+-- Lookup rows are referenced by nearly every table and are dropped with the
+-- schema in 000001, so reverting the seed keeps them.
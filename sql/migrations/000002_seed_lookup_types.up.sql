@@ -0,0 +1,124 @@
+INSERT INTO "roles" (role_id, name)
+VALUES (1, 'admin'),
+       (2, 'user'),
+       (3, 'collector')
+ON CONFLICT (role_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('roles', 'role_id'), (SELECT max(role_id) FROM "roles"));
+
+INSERT INTO "credit_health_types" (credit_health_id, name)
+VALUES (1, 'good'),
+       (2, 'warning'),
+       (3, 'blocked')
+ON CONFLICT (credit_health_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('credit_health_types', 'credit_health_id'), (SELECT max(credit_health_id) FROM "credit_health_types"));
+
+INSERT INTO "contract_tracking_types" (contract_tracking_id, name)
+VALUES (1, 'no contract yet'),
+       (2, 'the contract was given to the expedition partner'),
+       (3, 'contract in delivery'),
+       (4, 'contract accepted by user'),
+       (5, 'confirmed contract')
+ON CONFLICT (contract_tracking_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('contract_tracking_types', 'contract_tracking_id'), (SELECT max(contract_tracking_id) FROM "contract_tracking_types"));
+
+INSERT INTO "lending_status_types" (lending_status_id, name)
+VALUES (1, 'new'),
+       (2, 'approved'),
+       (3, 'on progress'),
+       (4, 'paid'),
+       (5, 'reject'),
+       (6, 'written off'),
+       (7, 'cancelled')
+ON CONFLICT (lending_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('lending_status_types', 'lending_status_id'), (SELECT max(lending_status_id) FROM "lending_status_types"));
+
+INSERT INTO "installment_status_types" (installment_status_id, name)
+VALUES (1, 'on progress'),
+       (2, 'paid'),
+       (3, 'restructured')
+ON CONFLICT (installment_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('installment_status_types', 'installment_status_id'), (SELECT max(installment_status_id) FROM "installment_status_types"));
+
+INSERT INTO "payment_intent_status_types" (payment_intent_status_id, name)
+VALUES (1, 'pending'),
+       (2, 'paid'),
+       (3, 'expired'),
+       (4, 'reversed')
+ON CONFLICT (payment_intent_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('payment_intent_status_types', 'payment_intent_status_id'), (SELECT max(payment_intent_status_id) FROM "payment_intent_status_types"));
+
+INSERT INTO "restructuring_status_types" (restructuring_status_id, name)
+VALUES (1, 'pending'),
+       (2, 'accepted'),
+       (3, 'declined')
+ON CONFLICT (restructuring_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('restructuring_status_types', 'restructuring_status_id'), (SELECT max(restructuring_status_id) FROM "restructuring_status_types"));
+
+INSERT INTO "credit_limit_proposal_status_types" (credit_limit_proposal_status_id, name)
+VALUES (1, 'pending'),
+       (2, 'approved'),
+       (3, 'rejected')
+ON CONFLICT (credit_limit_proposal_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('credit_limit_proposal_status_types', 'credit_limit_proposal_status_id'), (SELECT max(credit_limit_proposal_status_id) FROM "credit_limit_proposal_status_types"));
+
+INSERT INTO "credit_health_event_types" (credit_health_event_id, name)
+VALUES (1, 'payment'),
+       (2, 'payment reversal'),
+       (3, 'admin override'),
+       (4, 'write off')
+ON CONFLICT (credit_health_event_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('credit_health_event_types', 'credit_health_event_id'), (SELECT max(credit_health_event_id) FROM "credit_health_event_types"));
+
+INSERT INTO "kyc_status_types" (kyc_status_id, name)
+VALUES (1, 'not submitted'),
+       (2, 'pending'),
+       (3, 'approved'),
+       (4, 'rejected')
+ON CONFLICT (kyc_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('kyc_status_types', 'kyc_status_id'), (SELECT max(kyc_status_id) FROM "kyc_status_types"));
+
+INSERT INTO "guarantor_status_types" (guarantor_status_id, name)
+VALUES (1, 'invited'),
+       (2, 'accepted'),
+       (3, 'declined')
+ON CONFLICT (guarantor_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('guarantor_status_types', 'guarantor_status_id'), (SELECT max(guarantor_status_id) FROM "guarantor_status_types"));
+
+INSERT INTO "voucher_discount_types" (voucher_discount_type_id, name)
+VALUES (1, 'percentage'),
+       (2, 'fixed amount'),
+       (3, 'fine waiver')
+ON CONFLICT (voucher_discount_type_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('voucher_discount_types', 'voucher_discount_type_id'), (SELECT max(voucher_discount_type_id) FROM "voucher_discount_types"));
+
+INSERT INTO "voucher_status_types" (voucher_status_id, name)
+VALUES (1, 'scheduled'),
+       (2, 'active'),
+       (3, 'exhausted'),
+       (4, 'expired'),
+       (5, 'archived')
+ON CONFLICT (voucher_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('voucher_status_types', 'voucher_status_id'), (SELECT max(voucher_status_id) FROM "voucher_status_types"));
+
+INSERT INTO "reconciliation_status_types" (reconciliation_status_id, name)
+VALUES (1, 'unmatched'),
+       (2, 'ambiguous'),
+       (3, 'matched'),
+       (4, 'resolved'),
+       (5, 'ignored')
+ON CONFLICT (reconciliation_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('reconciliation_status_types', 'reconciliation_status_id'), (SELECT max(reconciliation_status_id) FROM "reconciliation_status_types"));
@@ -0,0 +1,11 @@
+-- Products already used by a loan or a voucher stay.
+DELETE FROM "loan_product_credit_healths"
+WHERE loan_product_id IN ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a03', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a06', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a12', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a18', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a24')
+  AND NOT EXISTS (SELECT 1 FROM "lendings" WHERE lendings.loan_product_id = loan_product_credit_healths.loan_product_id)
+  AND NOT EXISTS (SELECT 1 FROM "voucher_loan_products" WHERE voucher_loan_products.loan_product_id = loan_product_credit_healths.loan_product_id);
+
+DELETE FROM "loan_products"
+WHERE loan_product_id IN ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a03', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a06', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a12', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a18', '0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a24')
+  AND NOT EXISTS (SELECT 1 FROM "lendings" WHERE lendings.loan_product_id = loan_products.loan_product_id)
+  AND NOT EXISTS (SELECT 1 FROM "voucher_loan_products" WHERE voucher_loan_products.loan_product_id = loan_products.loan_product_id)
+  AND NOT EXISTS (SELECT 1 FROM "loan_product_credit_healths" WHERE loan_product_credit_healths.loan_product_id = loan_products.loan_product_id);
@@ -0,0 +1,23 @@
+insert into "loan_products" (loan_product_id, name, duration, percentage, fine_per_day, min_amount, max_amount, active_date, expire_date)
+values ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', '1 month', 1, 100, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07'),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a03', '3 months', 3, 105, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07'),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a06', '6 months', 6, 110, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07'),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a12', '12 months', 12, 120, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07'),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a18', '18 months', 18, 130, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07'),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a24', '24 months', 24, 150, 5000, 1000000, 100000000, '2022-01-01 00:00:00+07', '2099-12-31 23:59:59+07')
+ON CONFLICT (loan_product_id) DO NOTHING;
+
+insert into "loan_product_credit_healths" (loan_product_id, credit_health_id)
+values ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a01', 2),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a03', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a03', 2),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a06', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a06', 2),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a12', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a12', 2),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a18', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a18', 2),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a24', 1),
+       ('0c7c1f9e-5b1d-4f3e-9a61-2d7f4b8e1a24', 2)
+ON CONFLICT DO NOTHING;
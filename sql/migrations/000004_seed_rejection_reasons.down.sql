@@ -0,0 +1,4 @@
+-- Reasons already given for a rejected loan stay.
+DELETE FROM "rejection_reasons"
+WHERE code IN ('insufficient_income', 'high_existing_debt', 'incomplete_documents', 'failed_verification', 'other')
+  AND NOT EXISTS (SELECT 1 FROM "lendings" WHERE lendings.rejection_reason_id = rejection_reasons.rejection_reason_id);
@@ -0,0 +1,7 @@
+insert into "rejection_reasons" (code, name)
+values ('insufficient_income', 'Insufficient income'),
+       ('high_existing_debt', 'High existing debt'),
+       ('incomplete_documents', 'Incomplete documents'),
+       ('failed_verification', 'Failed identity verification'),
+       ('other', 'Other')
+ON CONFLICT (code) DO NOTHING;
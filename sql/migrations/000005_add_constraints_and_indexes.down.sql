@@ -0,0 +1,72 @@
+DROP INDEX IF EXISTS "vouchers_name_trgm_idx";
+DROP INDEX IF EXISTS "loan_products_name_trgm_idx";
+DROP INDEX IF EXISTS "lendings_name_trgm_idx";
+DROP INDEX IF EXISTS "users_email_trgm_idx";
+DROP INDEX IF EXISTS "users_name_trgm_idx";
+DROP INDEX IF EXISTS "voucher_redemptions_voucher_id_debtor_id_idx";
+DROP INDEX IF EXISTS "payment_intents_installment_id_idx";
+DROP INDEX IF EXISTS "payments_payment_date_idx";
+DROP INDEX IF EXISTS "payments_voucher_id_idx";
+DROP INDEX IF EXISTS "payments_installment_id_idx";
+DROP INDEX IF EXISTS "installments_due_date_idx";
+DROP INDEX IF EXISTS "installments_lending_id_idx";
+DROP INDEX IF EXISTS "lendings_lending_status_id_idx";
+DROP INDEX IF EXISTS "lendings_debtor_id_idx";
+DROP INDEX IF EXISTS "debtors_user_id_idx";
+
+ALTER TABLE "statement_imports"
+    DROP CONSTRAINT IF EXISTS "statement_imports_format_check";
+
+ALTER TABLE "collection_notes"
+    DROP CONSTRAINT IF EXISTS "collection_notes_channel_check";
+
+ALTER TABLE "promise_to_pays"
+    DROP CONSTRAINT IF EXISTS "promise_to_pays_amount_check";
+
+ALTER TABLE "write_offs"
+    DROP CONSTRAINT IF EXISTS "write_offs_loss_check";
+
+ALTER TABLE "credit_limit_proposals"
+    DROP CONSTRAINT IF EXISTS "credit_limit_proposals_limit_check";
+
+ALTER TABLE "restructurings"
+    DROP CONSTRAINT IF EXISTS "restructurings_tenor_check",
+    DROP CONSTRAINT IF EXISTS "restructurings_amount_check";
+
+ALTER TABLE "vouchers"
+    DROP CONSTRAINT IF EXISTS "vouchers_discount_payment_check",
+    DROP CONSTRAINT IF EXISTS "vouchers_discount_amount_check",
+    DROP CONSTRAINT IF EXISTS "vouchers_discount_quota_check",
+    DROP CONSTRAINT IF EXISTS "vouchers_per_user_limit_check",
+    DROP CONSTRAINT IF EXISTS "vouchers_min_installment_amount_check",
+    DROP CONSTRAINT IF EXISTS "vouchers_date_check";
+
+ALTER TABLE "payment_reversals"
+    DROP CONSTRAINT IF EXISTS "payment_reversals_amount_check";
+
+ALTER TABLE "payment_intents"
+    DROP CONSTRAINT IF EXISTS "payment_intents_amount_check";
+
+ALTER TABLE "payments"
+    DROP CONSTRAINT IF EXISTS "payments_amount_check";
+
+ALTER TABLE "installments"
+    DROP CONSTRAINT IF EXISTS "installments_amount_check";
+
+ALTER TABLE "lendings"
+    DROP CONSTRAINT IF EXISTS "lendings_amount_check",
+    DROP CONSTRAINT IF EXISTS "lendings_duration_check",
+    DROP CONSTRAINT IF EXISTS "lendings_percentage_check",
+    DROP CONSTRAINT IF EXISTS "lendings_fine_per_day_check";
+
+ALTER TABLE "loan_products"
+    DROP CONSTRAINT IF EXISTS "loan_products_duration_check",
+    DROP CONSTRAINT IF EXISTS "loan_products_percentage_check",
+    DROP CONSTRAINT IF EXISTS "loan_products_fine_per_day_check",
+    DROP CONSTRAINT IF EXISTS "loan_products_amount_check",
+    DROP CONSTRAINT IF EXISTS "loan_products_date_check";
+
+ALTER TABLE "debtors"
+    DROP CONSTRAINT IF EXISTS "debtors_credit_limit_check",
+    DROP CONSTRAINT IF EXISTS "debtors_credit_used_check",
+    DROP CONSTRAINT IF EXISTS "debtors_total_delay_check";
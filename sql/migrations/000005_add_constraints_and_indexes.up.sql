@@ -0,0 +1,79 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "debtors"
+    ADD CONSTRAINT "debtors_credit_limit_check" CHECK ("credit_limit" >= 0),
+    ADD CONSTRAINT "debtors_credit_used_check" CHECK ("credit_used" >= 0 AND "credit_used" <= "credit_limit"),
+    ADD CONSTRAINT "debtors_total_delay_check" CHECK ("total_delay" >= 0);
+
+ALTER TABLE "loan_products"
+    ADD CONSTRAINT "loan_products_duration_check" CHECK ("duration" > 0),
+    ADD CONSTRAINT "loan_products_percentage_check" CHECK ("percentage" >= 0),
+    ADD CONSTRAINT "loan_products_fine_per_day_check" CHECK ("fine_per_day" >= 0),
+    ADD CONSTRAINT "loan_products_amount_check" CHECK ("min_amount" >= 0 AND "max_amount" >= "min_amount"),
+    ADD CONSTRAINT "loan_products_date_check" CHECK ("expire_date" >= "active_date");
+
+ALTER TABLE "lendings"
+    ADD CONSTRAINT "lendings_amount_check" CHECK ("amount" > 0),
+    ADD CONSTRAINT "lendings_duration_check" CHECK ("duration" > 0),
+    ADD CONSTRAINT "lendings_percentage_check" CHECK ("percentage" >= 0),
+    ADD CONSTRAINT "lendings_fine_per_day_check" CHECK ("fine_per_day" >= 0);
+
+ALTER TABLE "installments"
+    ADD CONSTRAINT "installments_amount_check" CHECK ("amount" >= 0);
+
+ALTER TABLE "payments"
+    ADD CONSTRAINT "payments_amount_check" CHECK ("payment_amount" >= 0 AND "payment_fine" >= 0 AND
+                                                  "payment_discount" >= 0 AND "payment_fine_waiver" >= 0);
+
+ALTER TABLE "payment_intents"
+    ADD CONSTRAINT "payment_intents_amount_check" CHECK ("payment_amount" >= 0 AND "payment_fine" >= 0 AND
+                                                         "payment_discount" >= 0 AND "payment_fine_waiver" >= 0);
+
+ALTER TABLE "payment_reversals"
+    ADD CONSTRAINT "payment_reversals_amount_check" CHECK ("reversed_amount" >= 0 AND "reversed_fine" >= 0 AND
+                                                           "reversed_discount" >= 0 AND "reversed_fine_waiver" >= 0);
+
+ALTER TABLE "vouchers"
+    ADD CONSTRAINT "vouchers_discount_payment_check" CHECK ("discount_payment" BETWEEN 0 AND 100),
+    ADD CONSTRAINT "vouchers_discount_amount_check" CHECK ("discount_amount" >= 0 AND "max_discount" >= 0),
+    ADD CONSTRAINT "vouchers_discount_quota_check" CHECK ("discount_quota" >= 0),
+    ADD CONSTRAINT "vouchers_per_user_limit_check" CHECK ("per_user_limit" >= 0),
+    ADD CONSTRAINT "vouchers_min_installment_amount_check" CHECK ("min_installment_amount" >= 0),
+    ADD CONSTRAINT "vouchers_date_check" CHECK ("expire_date" >= "active_date");
+
+ALTER TABLE "restructurings"
+    ADD CONSTRAINT "restructurings_tenor_check" CHECK ("tenor" > 0),
+    ADD CONSTRAINT "restructurings_amount_check" CHECK ("outstanding_amount" >= 0 AND "capitalized_fine" >= 0 AND
+                                                        "total_amount" >= 0 AND "installment_amount" >= 0);
+
+ALTER TABLE "credit_limit_proposals"
+    ADD CONSTRAINT "credit_limit_proposals_limit_check" CHECK ("current_limit" >= 0 AND "proposed_limit" >= 0);
+
+ALTER TABLE "write_offs"
+    ADD CONSTRAINT "write_offs_loss_check" CHECK ("principal_loss" >= 0 AND "fine_loss" >= 0);
+
+ALTER TABLE "promise_to_pays"
+    ADD CONSTRAINT "promise_to_pays_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "collection_notes"
+    ADD CONSTRAINT "collection_notes_channel_check" CHECK ("channel" IN ('call', 'sms', 'email', 'whatsapp', 'visit'));
+
+ALTER TABLE "statement_imports"
+    ADD CONSTRAINT "statement_imports_format_check" CHECK ("format" IN ('csv', 'mt940'));
+
+CREATE INDEX "debtors_user_id_idx" ON "debtors" ("user_id");
+CREATE INDEX "lendings_debtor_id_idx" ON "lendings" ("debtor_id");
+CREATE INDEX "lendings_lending_status_id_idx" ON "lendings" ("lending_status_id");
+CREATE INDEX "installments_lending_id_idx" ON "installments" ("lending_id");
+CREATE INDEX "installments_due_date_idx" ON "installments" ("due_date");
+CREATE INDEX "payments_installment_id_idx" ON "payments" ("installment_id");
+CREATE INDEX "payments_voucher_id_idx" ON "payments" ("voucher_id");
+CREATE INDEX "payments_payment_date_idx" ON "payments" ("payment_date");
+CREATE INDEX "payment_intents_installment_id_idx" ON "payment_intents" ("installment_id");
+CREATE INDEX "voucher_redemptions_voucher_id_debtor_id_idx" ON "voucher_redemptions" ("voucher_id", "debtor_id");
+
+CREATE INDEX "users_name_trgm_idx" ON "users" USING gin ("name" gin_trgm_ops);
+CREATE INDEX "users_email_trgm_idx" ON "users" USING gin ("email" gin_trgm_ops);
+CREATE INDEX "lendings_name_trgm_idx" ON "lendings" USING gin ("name" gin_trgm_ops);
+CREATE INDEX "loan_products_name_trgm_idx" ON "loan_products" USING gin ("name" gin_trgm_ops);
+CREATE INDEX "vouchers_name_trgm_idx" ON "vouchers" USING gin ("name" gin_trgm_ops);
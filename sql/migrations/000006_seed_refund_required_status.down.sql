@@ -0,0 +1,3 @@
+DELETE FROM "payment_intent_status_types"
+WHERE payment_intent_status_id = 5
+  AND NOT EXISTS (SELECT 1 FROM "payment_intents" WHERE payment_intent_status_id = 5);
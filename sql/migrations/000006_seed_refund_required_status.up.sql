@@ -0,0 +1,5 @@
+INSERT INTO "payment_intent_status_types" (payment_intent_status_id, name)
+VALUES (5, 'refund required')
+ON CONFLICT (payment_intent_status_id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('payment_intent_status_types', 'payment_intent_status_id'), (SELECT max(payment_intent_status_id) FROM "payment_intent_status_types"));
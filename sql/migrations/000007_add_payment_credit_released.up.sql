@@ -0,0 +1,9 @@
+ALTER TABLE "payments"
+    ADD COLUMN "credit_released" float NOT NULL DEFAULT 0,
+    ADD CONSTRAINT "payments_credit_released_check" CHECK ("credit_released" >= 0);
+
+-- Payments settled before the column existed released their installment amount.
+UPDATE "payments"
+SET "credit_released" = "installments"."amount"
+FROM "installments"
+WHERE "installments"."installment_id" = "payments"."installment_id";
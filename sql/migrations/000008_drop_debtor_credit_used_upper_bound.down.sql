@@ -0,0 +1,5 @@
+-- Debtors already over their limit would fail a validated check, so the
+-- upper bound only applies to rows written from here on.
+ALTER TABLE "debtors"
+    DROP CONSTRAINT IF EXISTS "debtors_credit_used_check",
+    ADD CONSTRAINT "debtors_credit_used_check" CHECK ("credit_used" >= 0 AND "credit_used" <= "credit_limit") NOT VALID;
@@ -0,0 +1,9 @@
+-- Credit used may legitimately exceed the credit limit: an admin can lower a
+-- limit below what the debtor has already borrowed (directly, in bulk or by
+-- approving a scored proposal), a restructuring capitalizes the late fine and
+-- a reversed payment takes back the credit it released. New loans are still
+-- checked against the limit on the locked debtor; the database only keeps
+-- credit used from going negative.
+ALTER TABLE "debtors"
+    DROP CONSTRAINT IF EXISTS "debtors_credit_used_check",
+    ADD CONSTRAINT "debtors_credit_used_check" CHECK ("credit_used" >= 0);
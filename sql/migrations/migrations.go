@@ -0,0 +1,9 @@
+// Package migrations embeds the numbered schema and seed migrations so they
+// ship inside the binary. Files are named <version>_<name>.up.sql and
+// <version>_<name>.down.sql; seed migrations must be safe to run again.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS